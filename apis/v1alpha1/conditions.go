@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+const (
+	// ConditionTypeReconciled indicates whether the last reconciliation of the OpenTelemetryCollector succeeded.
+	ConditionTypeReconciled = "Reconciled"
+
+	// ConditionTypeAvailable indicates whether all desired collector replicas are ready.
+	ConditionTypeAvailable = "Available"
+
+	// ConditionTypeProgressing indicates whether the collector workload is being rolled out.
+	ConditionTypeProgressing = "Progressing"
+
+	// ConditionTypeConfigValid indicates whether the collector configuration can be parsed.
+	ConditionTypeConfigValid = "ConfigValid"
+
+	// ConditionTypeTargetAllocatorReady indicates whether all desired target allocator replicas are ready and
+	// the target allocator accepts its configuration. It is only set when the target allocator is enabled.
+	ConditionTypeTargetAllocatorReady = "TargetAllocatorReady"
+)
+
+const (
+	// ReasonReconcileSucceeded is used when all reconciliation tasks succeeded.
+	ReasonReconcileSucceeded = "ReconcileSucceeded"
+
+	// ReasonReplicasReady is used when all desired replicas of a workload are ready.
+	ReasonReplicasReady = "ReplicasReady"
+
+	// ReasonReplicasNotReady is used when some desired replicas of a workload aren't ready yet.
+	ReasonReplicasNotReady = "ReplicasNotReady"
+
+	// ReasonWorkloadNotFound is used when the workload of the OpenTelemetryCollector doesn't exist (yet).
+	ReasonWorkloadNotFound = "WorkloadNotFound"
+
+	// ReasonRollingOut is used while a workload is being updated.
+	ReasonRollingOut = "RollingOut"
+
+	// ReasonRolloutComplete is used once a workload is fully updated.
+	ReasonRolloutComplete = "RolloutComplete"
+
+	// ReasonSidecar is used for the availability of collectors running as sidecars, as they have no workload.
+	ReasonSidecar = "Sidecar"
+
+	// ReasonConfigValid is used when the configuration can be parsed.
+	ReasonConfigValid = "ConfigValid"
+
+	// ReasonConfigInvalid is used when the configuration can't be parsed.
+	ReasonConfigInvalid = "ConfigInvalid"
+
+	// ReasonTargetAllocatorConfigRejected is used when the target allocator would reject its configuration.
+	ReasonTargetAllocatorConfigRejected = "ConfigRejected"
+)
@@ -19,6 +19,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // Ingress is used to specify how OpenTelemetry Collector is exposed. This
@@ -33,7 +34,7 @@ import (
 // SEE: OpenTelemetryCollector.spec.ports[index].
 type Ingress struct {
 	// Type default value is: ""
-	// Supported types are: ingress
+	// Supported types are: ingress, route, gateway
 	Type IngressType `json:"type,omitempty"`
 
 	// Hostname by which the ingress proxy can be reached.
@@ -45,20 +46,45 @@ type Ingress struct {
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
 
-	// TLS configuration.
+	// TLS configuration. With the subdomain rule type, the hosts of the receivers are filled in for
+	// entries without hosts, so that e.g. cert-manager issues a single secret covering all receivers.
 	// +optional
 	TLS []networkingv1.IngressTLS `json:"tls,omitempty"`
 
+	// GenerateTLSSecret generates a self-signed certificate for the hosts of the ingress, stored in the
+	// secret "<name>-ingress-tls" and referenced by the ingress. It is renewed 30 days before it expires.
+	// It can't be combined with TLS or TLSPassthrough, and requires the hostname.
+	// +optional
+	GenerateTLSSecret bool `json:"generateTLSSecret,omitempty"`
+
+	// TLSPassthrough lets the ingress controller pass the TLS connections through to the collector,
+	// which terminates them itself. This allows OTLP/gRPC clients to connect to the receivers directly.
+	// It requires the subdomain rule type, as the connections are routed by their SNI.
+	// +optional
+	TLSPassthrough bool `json:"tlsPassthrough,omitempty"`
+
 	// IngressClassName is the name of an IngressClass cluster resource. Ingress
 	// controller implementations use this field to know whether they should be
 	// serving this Ingress resource.
 	// +optional
 	IngressClassName *string `json:"ingressClassName,omitempty"`
 
+	// RuleType defines how Ingress exposes collector receivers.
+	// IngressRuleTypePath ("path") exposes each receiver port on a unique path on single domain defined in Hostname.
+	// IngressRuleTypeSubdomain ("subdomain") exposes each receiver port on a unique subdomain of Hostname.
+	// Default is IngressRuleTypePath ("path").
+	// +optional
+	RuleType IngressRuleType `json:"ruleType,omitempty"`
+
 	// Route is an OpenShift specific section that is only considered when
 	// type "route" is used.
 	// +optional
 	Route OpenShiftRoute `json:"route,omitempty"`
+
+	// Gateway is a Gateway API specific section that is only considered when
+	// type "gateway" is used.
+	// +optional
+	Gateway GatewayReference `json:"gateway,omitempty"`
 }
 
 // OpenShiftRoute defines openshift route specific settings.
@@ -67,6 +93,21 @@ type OpenShiftRoute struct {
 	Termination TLSRouteTerminationType `json:"termination,omitempty"`
 }
 
+// GatewayReference references the Gateway the HTTPRoutes and GRPCRoutes of the collector are attached to.
+type GatewayReference struct {
+	// Name of the Gateway.
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the Gateway. By default the namespace of the collector is used.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the Gateway listener the routes are attached to.
+	// By default the routes are attached to all listeners of the Gateway.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
 // OpenTelemetryCollectorSpec defines the desired state of OpenTelemetryCollector.
 type OpenTelemetryCollectorSpec struct {
 	// Resources to set on the OpenTelemetry Collector pods.
@@ -95,6 +136,19 @@ type OpenTelemetryCollectorSpec struct {
 	//
 	// +optional
 	Autoscaler *AutoscalerSpec `json:"autoscaler,omitempty"`
+	// VerticalAutoscaler specifies the vertical pod autoscaling configuration to use
+	// for the OpenTelemetryCollector workload. The VerticalPodAutoscaler is only created
+	// if the CRDs of the Vertical Pod Autoscaler are installed. When the horizontal pod
+	// autoscaler is enabled too, only the memory of the collectors is controlled.
+	//
+	// +optional
+	VerticalAutoscaler *VerticalAutoscalerSpec `json:"verticalAutoscaler,omitempty"`
+	// PodDisruptionBudget specifies the pod disruption budget configuration to use
+	// for the OpenTelemetryCollector workload. Only relevant to deployment and statefulset mode.
+	// When not set, a budget with maxUnavailable 1 is created if the collector runs with more than one replica.
+	//
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 	// SecurityContext will be set as the container security context.
 	// +optional
 	SecurityContext *v1.SecurityContext `json:"securityContext,omitempty"`
@@ -173,6 +227,10 @@ type OpenTelemetryCollectorSpec struct {
 	// If specified, indicates the pod's scheduling constraints
 	// +optional
 	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// ObservabilitySpec defines how telemetry data gets handled.
+	//
+	// +optional
+	Observability ObservabilitySpec `json:"observability,omitempty"`
 }
 
 // OpenTelemetryTargetAllocator defines the configurations for the Prometheus target allocator.
@@ -205,6 +263,44 @@ type OpenTelemetryTargetAllocator struct {
 	// All CR instances which the ServiceAccount has access to will be retrieved. This includes other namespaces.
 	// +optional
 	PrometheusCR OpenTelemetryTargetAllocatorPrometheusCR `json:"prometheusCR,omitempty"`
+	// Persistence defines whether the target allocator persists the assignments of targets to collectors, so that
+	// targets stay with the same collectors when the target allocator restarts.
+	// +optional
+	Persistence OpenTelemetryTargetAllocatorPersistence `json:"persistence,omitempty"`
+	// Telemetry defines whether the target allocator exports its own metrics and traces using OTLP/HTTP.
+	// +optional
+	Telemetry OpenTelemetryTargetAllocatorTelemetry `json:"telemetry,omitempty"`
+	// PodDisruptionBudget specifies the pod disruption budget configuration to use for the TargetAllocator workload.
+	// When not set, a budget with maxUnavailable 1 is created if the TargetAllocator runs with more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+}
+
+// OpenTelemetryTargetAllocatorTelemetry defines the export of the target allocator's own metrics and traces.
+type OpenTelemetryTargetAllocatorTelemetry struct {
+	// Endpoint is the host and port of the OTLP/HTTP receiver the metrics and traces are sent to, for example
+	// "otel-collector:4318". The export is disabled when no endpoint is set.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Insecure disables TLS for the connection to the endpoint.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+	// Interval defines how often the metrics are exported. Defaults to 30s.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// OpenTelemetryTargetAllocatorPersistence defines the persistence of the target allocator's target assignments.
+type OpenTelemetryTargetAllocatorPersistence struct {
+	// Enabled indicates whether the target assignments are persisted in a ConfigMap named
+	// "<name>-targetallocator-assignments". The operator grants the target allocator's ServiceAccount the
+	// permissions to create, get and update this ConfigMap with a Role and a RoleBinding named
+	// "<name>-targetallocator-persistence". The ConfigMap is deleted with the instance.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval defines how often the target assignments are persisted. Defaults to 30s.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
 }
 
 type OpenTelemetryTargetAllocatorPrometheusCR struct {
@@ -235,6 +331,11 @@ type ScaleSubresourceStatus struct {
 	// OpenTelemetryCollector's deployment or statefulSet.
 	// +optional
 	Replicas int32 `json:"replicas,omitempty"`
+
+	// The number of ready pods targeted by this
+	// OpenTelemetryCollector's deployment or statefulSet.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
 }
 
 // OpenTelemetryCollectorStatus defines the observed state of OpenTelemetryCollector.
@@ -257,6 +358,18 @@ type OpenTelemetryCollectorStatus struct {
 	// +optional
 	// Deprecated: use "OpenTelemetryCollector.Status.Scale.Replicas" instead.
 	Replicas int32 `json:"replicas,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the OpenTelemetryCollector observed by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the OpenTelemetryCollector's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 // +kubebuilder:object:root=true
@@ -265,6 +378,7 @@ type OpenTelemetryCollectorStatus struct {
 // +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.scale.replicas,selectorpath=.status.scale.selector
 // +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode",description="Deployment Mode"
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="OpenTelemetry Version"
+// +kubebuilder:printcolumn:name="Available",type="string",JSONPath=".status.conditions[?(@.type==\"Available\")].status",description="Whether all collector replicas are ready"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Collector"
 // This annotation provides a hint for OLM which resources are managed by OpenTelemetryCollector kind.
@@ -306,6 +420,73 @@ type AutoscalerSpec struct {
 	// +optional
 	// TargetMemoryUtilization sets the target average memory utilization across all replicas
 	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+	// Metrics sets additional metrics the autoscaler scales on, like the queue size or the dropped spans of the
+	// collectors. Supported types are Pods, Object and External, CPU and memory are set with
+	// TargetCPUUtilization and TargetMemoryUtilization.
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+}
+
+// VerticalAutoscalerSpec defines the OpenTelemetryCollector's vertical pod autoscaler specification.
+// The memory_limiter processor of the collector should be configured with limit_percentage and
+// spike_limit_percentage, so that its limits follow the memory recommended by the autoscaler.
+type VerticalAutoscalerSpec struct {
+	// UpdateMode controls when the recommended resources are applied to the collector pods.
+	// Defaults to Auto.
+	// +optional
+	UpdateMode VerticalAutoscalerUpdateMode `json:"updateMode,omitempty"`
+	// MinAllowed sets a lower bound to the resources recommended for the collector container.
+	// +optional
+	MinAllowed v1.ResourceList `json:"minAllowed,omitempty"`
+	// MaxAllowed sets an upper bound to the resources recommended for the collector container.
+	// +optional
+	MaxAllowed v1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+type (
+	// VerticalAutoscalerUpdateMode controls when the vertical pod autoscaler applies its recommendations.
+	// +kubebuilder:validation:Enum=Off;Initial;Recreate;Auto
+	VerticalAutoscalerUpdateMode string
+)
+
+const (
+	// VerticalAutoscalerUpdateModeOff only computes recommendations, without applying them.
+	VerticalAutoscalerUpdateModeOff VerticalAutoscalerUpdateMode = "Off"
+	// VerticalAutoscalerUpdateModeInitial applies the recommendations when pods are created.
+	VerticalAutoscalerUpdateModeInitial VerticalAutoscalerUpdateMode = "Initial"
+	// VerticalAutoscalerUpdateModeRecreate applies the recommendations when pods are created, and evicts
+	// pods whose resources differ significantly from the recommendations.
+	VerticalAutoscalerUpdateModeRecreate VerticalAutoscalerUpdateMode = "Recreate"
+	// VerticalAutoscalerUpdateModeAuto currently behaves like Recreate.
+	VerticalAutoscalerUpdateModeAuto VerticalAutoscalerUpdateMode = "Auto"
+)
+
+// ObservabilitySpec defines how telemetry data gets handled.
+type ObservabilitySpec struct {
+	// Metrics defines the metrics configuration for operands.
+	//
+	// +optional
+	Metrics MetricsConfigSpec `json:"metrics,omitempty"`
+}
+
+// MetricsConfigSpec defines a metrics config.
+type MetricsConfigSpec struct {
+	// EnableMetrics specifies if ServiceMonitors should be created for the OpenTelemetry Collector and the
+	// TargetAllocator. The ServiceMonitors are only created if the Prometheus operator's CRDs are installed.
+	//
+	// +optional
+	EnableMetrics bool `json:"enableMetrics,omitempty"`
+}
+
+// PodDisruptionBudgetSpec defines the OpenTelemetryCollector's pod disruption budget specification.
+// At most one of MinAvailable and MaxUnavailable can be set, MaxUnavailable defaults to 1 when none is set.
+type PodDisruptionBudgetSpec struct {
+	// MinAvailable is the number or percentage of pods which must still be available after an eviction.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// MaxUnavailable is the number or percentage of pods which can be unavailable after an eviction.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 func init() {
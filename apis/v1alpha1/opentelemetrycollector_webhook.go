@@ -17,6 +17,7 @@ package v1alpha1
 import (
 	"fmt"
 
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -83,7 +84,7 @@ func (r *OpenTelemetryCollector) Default() {
 			}
 		}
 
-		if r.Spec.Autoscaler.TargetMemoryUtilization == nil && r.Spec.Autoscaler.TargetCPUUtilization == nil {
+		if r.Spec.Autoscaler.TargetMemoryUtilization == nil && r.Spec.Autoscaler.TargetCPUUtilization == nil && len(r.Spec.Autoscaler.Metrics) == 0 {
 			defaultCPUTarget := int32(90)
 			r.Spec.Autoscaler.TargetCPUUtilization = &defaultCPUTarget
 		}
@@ -91,6 +92,9 @@ func (r *OpenTelemetryCollector) Default() {
 	if r.Spec.Ingress.Type == IngressTypeRoute && r.Spec.Ingress.Route.Termination == "" {
 		r.Spec.Ingress.Route.Termination = TLSRouteTerminationTypeEdge
 	}
+	if r.Spec.Ingress.Type == IngressTypeNginx && r.Spec.Ingress.RuleType == "" {
+		r.Spec.Ingress.RuleType = IngressRuleTypePath
+	}
 }
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-opentelemetry-io-v1alpha1-opentelemetrycollector,mutating=false,failurePolicy=fail,groups=opentelemetry.io,resources=opentelemetrycollectors,versions=v1alpha1,name=vopentelemetrycollectorcreateupdate.kb.io,sideEffects=none,admissionReviewVersions=v1
@@ -219,6 +223,36 @@ func (r *OpenTelemetryCollector) validateCRDSpec() error {
 		if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.TargetMemoryUtilization != nil && (*r.Spec.Autoscaler.TargetMemoryUtilization < int32(1) || *r.Spec.Autoscaler.TargetMemoryUtilization > int32(99)) {
 			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, targetMemoryUtilization should be greater than 0 and less than 100")
 		}
+		if r.Spec.Autoscaler != nil {
+			for i, metric := range r.Spec.Autoscaler.Metrics {
+				if err := validateAutoscalerMetric(metric); err != nil {
+					return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, metrics[%d]: %w", i, err)
+				}
+			}
+		}
+	}
+
+	// validate vertical pod autoscaler
+	if r.Spec.Mode == ModeSidecar && r.Spec.VerticalAutoscaler != nil {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'verticalAutoscaler'", r.Spec.Mode)
+	}
+	if err := validateVerticalAutoscaler(r.Spec.VerticalAutoscaler); err != nil {
+		return fmt.Errorf("the OpenTelemetry Spec verticalAutoscaler configuration is incorrect, %w", err)
+	}
+	// both autoscalers would act on the memory usage of the collectors, and work against each other
+	if r.Spec.VerticalAutoscaler != nil && r.Spec.Autoscaler != nil && r.Spec.Autoscaler.TargetMemoryUtilization != nil {
+		return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, targetMemoryUtilization can't be used along with the verticalAutoscaler")
+	}
+
+	// validate pod disruption budgets
+	if r.Spec.Mode == ModeSidecar && r.Spec.PodDisruptionBudget != nil {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'podDisruptionBudget'", r.Spec.Mode)
+	}
+	if err := validatePodDisruptionBudget(r.Spec.PodDisruptionBudget); err != nil {
+		return fmt.Errorf("the OpenTelemetry Spec podDisruptionBudget configuration is incorrect, %w", err)
+	}
+	if err := validatePodDisruptionBudget(r.Spec.TargetAllocator.PodDisruptionBudget); err != nil {
+		return fmt.Errorf("the OpenTelemetry Spec targetAllocator podDisruptionBudget configuration is incorrect, %w", err)
 	}
 
 	if r.Spec.Ingress.Type == IngressTypeNginx && r.Spec.Mode == ModeSidecar {
@@ -227,5 +261,106 @@ func (r *OpenTelemetryCollector) validateCRDSpec() error {
 		)
 	}
 
+	if r.Spec.Ingress.TLSPassthrough && r.Spec.Ingress.RuleType != IngressRuleTypeSubdomain {
+		return fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect. TLS passthrough requires the rule type %s", IngressRuleTypeSubdomain)
+	}
+	if r.Spec.Ingress.RuleType == IngressRuleTypeSubdomain && r.Spec.Ingress.Hostname == "" {
+		return fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect. The hostname is required for the rule type %s", IngressRuleTypeSubdomain)
+	}
+	if r.Spec.Ingress.GenerateTLSSecret {
+		if r.Spec.Ingress.Hostname == "" {
+			return fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect. The hostname is required to generate the TLS secret")
+		}
+		if len(r.Spec.Ingress.TLS) > 0 || r.Spec.Ingress.TLSPassthrough {
+			return fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect. The TLS secret can't be generated along with the TLS configuration or TLS passthrough")
+		}
+	}
+
+	if r.Spec.Ingress.Type == IngressTypeGateway {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect. Gateway routes can only be used in combination with the modes: %s, %s, %s",
+				ModeDeployment, ModeDaemonSet, ModeStatefulSet,
+			)
+		}
+		if r.Spec.Ingress.Gateway.Name == "" {
+			return fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect. The gateway name is required for the ingress type %s", IngressTypeGateway)
+		}
+		if r.Spec.Ingress.Hostname == "" {
+			return fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect. The hostname is required for the ingress type %s", IngressTypeGateway)
+		}
+	}
+
+	return nil
+}
+
+// validateAutoscalerMetric checks that the source matching the type of the metric is set, and that its target
+// is supported by the source.
+func validateAutoscalerMetric(metric autoscalingv2.MetricSpec) error {
+	var (
+		name   string
+		target autoscalingv2.MetricTarget
+	)
+	switch metric.Type {
+	case autoscalingv2.PodsMetricSourceType:
+		if metric.Pods == nil {
+			return fmt.Errorf("pods must be set for the metric type %s", metric.Type)
+		}
+		if metric.Pods.Target.Type != autoscalingv2.AverageValueMetricType {
+			return fmt.Errorf("the target type of pods metrics must be %s", autoscalingv2.AverageValueMetricType)
+		}
+		name, target = metric.Pods.Metric.Name, metric.Pods.Target
+	case autoscalingv2.ObjectMetricSourceType:
+		if metric.Object == nil {
+			return fmt.Errorf("object must be set for the metric type %s", metric.Type)
+		}
+		if metric.Object.DescribedObject.Kind == "" || metric.Object.DescribedObject.Name == "" {
+			return fmt.Errorf("the kind and name of the described object must be set")
+		}
+		name, target = metric.Object.Metric.Name, metric.Object.Target
+	case autoscalingv2.ExternalMetricSourceType:
+		if metric.External == nil {
+			return fmt.Errorf("external must be set for the metric type %s", metric.Type)
+		}
+		name, target = metric.External.Metric.Name, metric.External.Target
+	default:
+		return fmt.Errorf("unsupported metric type %q, supported types are %s, %s and %s", metric.Type,
+			autoscalingv2.PodsMetricSourceType, autoscalingv2.ObjectMetricSourceType, autoscalingv2.ExternalMetricSourceType)
+	}
+
+	if name == "" {
+		return fmt.Errorf("the metric name must be set")
+	}
+	switch target.Type {
+	case autoscalingv2.ValueMetricType:
+		if target.Value == nil || target.Value.Sign() <= 0 {
+			return fmt.Errorf("the target value must be greater than 0")
+		}
+	case autoscalingv2.AverageValueMetricType:
+		if target.AverageValue == nil || target.AverageValue.Sign() <= 0 {
+			return fmt.Errorf("the target averageValue must be greater than 0")
+		}
+	default:
+		return fmt.Errorf("unsupported target type %q, supported types are %s and %s", target.Type,
+			autoscalingv2.ValueMetricType, autoscalingv2.AverageValueMetricType)
+	}
+	return nil
+}
+
+func validateVerticalAutoscaler(vpa *VerticalAutoscalerSpec) error {
+	if vpa == nil {
+		return nil
+	}
+	for name, minAllowed := range vpa.MinAllowed {
+		if maxAllowed, ok := vpa.MaxAllowed[name]; ok && minAllowed.Cmp(maxAllowed) > 0 {
+			return fmt.Errorf("minAllowed %s must not be greater than maxAllowed %s", name, name)
+		}
+	}
+	return nil
+}
+
+func validatePodDisruptionBudget(pdb *PodDisruptionBudgetSpec) error {
+	if pdb != nil && pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		return fmt.Errorf("minAvailable and maxUnavailable are mutually exclusive")
+	}
 	return nil
 }
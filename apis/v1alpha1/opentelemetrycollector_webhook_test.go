@@ -21,7 +21,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestOTELColDefaultingWebhook(t *testing.T) {
@@ -157,6 +159,33 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Missing ingress rule type",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Ingress: Ingress{
+						Type: IngressTypeNginx,
+					},
+				},
+			},
+			expected: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					},
+				},
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Ingress: Ingress{
+						Type:     IngressTypeNginx,
+						RuleType: IngressRuleTypePath,
+					},
+					Replicas:        &one,
+					UpgradeStrategy: UpgradeStrategyAutomatic,
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -175,6 +204,7 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 	one := int32(1)
 	three := int32(3)
 	five := int32(5)
+	queueSize := resource.MustParse("100")
 
 	tests := []struct { //nolint:govet
 		name        string
@@ -233,6 +263,18 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 							},
 						},
 						TargetCPUUtilization: &five,
+						Metrics: []autoscalingv2.MetricSpec{
+							{
+								Type: autoscalingv2.PodsMetricSourceType,
+								Pods: &autoscalingv2.PodsMetricSource{
+									Metric: autoscalingv2.MetricIdentifier{Name: "otelcol_exporter_queue_size"},
+									Target: autoscalingv2.MetricTarget{
+										Type:         autoscalingv2.AverageValueMetricType,
+										AverageValue: &queueSize,
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -420,6 +462,114 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpenTelemetry Spec autoscale configuration is incorrect, minReplicas must not be greater than maxReplicas",
 		},
+		{
+			name: "invalid autoscaler metric type",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Autoscaler: &AutoscalerSpec{
+						MaxReplicas: &three,
+						Metrics: []autoscalingv2.MetricSpec{
+							{Type: autoscalingv2.ResourceMetricSourceType},
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec autoscale configuration is incorrect, metrics[0]: unsupported metric type \"Resource\"",
+		},
+		{
+			name: "missing autoscaler metric source",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Autoscaler: &AutoscalerSpec{
+						MaxReplicas: &three,
+						Metrics: []autoscalingv2.MetricSpec{
+							{Type: autoscalingv2.ExternalMetricSourceType},
+						},
+					},
+				},
+			},
+			expectedErr: "metrics[0]: external must be set for the metric type External",
+		},
+		{
+			name: "invalid autoscaler pods metric target",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Autoscaler: &AutoscalerSpec{
+						MaxReplicas: &three,
+						Metrics: []autoscalingv2.MetricSpec{
+							{
+								Type: autoscalingv2.PodsMetricSourceType,
+								Pods: &autoscalingv2.PodsMetricSource{
+									Metric: autoscalingv2.MetricIdentifier{Name: "otelcol_exporter_queue_size"},
+									Target: autoscalingv2.MetricTarget{
+										Type:  autoscalingv2.ValueMetricType,
+										Value: &queueSize,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "metrics[0]: the target type of pods metrics must be AverageValue",
+		},
+		{
+			name: "missing autoscaler metric target value",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Autoscaler: &AutoscalerSpec{
+						MaxReplicas: &three,
+						Metrics: []autoscalingv2.MetricSpec{
+							{
+								Type: autoscalingv2.ExternalMetricSourceType,
+								External: &autoscalingv2.ExternalMetricSource{
+									Metric: autoscalingv2.MetricIdentifier{Name: "otelcol_processor_dropped_spans"},
+									Target: autoscalingv2.MetricTarget{
+										Type: autoscalingv2.ValueMetricType,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "metrics[0]: the target value must be greater than 0",
+		},
+		{
+			name: "invalid mode with verticalAutoscaler",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:               ModeSidecar,
+					VerticalAutoscaler: &VerticalAutoscalerSpec{},
+				},
+			},
+			expectedErr: "does not support the attribute 'verticalAutoscaler'",
+		},
+		{
+			name: "invalid verticalAutoscaler bounds",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					VerticalAutoscaler: &VerticalAutoscalerSpec{
+						MinAllowed: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+						MaxAllowed: v1.ResourceList{v1.ResourceMemory: resource.MustParse("512Mi")},
+					},
+				},
+			},
+			expectedErr: "minAllowed memory must not be greater than maxAllowed memory",
+		},
+		{
+			name: "verticalAutoscaler conflicting with memory autoscaling",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Autoscaler: &AutoscalerSpec{
+						MaxReplicas:             &three,
+						TargetMemoryUtilization: &five,
+					},
+					VerticalAutoscaler: &VerticalAutoscalerSpec{},
+				},
+			},
+			expectedErr: "targetMemoryUtilization can't be used along with the verticalAutoscaler",
+		},
 		{
 			name: "invalid deployment mode incompabible with ingress settings",
 			otelcol: OpenTelemetryCollector{
@@ -434,6 +584,99 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 				ModeDeployment, ModeDaemonSet, ModeStatefulSet,
 			),
 		},
+		{
+			name: "invalid deployment mode incompabible with gateway ingress settings",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					Ingress: Ingress{
+						Type:     IngressTypeGateway,
+						Hostname: "example.com",
+						Gateway:  GatewayReference{Name: "gateway"},
+					},
+				},
+			},
+			expectedErr: fmt.Sprintf("Gateway routes can only be used in combination with the modes: %s, %s, %s",
+				ModeDeployment, ModeDaemonSet, ModeStatefulSet,
+			),
+		},
+		{
+			name: "tls passthrough without subdomain rule type",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ingress: Ingress{
+						Type:           IngressTypeNginx,
+						RuleType:       IngressRuleTypePath,
+						Hostname:       "example.com",
+						TLSPassthrough: true,
+					},
+				},
+			},
+			expectedErr: "TLS passthrough requires the rule type subdomain",
+		},
+		{
+			name: "subdomain rule type without hostname",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ingress: Ingress{
+						Type:     IngressTypeNginx,
+						RuleType: IngressRuleTypeSubdomain,
+					},
+				},
+			},
+			expectedErr: "The hostname is required for the rule type subdomain",
+		},
+		{
+			name: "generated tls secret without hostname",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ingress: Ingress{
+						Type:              IngressTypeNginx,
+						GenerateTLSSecret: true,
+					},
+				},
+			},
+			expectedErr: "The hostname is required to generate the TLS secret",
+		},
+		{
+			name: "generated tls secret with tls passthrough",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ingress: Ingress{
+						Type:              IngressTypeNginx,
+						RuleType:          IngressRuleTypeSubdomain,
+						Hostname:          "example.com",
+						TLSPassthrough:    true,
+						GenerateTLSSecret: true,
+					},
+				},
+			},
+			expectedErr: "The TLS secret can't be generated along with the TLS configuration or TLS passthrough",
+		},
+		{
+			name: "missing gateway name",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ingress: Ingress{
+						Type:     IngressTypeGateway,
+						Hostname: "example.com",
+					},
+				},
+			},
+			expectedErr: "The gateway name is required for the ingress type gateway",
+		},
+		{
+			name: "missing hostname with gateway",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ingress: Ingress{
+						Type:    IngressTypeGateway,
+						Gateway: GatewayReference{Name: "gateway"},
+					},
+				},
+			},
+			expectedErr: "The hostname is required for the ingress type gateway",
+		},
 		{
 			name: "invalid mode with priorityClassName",
 			otelcol: OpenTelemetryCollector{
@@ -470,6 +713,42 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "does not support the attribute 'affinity'",
 		},
+		{
+			name: "invalid mode with podDisruptionBudget",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                ModeSidecar,
+					PodDisruptionBudget: &PodDisruptionBudgetSpec{},
+				},
+			},
+			expectedErr: "does not support the attribute 'podDisruptionBudget'",
+		},
+		{
+			name: "invalid podDisruptionBudget",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					PodDisruptionBudget: &PodDisruptionBudgetSpec{
+						MinAvailable:   &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+						MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "50%"},
+					},
+				},
+			},
+			expectedErr: "minAvailable and maxUnavailable are mutually exclusive",
+		},
+		{
+			name: "invalid targetAllocator podDisruptionBudget",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						PodDisruptionBudget: &PodDisruptionBudgetSpec{
+							MinAvailable:   &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+							MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+						},
+					},
+				},
+			},
+			expectedErr: "targetAllocator podDisruptionBudget configuration is incorrect",
+		},
 	}
 
 	for _, test := range tests {
@@ -17,23 +17,47 @@ package agent
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"gopkg.in/yaml.v3"
-
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/identity"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/logger"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/metrics"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/operator"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/telemetry"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/config"
 
 	"github.com/oklog/ulid/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
 
 	"github.com/open-telemetry/opamp-go/client"
 	"github.com/open-telemetry/opamp-go/client/types"
 	"github.com/open-telemetry/opamp-go/protobufs"
 )
 
+const (
+	// collectorsAttribute is the non-identifying attribute of the agent description holding the state of the collectors.
+	collectorsAttribute = "opentelemetry.collectors"
+	// applyModeAttribute is the non-identifying attribute of the agent description holding the apply mode of the agent.
+	applyModeAttribute = "opamp.bridge.apply_mode"
+	// tracerName is the name of the tracer of the spans reported as the own traces of the agent.
+	tracerName = "github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/agent"
+)
+
 type Agent struct {
 	logger types.Logger
 
@@ -42,26 +66,58 @@ type Agent struct {
 	lastHash    []byte
 
 	instanceId         ulid.ULID
+	instanceIdStore    identity.Store
 	agentDescription   *protobufs.AgentDescription
 	remoteConfigStatus *protobufs.RemoteConfigStatus
 
-	opampClient         client.OpAMPClient
 	metricReporter      *metrics.MetricReporter
+	tracerProvider      *sdktrace.TracerProvider
+	tracer              trace.Tracer
+	logSink             *logger.ExportingSink
+	logExporter         *telemetry.LogExporter
 	config              config.Config
 	applier             operator.ConfigApplier
 	remoteConfigEnabled bool
+
+	header    http.Header
+	tlsConfig *tls.Config
+	// opampClient is replaced when the headers change, while the callbacks of the previous client may still use it, so
+	// it's read without blocking. clientLock serializes its replacement and the shutdown of the agent.
+	opampClient atomic.Pointer[client.OpAMPClient]
+	// newClient creates the OpAMP client replacing the current one when the headers change.
+	newClient  func() client.OpAMPClient
+	clientLock sync.Mutex
+
+	// statusLock guards the state reported by Status, which is read by the HTTP server of the bridge.
+	statusLock      sync.Mutex
+	started         bool
+	connected       bool
+	lastMessageTime time.Time
+	applyResults    map[string]ApplyResult
+
+	done chan struct{}
 }
 
-func NewAgent(logger types.Logger, applier operator.ConfigApplier, config config.Config, opampClient client.OpAMPClient) *Agent {
+// NewAgent creates an agent. The instance UID is persisted to the instanceIdStore if it isn't nil. The own logs of the
+// agent are exported through the logSink, they aren't reported if it's nil.
+func NewAgent(logger types.Logger, applier operator.ConfigApplier, config config.Config, opampClient client.OpAMPClient, instanceIdStore identity.Store, logSink *logger.ExportingSink) *Agent {
 	agent := &Agent{
 		config:              config,
 		applier:             applier,
 		logger:              logger,
 		appliedKeys:         map[collectorKey]bool{},
+		applyResults:        map[string]ApplyResult{},
 		instanceId:          config.GetNewInstanceId(),
-		agentDescription:    config.GetDescription(),
+		instanceIdStore:     instanceIdStore,
+		agentDescription:    newAgentDescription(config),
 		remoteConfigEnabled: config.RemoteConfigEnabled(),
-		opampClient:         opampClient,
+		tracer:              trace.NewNoopTracerProvider().Tracer(tracerName),
+		logSink:             logSink,
+		done:                make(chan struct{}),
+	}
+	agent.opampClient.Store(&opampClient)
+	agent.newClient = func() client.OpAMPClient {
+		return config.CreateClient(logger)
 	}
 
 	agent.logger.Debugf("Agent created, id=%v, type=%s, version=%s.",
@@ -70,23 +126,149 @@ func NewAgent(logger types.Logger, applier operator.ConfigApplier, config config
 	return agent
 }
 
-// TODO: Something should run on a schedule to set the health of the OpAMP client.
-func (agent *Agent) getHealth() *protobufs.AgentHealth {
-	return &protobufs.AgentHealth{
-		Healthy:           true,
-		StartTimeUnixNano: agent.startTime,
-		LastError:         "",
+// getStatus computes the health and the description of the agent in a single pass over the collectors it manages.
+// The agent is healthy if all collectors are, otherwise the last error is the one of the first unhealthy collector. The
+// description holds the observed state of every collector as a non-identifying attribute mapping the collector keys to
+// their state. It's nil, along with the error, if the state of a collector couldn't be read.
+func (agent *Agent) getStatus() (*protobufs.ComponentHealth, *protobufs.AgentDescription, error) {
+	health := &protobufs.ComponentHealth{
+		Healthy:            true,
+		StartTimeUnixNano:  agent.startTime,
+		StatusTimeUnixNano: uint64(time.Now().UnixNano()),
+		ComponentHealthMap: map[string]*protobufs.ComponentHealth{},
+	}
+	instances, err := agent.applier.ListInstances()
+	if err != nil {
+		err = fmt.Errorf("couldn't list instances: %w", err)
+		health.Healthy = false
+		health.LastError = err.Error()
+		return health, nil, err
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return newCollectorKey(instances[i].GetName(), instances[i].GetNamespace()).String() <
+			newCollectorKey(instances[j].GetName(), instances[j].GetNamespace()).String()
+	})
+	healthy := 0
+	collectors := &protobufs.KeyValueList{}
+	var statusErr error
+	for _, instance := range instances {
+		key := newCollectorKey(instance.GetName(), instance.GetNamespace()).String()
+		status, err := agent.applier.GetCollectorStatus(instance)
+		collectorHealth := &protobufs.ComponentHealth{
+			Healthy:            false,
+			StatusTimeUnixNano: health.StatusTimeUnixNano,
+		}
+		if err != nil {
+			collectorHealth.LastError = err.Error()
+			if statusErr == nil {
+				statusErr = fmt.Errorf("couldn't get the state of %s: %w", key, err)
+			}
+		} else {
+			collectorHealth = status.Health
+			collectors.Values = append(collectors.Values, &protobufs.KeyValue{
+				Key: key,
+				Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_KvlistValue{KvlistValue: &protobufs.KeyValueList{
+					Values: []*protobufs.KeyValue{
+						intKeyValue("pods", status.Pods),
+						intKeyValue("ready_pods", status.ReadyPods),
+						stringKeyValue("image", status.Image),
+						stringKeyValue("version", status.Version),
+						stringKeyValue("reconcile_result", status.ReconcileResult),
+					},
+				}}},
+			})
+		}
+		health.ComponentHealthMap[key] = collectorHealth
+		if collectorHealth.Healthy {
+			healthy++
+		} else if health.Healthy {
+			health.Healthy = false
+			health.LastError = fmt.Sprintf("%s: %s", key, collectorHealth.LastError)
+		}
+	}
+	health.Status = fmt.Sprintf("%d/%d collectors healthy", healthy, len(instances))
+	if statusErr != nil {
+		return health, nil, statusErr
+	}
+
+	description := proto.Clone(agent.agentDescription).(*protobufs.AgentDescription)
+	description.NonIdentifyingAttributes = append(description.NonIdentifyingAttributes, &protobufs.KeyValue{
+		Key:   collectorsAttribute,
+		Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_KvlistValue{KvlistValue: collectors}},
+	})
+	return health, description, nil
+}
+
+// newAgentDescription returns the description of the agent for the given configuration, along with its apply mode.
+func newAgentDescription(config config.Config) *protobufs.AgentDescription {
+	description := config.GetDescription()
+	description.NonIdentifyingAttributes = append(description.NonIdentifyingAttributes,
+		stringKeyValue(applyModeAttribute, string(config.GetApplyMode())))
+	return description
+}
+
+func stringKeyValue(key string, value string) *protobufs.KeyValue {
+	return &protobufs.KeyValue{Key: key, Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: value}}}
+}
+
+func intKeyValue(key string, value int) *protobufs.KeyValue {
+	return &protobufs.KeyValue{Key: key, Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_IntValue{IntValue: int64(value)}}}
+}
+
+// reportStatus reports the health of the agent, and the agent description if the state of a collector changed.
+func (agent *Agent) reportStatus() {
+	health, description, err := agent.getStatus()
+	if err := agent.getClient().SetHealth(health); err != nil {
+		agent.logger.Errorf("couldn't report the health: %v", err)
+	}
+	if err != nil {
+		agent.logger.Errorf("couldn't get the state of the collectors: %v", err)
+		return
+	}
+	if proto.Equal(description, agent.getClient().AgentDescription()) {
+		return
+	}
+	if err := agent.getClient().SetAgentDescription(description); err != nil {
+		agent.logger.Errorf("couldn't report the state of the collectors: %v", err)
+	}
+}
+
+// run reports the status of the agent and reloads the headers at the configured intervals until the agent is shut
+// down.
+func (agent *Agent) run() {
+	healthTicker := time.NewTicker(agent.config.GetHealthCheckInterval())
+	defer healthTicker.Stop()
+	// The headers are only reloaded if a value can change, a nil channel never fires.
+	var headerReload <-chan time.Time
+	if agent.config.HasReloadableHeaders() {
+		headerTicker := time.NewTicker(agent.config.GetHeaderReloadInterval())
+		defer headerTicker.Stop()
+		headerReload = headerTicker.C
+	}
+	for {
+		select {
+		case <-agent.done:
+			return
+		case <-healthTicker.C:
+			agent.reportStatus()
+		case <-headerReload:
+			if err := agent.reloadHeaders(); err != nil {
+				agent.logger.Errorf("couldn't reload the headers: %v", err)
+			}
+		}
 	}
 }
 
 // onConnect is called when an agent is successfully connected to a server.
 func (agent *Agent) onConnect() {
 	agent.logger.Debugf("Connected to the server.")
+	agent.setConnected(true)
 }
 
 // onConnectFailed is called when an agent was unable to connect to a server.
 func (agent *Agent) onConnectFailed(err error) {
 	agent.logger.Errorf("Failed to connect to the server: %v", err)
+	agent.setConnected(false)
 }
 
 // onError is called when an agent receives an error response from the server.
@@ -102,8 +284,69 @@ func (agent *Agent) saveRemoteConfigStatus(_ context.Context, status *protobufs.
 // Start sets up the callbacks for the OpAMP client and begins the client's connection to the server.
 func (agent *Agent) Start() error {
 	agent.startTime = uint64(time.Now().UnixNano())
+	if agent.instanceIdStore != nil {
+		if err := agent.loadInstanceId(); err != nil {
+			return err
+		}
+	}
+	agent.applier.SetOwner(agent.instanceId.String())
+	if err := agent.loadAppliedKeys(); err != nil {
+		return err
+	}
+	header, err := agent.config.GetHeaders()
+	if err != nil {
+		return err
+	}
+	agent.header = header
+	tlsConfig, err := agent.config.GetTLSConfig()
+	if err != nil {
+		return err
+	}
+	agent.tlsConfig = tlsConfig
+
+	err = agent.startClient()
+	if err != nil {
+		return err
+	}
+	agent.setStarted(true)
+
+	go agent.run()
+
+	return nil
+}
+
+// loadAppliedKeys rebuilds the keys of the resources applied from the remote configuration from the resources owned by
+// the agent in the cluster, so that the resources of the keys removed from the remote configuration are deleted across
+// restarts. Only the resources labelled with the instance UID of the agent are taken into account, the ones of other
+// bridges are left alone. Adopted resources are only added once they are applied.
+func (agent *Agent) loadAppliedKeys() error {
+	instances, err := agent.applier.ListInstances()
+	if err != nil {
+		return fmt.Errorf("couldn't list instances: %w", err)
+	}
+	for _, instance := range instances {
+		if operator.IsOwnedBy(&instance, agent.instanceId.String()) {
+			agent.appliedKeys[newCollectorKey(instance.GetName(), instance.GetNamespace())] = true
+		}
+	}
+	instrumentations, err := agent.applier.ListInstrumentations()
+	if err != nil {
+		return fmt.Errorf("couldn't list instrumentations: %w", err)
+	}
+	for _, instrumentation := range instrumentations {
+		if operator.IsOwnedBy(&instrumentation, agent.instanceId.String()) {
+			agent.appliedKeys[newInstrumentationKey(instrumentation.GetName(), instrumentation.GetNamespace())] = true
+		}
+	}
+	return nil
+}
+
+// startClient reports the current state of the agent to the OpAMP client and starts it.
+func (agent *Agent) startClient() error {
 	settings := types.StartSettings{
 		OpAMPServerURL: agent.config.Endpoint,
+		Header:         agent.header,
+		TLSConfig:      agent.tlsConfig,
 		InstanceUid:    agent.instanceId.String(),
 		Callbacks: types.CallbacksStruct{
 			OnConnectFunc:              agent.onConnect,
@@ -117,24 +360,79 @@ func (agent *Agent) Start() error {
 		PackagesStateProvider: nil,
 		Capabilities:          agent.config.GetCapabilities(),
 	}
-	err := agent.opampClient.SetAgentDescription(agent.agentDescription)
+	health, description, err := agent.getStatus()
+	if err != nil {
+		agent.logger.Errorf("couldn't get the state of the collectors: %v", err)
+		description = agent.agentDescription
+	}
+	err = agent.getClient().SetAgentDescription(description)
 	if err != nil {
 		return err
 	}
-	err = agent.opampClient.SetHealth(agent.getHealth())
+	err = agent.getClient().SetHealth(health)
 	if err != nil {
 		return err
 	}
 
 	agent.logger.Debugf("Starting OpAMP client...")
 
-	err = agent.opampClient.Start(context.Background(), settings)
+	err = agent.getClient().Start(context.Background(), settings)
 	if err != nil {
 		return err
 	}
 
 	agent.logger.Debugf("OpAMP Client started.")
+	return nil
+}
 
+// getClient returns the current OpAMP client.
+func (agent *Agent) getClient() client.OpAMPClient {
+	return *agent.opampClient.Load()
+}
+
+// reloadHeaders reads the header values again and reconnects to the server with a new OpAMP client if they changed,
+// as a client can't be restarted and only sends the headers it was started with.
+func (agent *Agent) reloadHeaders() error {
+	header, err := agent.config.GetHeaders()
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(header, agent.header) {
+		return nil
+	}
+	agent.clientLock.Lock()
+	defer agent.clientLock.Unlock()
+	select {
+	case <-agent.done:
+		// The agent is shutting down, its client is already stopped.
+		return nil
+	default:
+	}
+
+	agent.logger.Debugf("Headers changed, reconnecting to the server...")
+	err = agent.getClient().Stop(context.Background())
+	if err != nil {
+		return err
+	}
+	agent.header = header
+	newClient := agent.newClient()
+	agent.opampClient.Store(&newClient)
+	return agent.startClient()
+}
+
+// loadInstanceId restores the instance UID saved by a previous run of the agent, or saves the generated one if there is
+// none yet.
+func (agent *Agent) loadInstanceId() error {
+	ctx := context.Background()
+	instanceId, found, err := agent.instanceIdStore.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return agent.instanceIdStore.Save(ctx, agent.instanceId)
+	}
+	agent.logger.Debugf("Restored agent identity, id=%v", instanceId.String())
+	agent.instanceId = instanceId
 	return nil
 }
 
@@ -144,27 +442,70 @@ func (agent *Agent) updateAgentIdentity(instanceId ulid.ULID) {
 	agent.logger.Debugf("Agent identity is being changed from id=%v to id=%v",
 		agent.instanceId.String(),
 		instanceId.String())
+	agent.statusLock.Lock()
 	agent.instanceId = instanceId
+	agent.statusLock.Unlock()
+	// The resources are labelled with the new identity when they are applied next.
+	agent.applier.SetOwner(instanceId.String())
+	if agent.instanceIdStore != nil {
+		if err := agent.instanceIdStore.Save(context.Background(), instanceId); err != nil {
+			agent.logger.Errorf("couldn't save the agent identity: %v", err)
+		}
+	}
 }
 
 // getEffectiveConfig is called when a remote server needs to learn of the current effective configuration of each
-// collector the agent is managing.
+// collector the agent is managing. The configuration of a collector is the one rendered by the operator, the state of
+// the collectors is reported in the agent description instead. The instrumentations are reported with their spec.
 func (agent *Agent) getEffectiveConfig(ctx context.Context) (*protobufs.EffectiveConfig, error) {
 	instances, err := agent.applier.ListInstances()
 	if err != nil {
-		agent.logger.Errorf("couldn't list instances", err)
+		agent.logger.Errorf("couldn't list instances: %v", err)
 		return nil, err
 	}
 	instanceMap := map[string]*protobufs.AgentConfigFile{}
 	for _, instance := range instances {
-		marshaled, err := yaml.Marshal(instance)
+		rendered, err := agent.applier.GetEffectiveConfig(instance)
 		if err != nil {
-			agent.logger.Errorf("couldn't marshal collector configuration", err)
+			agent.logger.Errorf("couldn't get the collector configuration: %v", err)
 			return nil, err
 		}
 		mapKey := newCollectorKey(instance.GetName(), instance.GetNamespace())
 		instanceMap[mapKey.String()] = &protobufs.AgentConfigFile{
-			Body:        marshaled,
+			Body:        rendered,
+			ContentType: "yaml",
+		}
+	}
+	// Collectors the agent can't manage are reported read-only, so that they can be adopted
+	unmanaged, err := agent.applier.ListUnmanagedInstances()
+	if err != nil {
+		agent.logger.Errorf("couldn't list unmanaged instances: %v", err)
+		return nil, err
+	}
+	for _, instance := range unmanaged {
+		rendered, err := agent.applier.GetEffectiveConfig(instance)
+		if err != nil {
+			agent.logger.Errorf("couldn't get the collector configuration: %v", err)
+			return nil, err
+		}
+		instanceMap[unmanagedCollectorKey(instance.GetName(), instance.GetNamespace())] = &protobufs.AgentConfigFile{
+			Body:        rendered,
+			ContentType: "yaml",
+		}
+	}
+	instrumentations, err := agent.applier.ListInstrumentations()
+	if err != nil {
+		agent.logger.Errorf("couldn't list instrumentations: %v", err)
+		return nil, err
+	}
+	for _, instrumentation := range instrumentations {
+		marshalled, err := yaml.Marshal(instrumentation.Spec)
+		if err != nil {
+			return nil, err
+		}
+		mapKey := newInstrumentationKey(instrumentation.GetName(), instrumentation.GetNamespace())
+		instanceMap[mapKey.String()] = &protobufs.AgentConfigFile{
+			Body:        marshalled,
 			ContentType: "yaml",
 		}
 	}
@@ -179,7 +520,7 @@ func (agent *Agent) getEffectiveConfig(ctx context.Context) (*protobufs.Effectiv
 // configured destination. The settings received will be used to initialize a reporter, shutting down any previously
 // running metrics reporting instances.
 func (agent *Agent) initMeter(settings *protobufs.TelemetryConnectionSettings) {
-	reporter, err := metrics.NewMetricReporter(agent.logger, settings, agent.config.GetAgentType(), agent.config.GetAgentVersion(), agent.instanceId)
+	reporter, err := metrics.NewMetricReporter(agent.logger, settings, agent.config.GetTelemetryProtocol(), agent.config.GetAgentType(), agent.config.GetAgentVersion(), agent.instanceId)
 	if err != nil {
 		agent.logger.Errorf("Cannot collect metrics: %v", err)
 		return
@@ -191,62 +532,249 @@ func (agent *Agent) initMeter(settings *protobufs.TelemetryConnectionSettings) {
 	agent.metricReporter = reporter
 }
 
+// initTracer initializes a tracer provider for the agent to report the spans of the remote configuration applications
+// to the configured destination, shutting down any previously running provider.
+func (agent *Agent) initTracer(settings *protobufs.TelemetryConnectionSettings) {
+	resource, err := telemetry.NewResource(agent.config.GetAgentType(), agent.config.GetAgentVersion(), agent.instanceId)
+	if err != nil {
+		agent.logger.Errorf("Cannot report traces: %v", err)
+		return
+	}
+	provider, err := telemetry.NewTracerProvider(settings, agent.config.GetTelemetryProtocol(), resource)
+	if err != nil {
+		agent.logger.Errorf("Cannot report traces: %v", err)
+		return
+	}
+
+	agent.shutdownTracer()
+	agent.tracerProvider = provider
+	agent.tracer = provider.Tracer(tracerName)
+}
+
+func (agent *Agent) shutdownTracer() {
+	if agent.tracerProvider != nil {
+		if err := agent.tracerProvider.Shutdown(context.Background()); err != nil {
+			agent.logger.Errorf("Failed to shutdown the tracer provider: %v", err)
+		}
+	}
+}
+
+// initLogExporter initializes a log exporter for the agent to report its own logs to the configured destination,
+// replacing and shutting down any previously running exporter.
+func (agent *Agent) initLogExporter(settings *protobufs.TelemetryConnectionSettings) {
+	if agent.logSink == nil {
+		agent.logger.Errorf("Cannot report logs: the agent logger doesn't support exporting")
+		return
+	}
+	resource, err := telemetry.NewResource(agent.config.GetAgentType(), agent.config.GetAgentVersion(), agent.instanceId)
+	if err != nil {
+		agent.logger.Errorf("Cannot report logs: %v", err)
+		return
+	}
+	exporter, err := telemetry.NewLogExporter(settings, agent.config.GetTelemetryProtocol(), resource)
+	if err != nil {
+		agent.logger.Errorf("Cannot report logs: %v", err)
+		return
+	}
+
+	agent.logSink.SetExporter(exporter)
+	if agent.logExporter != nil {
+		agent.logExporter.Shutdown()
+	}
+	agent.logExporter = exporter
+}
+
+func (agent *Agent) shutdownLogExporter() {
+	if agent.logExporter != nil {
+		agent.logSink.SetExporter(nil)
+		agent.logExporter.Shutdown()
+	}
+}
+
 // applyRemoteConfig receives a remote configuration from a remote server of the following form:
 //
 //	map[name/namespace] -> collector CRD spec
+//	map[instrumentation/namespace/name] -> instrumentation CRD spec
 //
-// For every key in the received remote configuration, the agent attempts to apply it to the connected
-// Kubernetes cluster. If an agent fails to apply a collector CRD, it will continue to the next entry. The agent will
-// store the received configuration hash regardless of application status as per the OpAMP spec.
+// The entries are applied to the connected Kubernetes cluster according to the apply mode of the agent, and the
+// resources of the entries missing from the configuration are deleted. The errors are reported prefixed with the key of
+// their entry, and the message of a failed status starts with the apply mode. A successful status can't hold the apply
+// mode, RemoteConfigStatus only has an error message meant for failures, so the apply mode is always reported in the
+// agent description as well, see applyModeAttribute. The agent will store the received
+// configuration hash regardless of application status as per the OpAMP spec. The application is traced with a child
+// span for every entry applied or deleted in the independent mode.
 //
 // INVARIANT: The caller must verify that config isn't nil _and_ the configuration has changed between calls.
-func (agent *Agent) applyRemoteConfig(config *protobufs.AgentRemoteConfig) (*protobufs.RemoteConfigStatus, error) {
+func (agent *Agent) applyRemoteConfig(ctx context.Context, remoteConfig *protobufs.AgentRemoteConfig) (*protobufs.RemoteConfigStatus, error) {
+	mode := agent.config.GetApplyMode()
+	ctx, span := agent.tracer.Start(ctx, "applyRemoteConfig", trace.WithAttributes(
+		attribute.String("opamp.remote_config.hash", fmt.Sprintf("%x", remoteConfig.GetConfigHash())),
+		attribute.String("opamp.apply_mode", string(mode))))
+	defer span.End()
+	agent.resetApplyResults()
+	var multiErr error
+	if mode == config.ApplyModeTransactional {
+		multiErr = agent.applyTransaction(remoteConfig)
+	} else {
+		multiErr = agent.applyEntries(ctx, remoteConfig)
+	}
+	agent.setLastHash(remoteConfig.GetConfigHash())
+	if multiErr != nil {
+		span.SetStatus(codes.Error, multiErr.Error())
+		return &protobufs.RemoteConfigStatus{
+			LastRemoteConfigHash: agent.lastHash,
+			Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+			ErrorMessage:         fmt.Sprintf("%s apply failed: %s", mode, multiErr),
+		}, multiErr
+	}
+	return &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: agent.lastHash,
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+	}, nil
+}
+
+// applyEntries applies every entry of the remote configuration independently. If the agent fails to apply an entry, it
+// will continue to the next one, and the resources of the missing entries are deleted regardless.
+func (agent *Agent) applyEntries(ctx context.Context, remoteConfig *protobufs.AgentRemoteConfig) error {
 	var multiErr error
 	// Apply changes from the received config map
-	for key, file := range config.Config.GetConfigMap() {
+	for key, file := range remoteConfig.Config.GetConfigMap() {
 		if len(key) == 0 || len(file.Body) == 0 {
 			continue
 		}
+		_, keySpan := agent.tracer.Start(ctx, "apply", trace.WithAttributes(attribute.String("opamp.config.key", key)))
 		colKey, err := collectorKeyFromKey(key)
 		if err != nil {
+			endSpan(keySpan, err)
+			agent.recordApplyResult(key, err)
 			multiErr = multierr.Append(multiErr, err)
 			continue
 		}
-		err = agent.applier.Apply(colKey.name, colKey.namespace, file)
+		if colKey.kind == operator.InstrumentationResource {
+			err = agent.applier.ApplyInstrumentation(colKey.name, colKey.namespace, file)
+		} else {
+			err = agent.applier.Apply(colKey.name, colKey.namespace, file)
+		}
+		endSpan(keySpan, err)
+		agent.recordApplyResult(key, err)
 		if err != nil {
-			multiErr = multierr.Append(multiErr, err)
+			multiErr = multierr.Append(multiErr, fmt.Errorf("%s: %w", key, err))
 			continue
 		}
 		agent.appliedKeys[colKey] = true
 	}
 	// Check if anything was deleted
 	for collectorKey := range agent.appliedKeys {
-		if _, ok := config.Config.GetConfigMap()[collectorKey.String()]; !ok {
-			err := agent.applier.Delete(collectorKey.name, collectorKey.namespace)
+		if _, ok := remoteConfig.Config.GetConfigMap()[collectorKey.String()]; !ok {
+			_, keySpan := agent.tracer.Start(ctx, "delete", trace.WithAttributes(attribute.String("opamp.config.key", collectorKey.String())))
+			var err error
+			if collectorKey.kind == operator.InstrumentationResource {
+				err = agent.applier.DeleteInstrumentation(collectorKey.name, collectorKey.namespace)
+			} else {
+				err = agent.applier.Delete(collectorKey.name, collectorKey.namespace)
+			}
+			endSpan(keySpan, err)
 			if err != nil {
-				multiErr = multierr.Append(multiErr, err)
+				agent.recordDeleteError(collectorKey.String(), err)
+				multiErr = multierr.Append(multiErr, fmt.Errorf("%s: %w", collectorKey, err))
+				continue
 			}
+			delete(agent.appliedKeys, collectorKey)
 		}
 	}
-	agent.lastHash = config.GetConfigHash()
+	return multiErr
+}
+
+// applyTransaction applies all the entries of the remote configuration and deletes the resources of the missing ones,
+// or does none of it, see operator.Client.ApplyTransaction. Nothing is applied if a key is invalid.
+func (agent *Agent) applyTransaction(remoteConfig *protobufs.AgentRemoteConfig) error {
+	var multiErr error
+	var keys []collectorKey
+	var changes []operator.Change
+	configMap := remoteConfig.Config.GetConfigMap()
+	for key, file := range configMap {
+		if len(key) == 0 || len(file.Body) == 0 {
+			continue
+		}
+		colKey, err := collectorKeyFromKey(key)
+		if err != nil {
+			agent.recordApplyResult(key, err)
+			multiErr = multierr.Append(multiErr, fmt.Errorf("%s: %w", key, err))
+			continue
+		}
+		keys = append(keys, colKey)
+		changes = append(changes, operator.Change{Kind: colKey.kind, Name: colKey.name, Namespace: colKey.namespace, Config: file})
+	}
 	if multiErr != nil {
-		return &protobufs.RemoteConfigStatus{
-			LastRemoteConfigHash: agent.lastHash,
-			Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
-			ErrorMessage:         multiErr.Error(),
-		}, multiErr
+		for _, colKey := range keys {
+			agent.recordApplyResult(colKey.String(), operator.ErrNotApplied)
+		}
+		return multiErr
 	}
-	return &protobufs.RemoteConfigStatus{
-		LastRemoteConfigHash: agent.lastHash,
-		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
-	}, nil
+	for colKey := range agent.appliedKeys {
+		if _, ok := configMap[colKey.String()]; !ok {
+			keys = append(keys, colKey)
+			changes = append(changes, operator.Change{Kind: colKey.kind, Name: colKey.name, Namespace: colKey.namespace})
+		}
+	}
+	// The changes are sorted so that they are applied, and their errors reported, in a consistent order.
+	sort.Sort(changesByKey{keys: keys, changes: changes})
+
+	errs := agent.applier.ApplyTransaction(changes)
+	for i, err := range errs {
+		key := keys[i]
+		applied := err == nil || errors.Is(err, operator.ErrRollbackFailed)
+		if changes[i].Config == nil {
+			if err != nil {
+				agent.recordDeleteError(key.String(), err)
+			}
+			if applied {
+				delete(agent.appliedKeys, key)
+			}
+		} else {
+			agent.recordApplyResult(key.String(), err)
+			if applied {
+				agent.appliedKeys[key] = true
+			}
+		}
+		if err != nil {
+			multiErr = multierr.Append(multiErr, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	return multiErr
+}
+
+// changesByKey sorts the changes of a transaction along with their keys.
+type changesByKey struct {
+	keys    []collectorKey
+	changes []operator.Change
+}
+
+func (c changesByKey) Len() int           { return len(c.keys) }
+func (c changesByKey) Less(i, j int) bool { return c.keys[i].String() < c.keys[j].String() }
+func (c changesByKey) Swap(i, j int) {
+	c.keys[i], c.keys[j] = c.keys[j], c.keys[i]
+	c.changes[i], c.changes[j] = c.changes[j], c.changes[i]
 }
 
-// Shutdown will stop the OpAMP client gracefully.
+// endSpan ends the span of a remote configuration entry, recording its error if any.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Shutdown will stop the OpAMP client gracefully and flush the own telemetry of the agent.
 func (agent *Agent) Shutdown() {
 	agent.logger.Debugf("Agent shutting down...")
-	if agent.opampClient != nil {
-		err := agent.opampClient.Stop(context.Background())
+	agent.setStarted(false)
+	agent.clientLock.Lock()
+	defer agent.clientLock.Unlock()
+	close(agent.done)
+	if opampClient := agent.getClient(); opampClient != nil {
+		err := opampClient.Stop(context.Background())
 		if err != nil {
 			agent.logger.Errorf(err.Error())
 		}
@@ -254,32 +782,36 @@ func (agent *Agent) Shutdown() {
 	if agent.metricReporter != nil {
 		agent.metricReporter.Shutdown()
 	}
+	agent.shutdownTracer()
+	agent.shutdownLogExporter()
 }
 
 // onMessage is called when the client receives a new message from the connected OpAMP server. The agent is responsible
-// for checking if it should apply a new remote configuration. The agent will also initialize metrics based on the
-// settings received from the server. The agent is also able to update its identifier if it needs to.
+// for checking if it should apply a new remote configuration. The agent will also initialize the reporting of its own
+// metrics, traces and logs based on the settings received from the server. The agent is also able to update its identifier if it needs to.
 func (agent *Agent) onMessage(ctx context.Context, msg *types.MessageData) {
+	agent.recordMessage()
 	// If we received remote configuration, and it's not the same as the previously applied one
 	if agent.remoteConfigEnabled && msg.RemoteConfig != nil && !bytes.Equal(agent.lastHash, msg.RemoteConfig.GetConfigHash()) {
 		var err error
-		status, err := agent.applyRemoteConfig(msg.RemoteConfig)
+		status, err := agent.applyRemoteConfig(ctx, msg.RemoteConfig)
 		if err != nil {
 			agent.logger.Errorf(err.Error())
 		}
-		err = agent.opampClient.SetRemoteConfigStatus(status)
+		err = agent.getClient().SetRemoteConfigStatus(status)
 		if err != nil {
 			agent.logger.Errorf(err.Error())
 			return
 		}
-		err = agent.opampClient.UpdateEffectiveConfig(ctx)
+		err = agent.getClient().UpdateEffectiveConfig(ctx)
 		if err != nil {
 			agent.logger.Errorf(err.Error())
 		}
+		agent.reportStatus()
 	}
 
-	// The instance id is updated prior to the meter initialization so that the new meter will report using the updated
-	// instanceId.
+	// The instance id is updated prior to the own telemetry initialization so that the new meter, tracer and log exporter
+	// will report using the updated instanceId.
 	if msg.AgentIdentification != nil {
 		newInstanceId, err := ulid.Parse(msg.AgentIdentification.NewInstanceUid)
 		if err != nil {
@@ -292,4 +824,10 @@ func (agent *Agent) onMessage(ctx context.Context, msg *types.MessageData) {
 	if msg.OwnMetricsConnSettings != nil {
 		agent.initMeter(msg.OwnMetricsConnSettings)
 	}
+	if msg.OwnTracesConnSettings != nil {
+		agent.initTracer(msg.OwnTracesConnSettings)
+	}
+	if msg.OwnLogsConnSettings != nil {
+		agent.initLogExporter(msg.OwnLogsConnSettings)
+	}
 }
@@ -17,25 +17,40 @@ package agent
 import (
 	"context"
 	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"sort"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	"github.com/oklog/ulid/v2"
 	"github.com/open-telemetry/opamp-go/client"
 	"github.com/open-telemetry/opamp-go/client/types"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/identity"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/logger"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/operator"
 )
@@ -49,6 +64,8 @@ var (
 type mockOpampClient struct {
 	lastStatus          *protobufs.RemoteConfigStatus
 	lastEffectiveConfig *protobufs.EffectiveConfig
+	lastHealth          *protobufs.ComponentHealth
+	lastDescription     *protobufs.AgentDescription
 	settings            types.StartSettings
 }
 
@@ -62,14 +79,16 @@ func (m *mockOpampClient) Stop(ctx context.Context) error {
 }
 
 func (m *mockOpampClient) SetAgentDescription(descr *protobufs.AgentDescription) error {
+	m.lastDescription = descr
 	return nil
 }
 
 func (m *mockOpampClient) AgentDescription() *protobufs.AgentDescription {
-	return nil
+	return m.lastDescription
 }
 
-func (m *mockOpampClient) SetHealth(health *protobufs.AgentHealth) error {
+func (m *mockOpampClient) SetHealth(health *protobufs.ComponentHealth) error {
+	m.lastHealth = health
 	return nil
 }
 
@@ -91,17 +110,22 @@ func (m *mockOpampClient) SetPackageStatuses(statuses *protobufs.PackageStatuses
 	return nil
 }
 
-func getFakeApplier(t *testing.T, conf config.Config) *operator.Client {
+func getFakeApplier(t *testing.T, conf config.Config, objs ...k8sclient.Object) *operator.Client {
+	return operator.NewClient(l, getFakeClient(t, objs...), conf.GetComponentsAllowed(), conf.Policy)
+}
+
+func getFakeClient(t *testing.T, objs ...k8sclient.Object) k8sclient.Client {
 	schemeBuilder := runtime.NewSchemeBuilder(func(s *runtime.Scheme) error {
-		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{})
+		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{},
+			&v1alpha1.Instrumentation{}, &v1alpha1.InstrumentationList{})
 		metav1.AddToGroupVersion(s, v1alpha1.GroupVersion)
 		return nil
 	})
 	scheme := runtime.NewScheme()
 	err := schemeBuilder.AddToScheme(scheme)
 	require.NoError(t, err, "Should be able to add custom types")
-	c := fake.NewClientBuilder().WithScheme(scheme)
-	return operator.NewClient(l, c.Build(), conf.GetComponentsAllowed())
+	require.NoError(t, clientgoscheme.AddToScheme(scheme), "Should be able to add the core types")
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
 }
 
 func TestAgent_onMessage(t *testing.T) {
@@ -159,12 +183,8 @@ func TestAgent_onMessage(t *testing.T) {
 			want: want{
 				contents: map[string][]string{
 					"good/testnamespace": {
-						"kind: OpenTelemetryCollector",
-						"name: good",
-						"namespace: testnamespace",
 						"send_batch_size: 10000",
 						"receivers: [otlp]",
-						"status:",
 					},
 				},
 				status: &protobufs.RemoteConfigStatus{
@@ -189,7 +209,27 @@ func TestAgent_onMessage(t *testing.T) {
 				status: &protobufs.RemoteConfigStatus{
 					LastRemoteConfigHash: []byte("bad/testnamespace408"),
 					Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
-					ErrorMessage:         "yaml: line 16: could not find expected ':'",
+					ErrorMessage:         "independent apply failed: bad/testnamespace: yaml: line 16: could not find expected ':'",
+				},
+			},
+		},
+		{
+			name: "namespace not allowed by the policy",
+			fields: fields{
+				configFile: "testdata/agentpolicy.yaml",
+			},
+			args: args{
+				ctx: context.Background(),
+				configFile: map[string]string{
+					"good/testnamespace": "basic.yaml",
+				},
+			},
+			want: want{
+				contents: nil,
+				status: &protobufs.RemoteConfigStatus{
+					LastRemoteConfigHash: []byte("good/testnamespace405"),
+					Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+					ErrorMessage:         "independent apply failed: good/testnamespace: Policy violations: namespace testnamespace is not allowed",
 				},
 			},
 		},
@@ -207,12 +247,8 @@ func TestAgent_onMessage(t *testing.T) {
 			want: want{
 				contents: map[string][]string{
 					"good/testnamespace": {
-						"kind: OpenTelemetryCollector",
-						"name: good",
-						"namespace: testnamespace",
 						"send_batch_size: 10000",
 						"receivers: [otlp]",
-						"status:",
 					},
 				},
 				status: &protobufs.RemoteConfigStatus{
@@ -237,7 +273,7 @@ func TestAgent_onMessage(t *testing.T) {
 				status: &protobufs.RemoteConfigStatus{
 					LastRemoteConfigHash: []byte("good/testnamespace405"),
 					Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
-					ErrorMessage:         "Items in config are not allowed: [processors.batch]",
+					ErrorMessage:         "independent apply failed: good/testnamespace: Items in config are not allowed: [processors.batch]",
 				},
 			},
 		},
@@ -257,7 +293,7 @@ func TestAgent_onMessage(t *testing.T) {
 				status: &protobufs.RemoteConfigStatus{
 					LastRemoteConfigHash: []byte("good/testnamespace405"),
 					Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
-					ErrorMessage:         "Items in config are not allowed: [processors]",
+					ErrorMessage:         "independent apply failed: good/testnamespace: Items in config are not allowed: [processors]",
 				},
 			},
 		},
@@ -278,13 +314,8 @@ func TestAgent_onMessage(t *testing.T) {
 			want: want{
 				contents: map[string][]string{
 					"good/testnamespace": {
-						"kind: OpenTelemetryCollector",
-						"name: good",
-						"namespace: testnamespace",
 						"send_batch_size: 10000",
 						"processors: []",
-						"replicas: 1",
-						"status:",
 					},
 				},
 				status: &protobufs.RemoteConfigStatus{
@@ -293,13 +324,8 @@ func TestAgent_onMessage(t *testing.T) {
 				},
 				nextContents: map[string][]string{
 					"good/testnamespace": {
-						"kind: OpenTelemetryCollector",
-						"name: good",
-						"namespace: testnamespace",
 						"send_batch_size: 10000",
 						"processors: [memory_limiter, batch]",
-						"replicas: 3",
-						"status:",
 					},
 				},
 				nextStatus: &protobufs.RemoteConfigStatus{
@@ -325,13 +351,8 @@ func TestAgent_onMessage(t *testing.T) {
 			want: want{
 				contents: map[string][]string{
 					"good/testnamespace": {
-						"kind: OpenTelemetryCollector",
-						"name: good",
-						"namespace: testnamespace",
 						"send_batch_size: 10000",
 						"processors: []",
-						"replicas: 1",
-						"status:",
 					},
 				},
 				status: &protobufs.RemoteConfigStatus{
@@ -340,19 +361,14 @@ func TestAgent_onMessage(t *testing.T) {
 				},
 				nextContents: map[string][]string{
 					"good/testnamespace": {
-						"kind: OpenTelemetryCollector",
-						"name: good",
-						"namespace: testnamespace",
 						"send_batch_size: 10000",
 						"processors: []",
-						"replicas: 1",
-						"status:",
 					},
 				},
 				nextStatus: &protobufs.RemoteConfigStatus{
 					LastRemoteConfigHash: []byte("good/testnamespace408"), // The new hash should be of the bad config
 					Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
-					ErrorMessage:         "yaml: line 16: could not find expected ':'",
+					ErrorMessage:         "independent apply failed: good/testnamespace: yaml: line 16: could not find expected ':'",
 				},
 			},
 		},
@@ -374,12 +390,8 @@ func TestAgent_onMessage(t *testing.T) {
 			want: want{
 				contents: map[string][]string{
 					"good/testnamespace": {
-						"kind: OpenTelemetryCollector",
-						"name: good",
-						"namespace: testnamespace",
 						"send_batch_size: 10000",
 						"processors: []",
-						"status:",
 					},
 				},
 				status: &protobufs.RemoteConfigStatus{
@@ -388,20 +400,12 @@ func TestAgent_onMessage(t *testing.T) {
 				},
 				nextContents: map[string][]string{
 					"good/testnamespace": {
-						"kind: OpenTelemetryCollector",
-						"name: good",
-						"namespace: testnamespace",
 						"send_batch_size: 10000",
 						"processors: []",
-						"status:",
 					},
 					"other/testnamespace": {
-						"kind: OpenTelemetryCollector",
-						"name: other",
-						"namespace: testnamespace",
 						"send_batch_size: 10000",
 						"processors: [memory_limiter, batch]",
-						"status:",
 					},
 				},
 				nextStatus: &protobufs.RemoteConfigStatus{
@@ -425,12 +429,8 @@ func TestAgent_onMessage(t *testing.T) {
 			want: want{
 				contents: map[string][]string{
 					"good/testnamespace": {
-						"kind: OpenTelemetryCollector",
-						"name: good",
-						"namespace: testnamespace",
 						"send_batch_size: 10000",
 						"processors: []",
-						"status:",
 					},
 				},
 				status: &protobufs.RemoteConfigStatus{
@@ -444,6 +444,68 @@ func TestAgent_onMessage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "can apply instrumentation",
+			fields: fields{
+				configFile: "testdata/agent.yaml",
+			},
+			args: args{
+				ctx: context.Background(),
+				configFile: map[string]string{
+					"good/testnamespace":                 "basic.yaml",
+					"instrumentation/testnamespace/java": "instrumentation.yaml",
+				},
+				nextConfigFile: map[string]string{
+					"good/testnamespace": "basic.yaml",
+				},
+			},
+			want: want{
+				contents: map[string][]string{
+					"good/testnamespace": {
+						"send_batch_size: 10000",
+						"processors: []",
+					},
+					"instrumentation/testnamespace/java": {
+						"endpoint: http://otel-collector:4317",
+						"argument: \"0.25\"",
+					},
+				},
+				status: &protobufs.RemoteConfigStatus{
+					LastRemoteConfigHash: []byte("good/testnamespace405instrumentation/testnamespace/java152"),
+					Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+				},
+				nextContents: map[string][]string{
+					"good/testnamespace": {
+						"send_batch_size: 10000",
+						"processors: []",
+					},
+				},
+				nextStatus: &protobufs.RemoteConfigStatus{
+					LastRemoteConfigHash: []byte("good/testnamespace405"),
+					Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+				},
+			},
+		},
+		{
+			name: "invalid instrumentation",
+			fields: fields{
+				configFile: "testdata/agent.yaml",
+			},
+			args: args{
+				ctx: context.Background(),
+				configFile: map[string]string{
+					"instrumentation/testnamespace/java": "invalidinstrumentation.yaml",
+				},
+			},
+			want: want{
+				contents: nil,
+				status: &protobufs.RemoteConfigStatus{
+					LastRemoteConfigHash: []byte("instrumentation/testnamespace/java58"),
+					Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+					ErrorMessage:         "independent apply failed: instrumentation/testnamespace/java: spec.sampler.argument should be in rage [0..1]: 2",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -451,7 +513,7 @@ func TestAgent_onMessage(t *testing.T) {
 			conf, err := config.Load(tt.fields.configFile)
 			require.NoError(t, err, "should be able to load config")
 			applier := getFakeApplier(t, conf)
-			agent := NewAgent(clientLogger, applier, conf, mockClient)
+			agent := NewAgent(clientLogger, applier, conf, mockClient, nil, nil)
 			err = agent.Start()
 			defer agent.Shutdown()
 			require.NoError(t, err, "should be able to start agent")
@@ -491,6 +553,9 @@ func TestAgent_onMessage(t *testing.T) {
 					assert.Contains(t, asString, content)
 				}
 			}
+			for key := range nextEffectiveConfig.ConfigMap.GetConfigMap() {
+				assert.Contains(t, tt.want.nextContents, key, "only the next configuration should be applied")
+			}
 			assert.Equal(t, tt.want.nextStatus, mockClient.lastStatus)
 		})
 	}
@@ -501,7 +566,7 @@ func Test_CanUpdateIdentity(t *testing.T) {
 	conf, err := config.Load("testdata/agent.yaml")
 	require.NoError(t, err, "should be able to load config")
 	applier := getFakeApplier(t, conf)
-	agent := NewAgent(clientLogger, applier, conf, mockClient)
+	agent := NewAgent(clientLogger, applier, conf, mockClient, nil, nil)
 	err = agent.Start()
 	defer agent.Shutdown()
 	require.NoError(t, err, "should be able to start agent")
@@ -517,6 +582,520 @@ func Test_CanUpdateIdentity(t *testing.T) {
 	assert.Equal(t, agent.instanceId, newId)
 }
 
+func TestAgent_getStatusHealth(t *testing.T) {
+	one := int32(1)
+	collector := func(name string) *v1alpha1.OpenTelemetryCollector {
+		return &v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "testnamespace",
+				Labels: map[string]string{
+					operator.ResourceIdentifierKey: operator.ResourceIdentifierValue,
+				},
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode: v1alpha1.ModeDeployment,
+			},
+		}
+	}
+	deployment := func(name string, ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-collector",
+				Namespace: "testnamespace",
+			},
+			Spec:   appsv1.DeploymentSpec{Replicas: &one},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: ready},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		objs              []k8sclient.Object
+		wantHealthy       bool
+		wantLastError     string
+		wantStatus        string
+		wantComponentKeys []string
+	}{
+		{
+			name:        "no collectors",
+			wantHealthy: true,
+			wantStatus:  "0/0 collectors healthy",
+		},
+		{
+			name: "all collectors healthy",
+			objs: []k8sclient.Object{
+				collector("first"), deployment("first", 1),
+				collector("second"), deployment("second", 1),
+			},
+			wantHealthy:       true,
+			wantStatus:        "2/2 collectors healthy",
+			wantComponentKeys: []string{"first/testnamespace", "second/testnamespace"},
+		},
+		{
+			name: "one collector not ready",
+			objs: []k8sclient.Object{
+				collector("first"), deployment("first", 1),
+				collector("second"), deployment("second", 0),
+			},
+			wantHealthy:       false,
+			wantLastError:     "second/testnamespace: 1 of 1 replicas are not ready",
+			wantStatus:        "1/2 collectors healthy",
+			wantComponentKeys: []string{"first/testnamespace", "second/testnamespace"},
+		},
+		{
+			name: "workload missing",
+			objs: []k8sclient.Object{
+				collector("first"),
+			},
+			wantHealthy:       false,
+			wantLastError:     "first/testnamespace: the deployment testnamespace/first-collector doesn't exist",
+			wantStatus:        "0/1 collectors healthy",
+			wantComponentKeys: []string{"first/testnamespace"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockOpampClient{}
+			conf, err := config.Load("testdata/agent.yaml")
+			require.NoError(t, err, "should be able to load config")
+			applier := getFakeApplier(t, conf, tt.objs...)
+			agent := NewAgent(clientLogger, applier, conf, mockClient, nil, nil)
+			err = agent.Start()
+			defer agent.Shutdown()
+			require.NoError(t, err, "should be able to start agent")
+
+			health := mockClient.lastHealth
+			require.NotNil(t, health, "the health should be reported on start")
+			assert.Equal(t, tt.wantHealthy, health.Healthy)
+			assert.Equal(t, tt.wantLastError, health.LastError)
+			assert.Equal(t, tt.wantStatus, health.Status)
+			assert.NotZero(t, health.StartTimeUnixNano)
+			assert.Len(t, health.ComponentHealthMap, len(tt.wantComponentKeys))
+			for _, key := range tt.wantComponentKeys {
+				assert.Contains(t, health.ComponentHealthMap, key)
+			}
+		})
+	}
+}
+
+func TestAgent_getStatusDescription(t *testing.T) {
+	collector := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "testnamespace",
+			Labels: map[string]string{
+				operator.ResourceIdentifierKey: operator.ResourceIdentifierValue,
+			},
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:  v1alpha1.ModeDeployment,
+			Image: "otel/opentelemetry-collector:0.68.0",
+		},
+		Status: v1alpha1.OpenTelemetryCollectorStatus{
+			Version: "0.68.0",
+		},
+	}
+	mockClient := &mockOpampClient{}
+	conf, err := config.Load("testdata/agent.yaml")
+	require.NoError(t, err, "should be able to load config")
+	applier := getFakeApplier(t, conf, collector)
+	agent := NewAgent(clientLogger, applier, conf, mockClient, nil, nil)
+	err = agent.Start()
+	defer agent.Shutdown()
+	require.NoError(t, err, "should be able to start agent")
+
+	description := mockClient.lastDescription
+	require.NotNil(t, description, "the description should be reported on start")
+	var collectors *protobufs.KeyValueList
+	for _, attribute := range description.NonIdentifyingAttributes {
+		if attribute.Key == collectorsAttribute {
+			collectors = attribute.Value.GetKvlistValue()
+		}
+	}
+	require.NotNil(t, collectors, "the state of the collectors should be reported")
+	require.Len(t, collectors.Values, 1)
+	assert.Equal(t, "simplest/testnamespace", collectors.Values[0].Key)
+	state := map[string]*protobufs.AnyValue{}
+	for _, kv := range collectors.Values[0].Value.GetKvlistValue().Values {
+		state[kv.Key] = kv.Value
+	}
+	assert.Equal(t, int64(0), state["pods"].GetIntValue())
+	assert.Equal(t, int64(0), state["ready_pods"].GetIntValue())
+	assert.Equal(t, "otel/opentelemetry-collector:0.68.0", state["image"].GetStringValue())
+	assert.Equal(t, "0.68.0", state["version"].GetStringValue())
+	assert.Equal(t, operator.ReconcileResultUnknown, state["reconcile_result"].GetStringValue())
+
+	// reporting an unchanged state doesn't replace the description
+	agent.reportStatus()
+	assert.Same(t, description, mockClient.lastDescription)
+}
+
+// countingApplier counts the calls reading the state of the collectors.
+type countingApplier struct {
+	operator.ConfigApplier
+	listCalls   int
+	statusCalls int
+}
+
+func (c *countingApplier) ListInstances() ([]v1alpha1.OpenTelemetryCollector, error) {
+	c.listCalls++
+	return c.ConfigApplier.ListInstances()
+}
+
+func (c *countingApplier) GetCollectorStatus(instance v1alpha1.OpenTelemetryCollector) (*operator.CollectorStatus, error) {
+	c.statusCalls++
+	return c.ConfigApplier.GetCollectorStatus(instance)
+}
+
+func TestAgent_reportStatusReadsTheCollectorsOnce(t *testing.T) {
+	collector := func(name string) *v1alpha1.OpenTelemetryCollector {
+		return &v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "testnamespace",
+				Labels: map[string]string{
+					operator.ResourceIdentifierKey: operator.ResourceIdentifierValue,
+				},
+			},
+		}
+	}
+	mockClient := &mockOpampClient{}
+	conf, err := config.Load("testdata/agent.yaml")
+	require.NoError(t, err, "should be able to load config")
+	applier := &countingApplier{ConfigApplier: getFakeApplier(t, conf, collector("first"), collector("second"))}
+	agent := NewAgent(clientLogger, applier, conf, mockClient, nil, nil)
+	err = agent.Start()
+	defer agent.Shutdown()
+	require.NoError(t, err, "should be able to start agent")
+
+	applier.listCalls, applier.statusCalls = 0, 0
+	agent.reportStatus()
+	assert.Equal(t, 1, applier.listCalls)
+	assert.Equal(t, 2, applier.statusCalls)
+	assert.Len(t, mockClient.lastHealth.ComponentHealthMap, 2)
+}
+
+func TestAgent_reloadHeaders(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("Bearer first"), 0600))
+	conf, err := config.Load("testdata/agent.yaml")
+	require.NoError(t, err, "should be able to load config")
+	conf.Headers = map[string]config.HeaderValue{
+		"Authorization": {ValueFromFile: tokenFile},
+	}
+	firstClient := &mockOpampClient{}
+	applier := getFakeApplier(t, conf)
+	agent := NewAgent(clientLogger, applier, conf, firstClient, nil, nil)
+	secondClient := &mockOpampClient{}
+	agent.newClient = func() client.OpAMPClient {
+		return secondClient
+	}
+	err = agent.Start()
+	defer agent.Shutdown()
+	require.NoError(t, err, "should be able to start agent")
+	assert.Equal(t, "Bearer first", firstClient.settings.Header.Get("Authorization"))
+
+	// nothing changed, the client is kept
+	require.NoError(t, agent.reloadHeaders())
+	assert.Same(t, firstClient, agent.getClient())
+
+	// the token was rotated, a new client is started with it
+	require.NoError(t, os.WriteFile(tokenFile, []byte("Bearer second"), 0600))
+	require.NoError(t, agent.reloadHeaders())
+	assert.Same(t, secondClient, agent.getClient())
+	assert.Equal(t, "Bearer second", secondClient.settings.Header.Get("Authorization"))
+	assert.Equal(t, agent.instanceId.String(), secondClient.settings.InstanceUid)
+	assert.NotNil(t, secondClient.lastDescription)
+	assert.NotNil(t, secondClient.lastHealth)
+}
+
+func TestAgent_persistsIdentity(t *testing.T) {
+	conf, err := config.Load("testdata/agent.yaml")
+	require.NoError(t, err, "should be able to load config")
+	applier := getFakeApplier(t, conf)
+	store := identity.NewConfigMapStore(getFakeClient(t), "testnamespace", "bridge-identity")
+
+	agent := NewAgent(clientLogger, applier, conf, &mockOpampClient{}, store, nil)
+	require.NoError(t, agent.Start(), "should be able to start agent")
+	firstInstanceId := agent.instanceId
+	newId := ulid.MustNew(ulid.MaxTime(), ulid.Monotonic(rand.Reader, 0))
+	agent.onMessage(context.Background(), &types.MessageData{
+		AgentIdentification: &protobufs.AgentIdentification{
+			NewInstanceUid: newId.String(),
+		},
+	})
+	agent.Shutdown()
+
+	// a restarted agent keeps the identity assigned by the server
+	restartedClient := &mockOpampClient{}
+	restarted := NewAgent(clientLogger, applier, conf, restartedClient, store, nil)
+	require.NoError(t, restarted.Start(), "should be able to start agent")
+	defer restarted.Shutdown()
+	assert.NotEqual(t, firstInstanceId, restarted.instanceId)
+	assert.Equal(t, newId, restarted.instanceId)
+	assert.Equal(t, newId.String(), restartedClient.settings.InstanceUid)
+}
+
+func TestAgent_Status(t *testing.T) {
+	mockClient := &mockOpampClient{}
+	conf, err := config.Load("testdata/agent.yaml")
+	require.NoError(t, err, "should be able to load config")
+	applier := getFakeApplier(t, conf)
+	agent := NewAgent(clientLogger, applier, conf, mockClient, nil, nil)
+	assert.False(t, agent.Ready())
+	err = agent.Start()
+	require.NoError(t, err, "should be able to start agent")
+	assert.True(t, agent.Ready())
+	agent.onConnect()
+
+	data, err := getMessageDataFromConfigFile(map[string]string{
+		"good/testnamespace": "basic.yaml",
+		"bad/testnamespace":  "invalid.yaml",
+	})
+	require.NoError(t, err, "should be able to load data")
+	agent.onMessage(context.Background(), data)
+
+	status := agent.Status()
+	assert.Equal(t, agent.instanceId.String(), status.InstanceUID)
+	assert.True(t, status.Connected)
+	assert.NotNil(t, status.LastMessageTime)
+	assert.Equal(t, hex.EncodeToString(data.RemoteConfig.ConfigHash), status.LastRemoteConfigHash)
+	assert.Equal(t, "independent", status.ApplyMode)
+	assert.Equal(t, map[string]ApplyResult{
+		"good/testnamespace": {Applied: true},
+		"bad/testnamespace":  {Error: "yaml: line 16: could not find expected ':'"},
+	}, status.Configs)
+
+	// removed entries are deleted along with their result
+	data, err = getMessageDataFromConfigFile(map[string]string{})
+	require.NoError(t, err, "should be able to load data")
+	agent.onMessage(context.Background(), data)
+	assert.Empty(t, agent.Status().Configs)
+
+	agent.onConnectFailed(errors.New("connection refused"))
+	assert.False(t, agent.Status().Connected)
+	agent.Shutdown()
+	assert.False(t, agent.Ready())
+}
+
+func TestAgent_transactionalApply(t *testing.T) {
+	mockClient := &mockOpampClient{}
+	conf, err := config.Load("testdata/agenttransactional.yaml")
+	require.NoError(t, err, "should be able to load config")
+	applier := getFakeApplier(t, conf)
+	agent := NewAgent(clientLogger, applier, conf, mockClient, nil, nil)
+	require.NoError(t, agent.Start(), "should be able to start agent")
+	defer agent.Shutdown()
+
+	// nothing is applied when an entry is invalid
+	data, err := getMessageDataFromConfigFile(map[string]string{
+		"good/testnamespace":                 "basic.yaml",
+		"bad/testnamespace":                  "invalid.yaml",
+		"instrumentation/testnamespace/java": "instrumentation.yaml",
+	})
+	require.NoError(t, err, "should be able to load data")
+	agent.onMessage(context.Background(), data)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, mockClient.lastStatus.Status)
+	assert.Equal(t, "transactional apply failed: bad/testnamespace: yaml: line 16: could not find expected ':'; "+
+		"good/testnamespace: not applied: another change of the transaction is invalid; "+
+		"instrumentation/testnamespace/java: not applied: another change of the transaction is invalid",
+		mockClient.lastStatus.ErrorMessage)
+	instances, err := applier.ListInstances()
+	require.NoError(t, err)
+	assert.Empty(t, instances)
+	instrumentations, err := applier.ListInstrumentations()
+	require.NoError(t, err)
+	assert.Empty(t, instrumentations)
+	status := agent.Status()
+	assert.Equal(t, "transactional", status.ApplyMode)
+	assert.Equal(t, ApplyResult{Error: operator.ErrNotApplied.Error()}, status.Configs["good/testnamespace"])
+
+	// all the entries are applied when they are valid
+	data, err = getMessageDataFromConfigFile(map[string]string{
+		"good/testnamespace":                 "basic.yaml",
+		"instrumentation/testnamespace/java": "instrumentation.yaml",
+	})
+	require.NoError(t, err, "should be able to load data")
+	agent.onMessage(context.Background(), data)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, mockClient.lastStatus.Status)
+	assert.Empty(t, mockClient.lastStatus.ErrorMessage)
+	var applyMode string
+	for _, attribute := range mockClient.lastDescription.NonIdentifyingAttributes {
+		if attribute.Key == applyModeAttribute {
+			applyMode = attribute.Value.GetStringValue()
+		}
+	}
+	assert.Equal(t, "transactional", applyMode, "the apply mode should be reported in the agent description")
+	instances, err = applier.ListInstances()
+	require.NoError(t, err)
+	assert.Len(t, instances, 1)
+	instrumentations, err = applier.ListInstrumentations()
+	require.NoError(t, err)
+	assert.Len(t, instrumentations, 1)
+
+	// the missing entries are deleted
+	data, err = getMessageDataFromConfigFile(map[string]string{
+		"instrumentation/testnamespace/java": "instrumentation.yaml",
+	})
+	require.NoError(t, err, "should be able to load data")
+	agent.onMessage(context.Background(), data)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, mockClient.lastStatus.Status)
+	instances, err = applier.ListInstances()
+	require.NoError(t, err)
+	assert.Empty(t, instances)
+	assert.Equal(t, map[collectorKey]bool{newInstrumentationKey("java", "testnamespace"): true}, agent.appliedKeys)
+}
+
+func TestAgent_adoption(t *testing.T) {
+	collector := func(name string, labels map[string]string) *v1alpha1.OpenTelemetryCollector {
+		return &v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "testnamespace", Labels: labels},
+			Spec:       v1alpha1.OpenTelemetryCollectorSpec{Config: "receivers: {otlp: {}}"},
+		}
+	}
+	owned := func(instanceId ulid.ULID) map[string]string {
+		return map[string]string{
+			operator.ResourceIdentifierKey: operator.ResourceIdentifierValue,
+			operator.ResourceOwnerKey:      instanceId.String(),
+		}
+	}
+	instanceId := ulid.MustNew(ulid.Now(), ulid.Monotonic(rand.Reader, 0))
+	otherInstanceId := ulid.MustNew(ulid.Now(), ulid.Monotonic(rand.Reader, 0))
+	store := identity.NewConfigMapStore(getFakeClient(t), "testnamespace", "bridge-identity")
+	require.NoError(t, store.Save(context.Background(), instanceId))
+
+	mockClient := &mockOpampClient{}
+	conf, err := config.Load("testdata/agent.yaml")
+	require.NoError(t, err, "should be able to load config")
+	applier := getFakeApplier(t, conf,
+		collector("previous", owned(instanceId)),
+		collector("other-bridge", owned(otherInstanceId)),
+		collector("adopted", map[string]string{operator.ResourceAdoptionKey: operator.ResourceAdoptionValue}),
+		collector("other", nil),
+	)
+	agent := NewAgent(clientLogger, applier, conf, mockClient, store, nil)
+	require.NoError(t, agent.Start(), "should be able to start agent")
+	defer agent.Shutdown()
+
+	// the collectors applied before a restart are known again, the ones of other bridges are left alone
+	assert.Equal(t, map[collectorKey]bool{newCollectorKey("previous", "testnamespace"): true}, agent.appliedKeys)
+	effectiveConfig, err := agent.getEffectiveConfig(context.Background())
+	require.NoError(t, err)
+	var keys []string
+	for key := range effectiveConfig.ConfigMap.ConfigMap {
+		keys = append(keys, key)
+	}
+	assert.ElementsMatch(t, []string{"previous/testnamespace", "other-bridge/testnamespace", "adopted/testnamespace", "unmanaged/testnamespace/other"}, keys)
+
+	// so they are deleted when they are removed from the remote configuration
+	data, err := getMessageDataFromConfigFile(map[string]string{
+		"good/testnamespace": "basic.yaml",
+	})
+	require.NoError(t, err, "should be able to load data")
+	agent.onMessage(context.Background(), data)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, mockClient.lastStatus.Status)
+	instances, err := applier.ListInstances()
+	require.NoError(t, err)
+	var names []string
+	for _, instance := range instances {
+		names = append(names, instance.Name)
+	}
+	assert.ElementsMatch(t, []string{"good", "other-bridge", "adopted"}, names)
+	good, err := applier.GetInstance("good", "testnamespace")
+	require.NoError(t, err)
+	assert.True(t, operator.IsOwnedBy(good, instanceId.String()))
+
+	// unmanaged collectors are read-only
+	data, err = getMessageDataFromConfigFile(map[string]string{
+		"good/testnamespace":            "basic.yaml",
+		"unmanaged/testnamespace/other": "basic.yaml",
+	})
+	require.NoError(t, err, "should be able to load data")
+	agent.onMessage(context.Background(), data)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, mockClient.lastStatus.Status)
+	assert.Equal(t, "independent apply failed: unmanaged collectors are read-only", mockClient.lastStatus.ErrorMessage)
+	unmanaged, err := applier.ListUnmanagedInstances()
+	require.NoError(t, err)
+	require.Len(t, unmanaged, 1)
+	assert.Equal(t, "receivers: {otlp: {}}", unmanaged[0].Spec.Config)
+}
+
+func TestAgent_ownTelemetry(t *testing.T) {
+	var lock sync.Mutex
+	paths := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		paths[r.URL.Path]++
+	}))
+	defer srv.Close()
+
+	mockClient := &mockOpampClient{}
+	conf, err := config.Load("testdata/agent.yaml")
+	require.NoError(t, err, "should be able to load config")
+	applier := getFakeApplier(t, conf)
+	logSink := logger.NewExportingSink(funcr.New(func(prefix, args string) {}, funcr.Options{}).GetSink())
+	logrLogger := logr.New(logSink)
+	agent := NewAgent(logger.NewLogger(&logrLogger), applier, conf, mockClient, nil, logSink)
+	require.NoError(t, agent.Start(), "should be able to start agent")
+
+	settings := &protobufs.TelemetryConnectionSettings{DestinationEndpoint: srv.URL}
+	agent.onMessage(context.Background(), &types.MessageData{
+		OwnTracesConnSettings: settings,
+		OwnLogsConnSettings:   settings,
+	})
+	require.NotNil(t, agent.tracerProvider)
+	require.NotNil(t, agent.logExporter)
+
+	data, err := getMessageDataFromConfigFile(map[string]string{
+		"good/testnamespace": "basic.yaml",
+		"bad/testnamespace":  "invalid.yaml",
+	})
+	require.NoError(t, err, "should be able to load data")
+	agent.onMessage(context.Background(), data)
+	agent.Shutdown()
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, map[string]int{"/v1/traces": 1, "/v1/logs": 1}, paths)
+}
+
+func TestAgent_applyRemoteConfigSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	mockClient := &mockOpampClient{}
+	conf, err := config.Load("testdata/agent.yaml")
+	require.NoError(t, err, "should be able to load config")
+	applier := getFakeApplier(t, conf)
+	agent := NewAgent(clientLogger, applier, conf, mockClient, nil, nil)
+	agent.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName)
+
+	data, err := getMessageDataFromConfigFile(map[string]string{
+		"good/testnamespace": "basic.yaml",
+		"bad/testnamespace":  "invalid.yaml",
+	})
+	require.NoError(t, err, "should be able to load data")
+	_, err = agent.applyRemoteConfig(context.Background(), data.RemoteConfig)
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	root := spans[2]
+	assert.Equal(t, "applyRemoteConfig", root.Name())
+	assert.Equal(t, codes.Error, root.Status().Code)
+	results := map[string]codes.Code{}
+	for _, span := range spans[:2] {
+		assert.Equal(t, "apply", span.Name())
+		assert.Equal(t, root.SpanContext().SpanID(), span.Parent().SpanID())
+		require.Len(t, span.Attributes(), 1)
+		results[span.Attributes()[0].Value.AsString()] = span.Status().Code
+	}
+	assert.Equal(t, map[string]codes.Code{
+		"good/testnamespace": codes.Unset,
+		"bad/testnamespace":  codes.Error,
+	}, results)
+}
+
 func getMessageDataFromConfigFile(filemap map[string]string) (*types.MessageData, error) {
 	toReturn := &types.MessageData{}
 	if filemap == nil {
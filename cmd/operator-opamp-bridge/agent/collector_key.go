@@ -18,26 +18,58 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/operator"
+)
+
+const (
+	// instrumentationKind prefixes the keys of the remote configuration which hold an Instrumentation.
+	instrumentationKind = "instrumentation"
+	// unmanagedKind prefixes the keys of the effective configuration which hold a collector the agent can't manage. These
+	// entries are read-only, they are rejected in a remote configuration.
+	unmanagedKind = "unmanaged"
 )
 
+// collectorKey identifies a resource managed by the agent in the remote configuration. Collectors are keyed as
+// name/namespace, every other kind is keyed as kind/namespace/name.
 type collectorKey struct {
+	kind      string
 	name      string
 	namespace string
 }
 
 func newCollectorKey(name string, namespace string) collectorKey {
-	return collectorKey{name: name, namespace: namespace}
+	return collectorKey{kind: operator.CollectorResource, name: name, namespace: namespace}
+}
+
+func newInstrumentationKey(name string, namespace string) collectorKey {
+	return collectorKey{kind: operator.InstrumentationResource, name: name, namespace: namespace}
 }
 
 func collectorKeyFromKey(key string) (collectorKey, error) {
 	s := strings.Split(key, "/")
-	// We expect map keys to be of the form name/namespace
-	if len(s) != 2 {
-		return collectorKey{}, errors.New("invalid key")
+	switch {
+	// We expect collector keys to be of the form name/namespace
+	case len(s) == 2:
+		return newCollectorKey(s[0], s[1]), nil
+	// and instrumentation keys to be of the form instrumentation/namespace/name
+	case len(s) == 3 && s[0] == instrumentationKind:
+		return newInstrumentationKey(s[2], s[1]), nil
+	case len(s) == 3 && s[0] == unmanagedKind:
+		return collectorKey{}, errors.New("unmanaged collectors are read-only")
 	}
-	return newCollectorKey(s[0], s[1]), nil
+	return collectorKey{}, errors.New("invalid key")
+}
+
+// unmanagedCollectorKey is the key of a collector the agent can't manage in the effective configuration, of the form
+// unmanaged/namespace/name.
+func unmanagedCollectorKey(name string, namespace string) string {
+	return fmt.Sprintf("%s/%s/%s", unmanagedKind, namespace, name)
 }
 
 func (k collectorKey) String() string {
+	if k.kind == operator.InstrumentationResource {
+		return fmt.Sprintf("%s/%s/%s", instrumentationKind, k.namespace, k.name)
+	}
 	return fmt.Sprintf("%s/%s", k.name, k.namespace)
 }
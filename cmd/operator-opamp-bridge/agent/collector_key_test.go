@@ -19,6 +19,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/operator"
 )
 
 func Test_collectorKeyFromKey(t *testing.T) {
@@ -37,6 +39,19 @@ func Test_collectorKeyFromKey(t *testing.T) {
 				key: "good/namespace",
 			},
 			want: collectorKey{
+				kind:      operator.CollectorResource,
+				name:      "good",
+				namespace: "namespace",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "instrumentation",
+			args: args{
+				key: "instrumentation/namespace/good",
+			},
+			want: collectorKey{
+				kind:      operator.InstrumentationResource,
 				name:      "good",
 				namespace: "namespace",
 			},
@@ -50,6 +65,14 @@ func Test_collectorKeyFromKey(t *testing.T) {
 			want:    collectorKey{},
 			wantErr: assert.Error,
 		},
+		{
+			name: "unmanaged collector",
+			args: args{
+				key: "unmanaged/namespace/good",
+			},
+			want:    collectorKey{},
+			wantErr: assert.Error,
+		},
 		{
 			name: "too many slashes",
 			args: args{
@@ -96,3 +119,15 @@ func Test_collectorKey_String(t *testing.T) {
 		})
 	}
 }
+
+func Test_instrumentationKey_String(t *testing.T) {
+	k := newInstrumentationKey("good", "namespace")
+	assert.Equal(t, "instrumentation/namespace/good", k.String())
+	parsed, err := collectorKeyFromKey(k.String())
+	assert.NoError(t, err)
+	assert.Equal(t, k, parsed)
+}
+
+func Test_unmanagedCollectorKey(t *testing.T) {
+	assert.Equal(t, "unmanaged/namespace/good", unmanagedCollectorKey("good", "namespace"))
+}
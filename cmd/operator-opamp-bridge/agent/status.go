@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	configApplies = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "opentelemetry_opamp_bridge_config_applies_total",
+		Help: "Number of resources applied from the remote configuration, by result.",
+	}, []string{"result"})
+	connected = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "opentelemetry_opamp_bridge_connected",
+		Help: "Whether the bridge is connected to the OpAMP server.",
+	})
+	lastMessageTimestamp = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "opentelemetry_opamp_bridge_last_message_timestamp_seconds",
+		Help: "Timestamp of the last message received from the OpAMP server.",
+	})
+)
+
+// Status is a snapshot of the state of the agent.
+type Status struct {
+	InstanceUID          string                 `json:"instance_uid"`
+	Connected            bool                   `json:"connected"`
+	LastMessageTime      *time.Time             `json:"last_message_time,omitempty"`
+	LastRemoteConfigHash string                 `json:"last_remote_config_hash,omitempty"`
+	ApplyMode            string                 `json:"apply_mode"`
+	Configs              map[string]ApplyResult `json:"configs"`
+}
+
+// ApplyResult is the outcome of applying an entry of the remote configuration.
+type ApplyResult struct {
+	Applied bool   `json:"applied"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Ready returns whether the agent has been started.
+func (agent *Agent) Ready() bool {
+	agent.statusLock.Lock()
+	defer agent.statusLock.Unlock()
+	return agent.started
+}
+
+// Status returns the current state of the agent.
+func (agent *Agent) Status() Status {
+	agent.statusLock.Lock()
+	defer agent.statusLock.Unlock()
+	status := Status{
+		InstanceUID:          agent.instanceId.String(),
+		Connected:            agent.connected,
+		LastRemoteConfigHash: hex.EncodeToString(agent.lastHash),
+		ApplyMode:            string(agent.config.GetApplyMode()),
+		Configs:              make(map[string]ApplyResult, len(agent.applyResults)),
+	}
+	if !agent.lastMessageTime.IsZero() {
+		lastMessageTime := agent.lastMessageTime
+		status.LastMessageTime = &lastMessageTime
+	}
+	for key, result := range agent.applyResults {
+		status.Configs[key] = result
+	}
+	return status
+}
+
+func (agent *Agent) setStarted(started bool) {
+	agent.statusLock.Lock()
+	defer agent.statusLock.Unlock()
+	agent.started = started
+}
+
+func (agent *Agent) setConnected(isConnected bool) {
+	agent.statusLock.Lock()
+	defer agent.statusLock.Unlock()
+	agent.connected = isConnected
+	if isConnected {
+		connected.Set(1)
+	} else {
+		connected.Set(0)
+	}
+}
+
+func (agent *Agent) recordMessage() {
+	agent.statusLock.Lock()
+	defer agent.statusLock.Unlock()
+	agent.lastMessageTime = time.Now()
+	lastMessageTimestamp.Set(float64(agent.lastMessageTime.UnixNano()) / 1e9)
+}
+
+// resetApplyResults forgets the results of the previous remote configuration.
+func (agent *Agent) resetApplyResults() {
+	agent.statusLock.Lock()
+	defer agent.statusLock.Unlock()
+	agent.applyResults = map[string]ApplyResult{}
+}
+
+// recordApplyResult records the outcome of applying the entry of the remote configuration with the given key.
+func (agent *Agent) recordApplyResult(key string, err error) {
+	agent.statusLock.Lock()
+	defer agent.statusLock.Unlock()
+	if err != nil {
+		configApplies.WithLabelValues("failed").Inc()
+		agent.applyResults[key] = ApplyResult{Error: err.Error()}
+		return
+	}
+	configApplies.WithLabelValues("applied").Inc()
+	agent.applyResults[key] = ApplyResult{Applied: true}
+}
+
+// recordDeleteError records that the resource of an entry removed from the remote configuration couldn't be deleted.
+func (agent *Agent) recordDeleteError(key string, err error) {
+	agent.statusLock.Lock()
+	defer agent.statusLock.Unlock()
+	agent.applyResults[key] = ApplyResult{Error: err.Error()}
+}
+
+func (agent *Agent) setLastHash(hash []byte) {
+	agent.statusLock.Lock()
+	defer agent.statusLock.Unlock()
+	agent.lastHash = hash
+}
@@ -40,7 +40,8 @@ var (
 )
 
 func registerKnownTypes(s *runtime.Scheme) error {
-	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{})
+	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{},
+		&v1alpha1.Instrumentation{}, &v1alpha1.InstrumentationList{})
 	metav1.AddToGroupVersion(s, v1alpha1.GroupVersion)
 	return nil
 }
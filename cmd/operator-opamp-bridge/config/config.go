@@ -16,22 +16,30 @@ package config
 
 import (
 	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/oklog/ulid/v2"
 	"github.com/open-telemetry/opamp-go/client"
+	"github.com/open-telemetry/opamp-go/client/types"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"gopkg.in/yaml.v2"
 
-	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/logger"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/telemetry"
 )
 
 const (
-	agentType             = "io.opentelemetry.operator-opamp-bridge"
-	defaultConfigFilePath = "/conf/remoteconfiguration.yaml"
+	agentType                   = "io.opentelemetry.operator-opamp-bridge"
+	defaultConfigFilePath       = "/conf/remoteconfiguration.yaml"
+	defaultHealthCheckInterval  = 30 * time.Second
+	defaultHeaderReloadInterval = time.Minute
 )
 
 var (
@@ -46,9 +54,92 @@ type Config struct {
 
 	// ComponentsAllowed is a list of allowed OpenTelemetry components for each pipeline type (receiver, processor, etc.)
 	ComponentsAllowed map[string][]string `yaml:"components_allowed,omitempty"`
+
+	// HealthCheckInterval is the interval at which the health of the managed collectors is reported to the server.
+	HealthCheckInterval time.Duration `yaml:"health_check_interval,omitempty"`
+
+	// Headers are additional HTTP headers sent to the server, e.g. to authenticate against a gateway in front of it.
+	Headers map[string]HeaderValue `yaml:"headers,omitempty"`
+
+	// HeaderReloadInterval is the interval at which the header values are read again, so rotated tokens are picked up.
+	HeaderReloadInterval time.Duration `yaml:"header_reload_interval,omitempty"`
+
+	// TLS configures the TLS connection to the server.
+	TLS *TLSConfig `yaml:"tls,omitempty"`
+
+	// Policy restricts where and how the server can apply collectors and instrumentations.
+	Policy *PolicyConfig `yaml:"policy,omitempty"`
+
+	// InstanceUID configures where the instance UID of the agent is persisted. By default, it's persisted to a ConfigMap
+	// named after the bridge in its own namespace.
+	InstanceUID *InstanceUIDConfig `yaml:"instance_uid,omitempty"`
+
+	// ApplyMode is how the entries of a remote configuration are applied, independent by default.
+	ApplyMode ApplyMode `yaml:"apply_mode,omitempty"`
+
+	// TelemetryProtocol is the OTLP protocol of the own telemetry sent to the destinations supplied by the server,
+	// http/protobuf by default or grpc. The server only supplies the URL of the destinations, which must be http or
+	// https.
+	TelemetryProtocol telemetry.Protocol `yaml:"telemetry_protocol,omitempty"`
+}
+
+// ApplyMode is how the entries of a remote configuration are applied to the cluster.
+type ApplyMode string
+
+const (
+	// ApplyModeIndependent applies every entry on its own, so the valid entries are applied even if others fail.
+	ApplyModeIndependent ApplyMode = "independent"
+	// ApplyModeTransactional applies all the entries or none of them. Every entry is validated with a dry run before
+	// anything is written, and the entries already applied are rolled back if another one still fails.
+	ApplyModeTransactional ApplyMode = "transactional"
+)
+
+// PolicyConfig restricts the resources applied from the remote configuration. Every restriction is optional, a resource
+// is rejected if it violates any of them.
+type PolicyConfig struct {
+	// AllowedNamespaces are glob patterns of the namespaces resources can be applied to, e.g. "team-*".
+	AllowedNamespaces []string `yaml:"allowed_namespaces,omitempty"`
+	// NamespaceSelector are the labels the namespaces resources are applied to must have.
+	NamespaceSelector map[string]string `yaml:"namespace_selector,omitempty"`
+	// AllowedModes are the deployment modes of the collectors, e.g. "deployment" or "statefulset".
+	AllowedModes []string `yaml:"allowed_modes,omitempty"`
+	// MaxReplicas limits the replicas and the maximum replicas of the autoscaler of the collectors.
+	MaxReplicas *int32 `yaml:"max_replicas,omitempty"`
+	// AllowedImages are glob patterns of the images of the collectors and their target allocators. A pattern ending with
+	// a slash allows every image of a registry or repository, e.g. "ghcr.io/open-telemetry/".
+	AllowedImages []string `yaml:"allowed_images,omitempty"`
+	// ForbiddenFields are the paths of the collector spec fields which must not be set, e.g. "hostNetwork", "volumes"
+	// or "podSecurityContext.runAsUser".
+	ForbiddenFields []string `yaml:"forbidden_fields,omitempty"`
 }
 
-func (c *Config) CreateClient(logger *logger.Logger) client.OpAMPClient {
+// InstanceUIDConfig defines the ConfigMap the instance UID is persisted to, along with the instance UIDs assigned by the
+// server. The ConfigMap defaults to <bridge name>-instance-uid in the namespace of the bridge.
+type InstanceUIDConfig struct {
+	ConfigMapName string `yaml:"configmap_name,omitempty"`
+	Namespace     string `yaml:"namespace,omitempty"`
+}
+
+// HeaderValue is the value of a header, given either literally or read from an environment variable or a file, e.g. a
+// token mounted from a Secret.
+type HeaderValue struct {
+	Value         string `yaml:"value,omitempty"`
+	ValueFromEnv  string `yaml:"value_from_env,omitempty"`
+	ValueFromFile string `yaml:"value_from_file,omitempty"`
+}
+
+type TLSConfig struct {
+	// CAFile is the CA bundle used to verify the server certificate, in addition to the system CAs.
+	CAFile string `yaml:"ca_file,omitempty"`
+
+	// CertFile and KeyFile are the client certificate and key presented to the server.
+	CertFile string `yaml:"cert_file,omitempty"`
+	KeyFile  string `yaml:"key_file,omitempty"`
+
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify,omitempty"`
+}
+
+func (c *Config) CreateClient(logger types.Logger) client.OpAMPClient {
 	if c.Protocol == "http" {
 		return client.NewHTTP(logger)
 	}
@@ -68,6 +159,100 @@ func (c *Config) GetComponentsAllowed() map[string]map[string]bool {
 	return m
 }
 
+func (c *Config) GetHealthCheckInterval() time.Duration {
+	if c.HealthCheckInterval <= 0 {
+		return defaultHealthCheckInterval
+	}
+	return c.HealthCheckInterval
+}
+
+func (c *Config) GetHeaderReloadInterval() time.Duration {
+	if c.HeaderReloadInterval <= 0 {
+		return defaultHeaderReloadInterval
+	}
+	return c.HeaderReloadInterval
+}
+
+// HasReloadableHeaders returns whether a header value is read from an environment variable or a file, so that it has
+// to be read again periodically.
+func (c *Config) HasReloadableHeaders() bool {
+	for _, value := range c.Headers {
+		if value.ValueFromFile != "" || value.ValueFromEnv != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// GetHeaders reads the values of the headers sent to the server. Values read from files are trimmed of surrounding
+// whitespace, as Secrets often end with a newline.
+func (c *Config) GetHeaders() (http.Header, error) {
+	header := http.Header{}
+	for name, value := range c.Headers {
+		switch {
+		case value.ValueFromFile != "":
+			content, err := os.ReadFile(value.ValueFromFile)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't read the value of header %s: %w", name, err)
+			}
+			header.Set(name, strings.TrimSpace(string(content)))
+		case value.ValueFromEnv != "":
+			envValue, ok := os.LookupEnv(value.ValueFromEnv)
+			if !ok {
+				return nil, fmt.Errorf("the environment variable %s of header %s isn't set", value.ValueFromEnv, name)
+			}
+			header.Set(name, envValue)
+		default:
+			header.Set(name, value.Value)
+		}
+	}
+	return header, nil
+}
+
+// GetTLSConfig returns the TLS configuration of the connection to the server, nil if none is configured. The client
+// certificate is read again on every handshake, so a rotated certificate is used once the connection is reestablished.
+func (c *Config) GetTLSConfig() (*tls.Config, error) {
+	if c.TLS == nil {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// #nosec G402
+		InsecureSkipVerify: c.TLS.InsecureSkipVerify,
+	}
+	if c.TLS.CAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		caBundle, err := os.ReadFile(c.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read the CA bundle: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no certificate found in the CA bundle %s", c.TLS.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if c.TLS.CertFile != "" || c.TLS.KeyFile != "" {
+		if c.TLS.CertFile == "" || c.TLS.KeyFile == "" {
+			return nil, errors.New("both the client certificate and key must be set")
+		}
+		if _, err := tls.LoadX509KeyPair(c.TLS.CertFile, c.TLS.KeyFile); err != nil {
+			return nil, fmt.Errorf("couldn't load the client certificate: %w", err)
+		}
+		certFile, keyFile := c.TLS.CertFile, c.TLS.KeyFile
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				return nil, err
+			}
+			return &cert, nil
+		}
+	}
+	return tlsConfig, nil
+}
+
 func (c *Config) GetCapabilities() protobufs.AgentCapabilities {
 	var capabilities int32
 	for _, capability := range c.Capabilities {
@@ -117,6 +302,22 @@ func (c *Config) GetNewInstanceId() ulid.ULID {
 	return ulid.MustNew(ulid.Timestamp(time.Now()), entropy)
 }
 
+// GetApplyMode returns how the entries of a remote configuration are applied.
+func (c *Config) GetApplyMode() ApplyMode {
+	if c.ApplyMode == "" {
+		return ApplyModeIndependent
+	}
+	return c.ApplyMode
+}
+
+// GetTelemetryProtocol returns the OTLP protocol of the own telemetry of the bridge.
+func (c *Config) GetTelemetryProtocol() telemetry.Protocol {
+	if c.TelemetryProtocol == "" {
+		return telemetry.ProtocolHTTP
+	}
+	return c.TelemetryProtocol
+}
+
 func (c *Config) RemoteConfigEnabled() bool {
 	capabilities := c.GetCapabilities()
 	return capabilities&protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig != 0
@@ -127,6 +328,12 @@ func Load(file string) (Config, error) {
 	if err := unmarshal(&cfg, file); err != nil {
 		return Config{}, err
 	}
+	if mode := cfg.GetApplyMode(); mode != ApplyModeIndependent && mode != ApplyModeTransactional {
+		return Config{}, fmt.Errorf("invalid apply_mode %q, must be %s or %s", mode, ApplyModeIndependent, ApplyModeTransactional)
+	}
+	if protocol := cfg.GetTelemetryProtocol(); protocol != telemetry.ProtocolHTTP && protocol != telemetry.ProtocolGRPC {
+		return Config{}, fmt.Errorf("invalid telemetry_protocol %q, must be %s or %s", protocol, telemetry.ProtocolHTTP, telemetry.ProtocolGRPC)
+	}
 	return cfg, nil
 }
 
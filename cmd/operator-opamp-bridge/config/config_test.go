@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/telemetry"
+)
+
+func TestConfig_GetHeaders(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("first-token\n"), 0600))
+	t.Setenv("OPAMP_TENANT", "tenant-a")
+	cfg := Config{
+		Headers: map[string]HeaderValue{
+			"Authorization": {ValueFromFile: tokenFile},
+			"X-Scope-OrgID": {ValueFromEnv: "OPAMP_TENANT"},
+			"X-Source":      {Value: "operator-opamp-bridge"},
+		},
+	}
+
+	header, err := cfg.GetHeaders()
+	require.NoError(t, err)
+	assert.Equal(t, "first-token", header.Get("Authorization"))
+	assert.Equal(t, "tenant-a", header.Get("X-Scope-OrgID"))
+	assert.Equal(t, "operator-opamp-bridge", header.Get("X-Source"))
+
+	// the token is read again once it's rotated
+	require.NoError(t, os.WriteFile(tokenFile, []byte("second-token\n"), 0600))
+	header, err = cfg.GetHeaders()
+	require.NoError(t, err)
+	assert.Equal(t, "second-token", header.Get("Authorization"))
+}
+
+func TestConfig_GetHeadersMissingValue(t *testing.T) {
+	for _, tt := range []struct {
+		desc  string
+		value HeaderValue
+	}{
+		{"missing file", HeaderValue{ValueFromFile: filepath.Join(t.TempDir(), "missing")}},
+		{"missing environment variable", HeaderValue{ValueFromEnv: "OPAMP_BRIDGE_TEST_UNSET"}},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := Config{Headers: map[string]HeaderValue{"Authorization": tt.value}}
+			_, err := cfg.GetHeaders()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestConfig_HasReloadableHeaders(t *testing.T) {
+	assert.False(t, (&Config{}).HasReloadableHeaders())
+	assert.False(t, (&Config{Headers: map[string]HeaderValue{"X-Source": {Value: "operator-opamp-bridge"}}}).HasReloadableHeaders())
+	assert.True(t, (&Config{Headers: map[string]HeaderValue{"Authorization": {ValueFromFile: "/var/run/token"}}}).HasReloadableHeaders())
+	assert.True(t, (&Config{Headers: map[string]HeaderValue{"X-Scope-OrgID": {ValueFromEnv: "OPAMP_TENANT"}}}).HasReloadableHeaders())
+}
+
+func TestConfig_GetTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeCertificate(t, certFile, keyFile)
+
+	t.Run("not configured", func(t *testing.T) {
+		cfg := Config{}
+		tlsConfig, err := cfg.GetTLSConfig()
+		require.NoError(t, err)
+		assert.Nil(t, tlsConfig)
+	})
+
+	t.Run("CA bundle and client certificate", func(t *testing.T) {
+		cfg := Config{TLS: &TLSConfig{CAFile: certFile, CertFile: certFile, KeyFile: keyFile}}
+		tlsConfig, err := cfg.GetTLSConfig()
+		require.NoError(t, err)
+		assert.NotNil(t, tlsConfig.RootCAs)
+		assert.False(t, tlsConfig.InsecureSkipVerify)
+		cert, err := tlsConfig.GetClientCertificate(nil)
+		require.NoError(t, err)
+		assert.NotEmpty(t, cert.Certificate)
+	})
+
+	t.Run("insecure", func(t *testing.T) {
+		cfg := Config{TLS: &TLSConfig{InsecureSkipVerify: true}}
+		tlsConfig, err := cfg.GetTLSConfig()
+		require.NoError(t, err)
+		assert.True(t, tlsConfig.InsecureSkipVerify)
+		assert.Nil(t, tlsConfig.RootCAs)
+		assert.Nil(t, tlsConfig.GetClientCertificate)
+	})
+
+	for _, tt := range []struct {
+		desc string
+		tls  TLSConfig
+	}{
+		{"missing CA bundle", TLSConfig{CAFile: filepath.Join(dir, "missing")}},
+		{"CA bundle without certificate", TLSConfig{CAFile: keyFile}},
+		{"certificate without key", TLSConfig{CertFile: certFile}},
+		{"key without certificate", TLSConfig{KeyFile: keyFile}},
+		{"mismatched certificate and key", TLSConfig{CertFile: keyFile, KeyFile: certFile}},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := Config{TLS: &tt.tls}
+			_, err := cfg.GetTLSConfig()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoad_ApplyMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    ApplyMode
+		wantErr bool
+	}{
+		{name: "default", content: "endpoint: ws://127.0.0.1:4320/v1/opamp\n", want: ApplyModeIndependent},
+		{name: "transactional", content: "apply_mode: transactional\n", want: ApplyModeTransactional},
+		{name: "invalid", content: "apply_mode: atomic\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(file, []byte(tt.content), 0600))
+			cfg, err := Load(file)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "invalid apply_mode")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.GetApplyMode())
+		})
+	}
+}
+
+func TestLoad_TelemetryProtocol(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    telemetry.Protocol
+		wantErr bool
+	}{
+		{name: "default", content: "endpoint: ws://127.0.0.1:4320/v1/opamp\n", want: telemetry.ProtocolHTTP},
+		{name: "grpc", content: "telemetry_protocol: grpc\n", want: telemetry.ProtocolGRPC},
+		{name: "invalid", content: "telemetry_protocol: http/json\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(file, []byte(tt.content), 0600))
+			cfg, err := Load(file)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "invalid telemetry_protocol")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.GetTelemetryProtocol())
+		})
+	}
+}
+
+// writeCertificate writes a self-signed certificate and its key.
+func writeCertificate(t *testing.T, certFile string, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "operator-opamp-bridge"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+}
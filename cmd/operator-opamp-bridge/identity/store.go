@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package identity persists the instance UID of the agent, so that the agent keeps its identity across restarts.
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/oklog/ulid/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/operator"
+)
+
+const (
+	// instanceUIDKey is the key of the ConfigMap data holding the instance UID.
+	instanceUIDKey = "instance_uid"
+
+	// NameEnv is the environment variable holding the name of the bridge, which names the default ConfigMap.
+	NameEnv = "OPAMP_BRIDGE_NAME"
+	// NamespaceEnv is the environment variable holding the namespace of the bridge, set with the downward API. The
+	// namespace of the service account of the pod is used if it isn't set.
+	NamespaceEnv = "OPAMP_BRIDGE_NAMESPACE"
+
+	defaultName = "opamp-bridge"
+)
+
+var (
+	// ErrUnknownNamespace is returned when no namespace is configured and the bridge doesn't run in a pod.
+	ErrUnknownNamespace = errors.New("the namespace of the instance UID ConfigMap isn't configured and the bridge doesn't run in a pod")
+
+	serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// Store loads and saves the instance UID of the agent.
+type Store interface {
+	// Load returns the saved instance UID, false if none was saved yet.
+	Load(ctx context.Context) (ulid.ULID, bool, error)
+	Save(ctx context.Context, instanceId ulid.ULID) error
+}
+
+// New creates the store matching the given configuration. The ConfigMap defaults to <bridge name>-instance-uid in the
+// namespace of the bridge.
+func New(cfg config.InstanceUIDConfig, k8sClient client.Client) (Store, error) {
+	name := cfg.ConfigMapName
+	if name == "" {
+		bridgeName := os.Getenv(NameEnv)
+		if bridgeName == "" {
+			bridgeName = defaultName
+		}
+		name = bridgeName + "-instance-uid"
+	}
+	namespace := cfg.Namespace
+	if namespace == "" {
+		namespace = podNamespace()
+	}
+	if namespace == "" {
+		return nil, ErrUnknownNamespace
+	}
+	return NewConfigMapStore(k8sClient, namespace, name), nil
+}
+
+// podNamespace returns the namespace of the pod the bridge runs in, empty if unknown.
+func podNamespace() string {
+	if namespace := os.Getenv(NamespaceEnv); namespace != "" {
+		return namespace
+	}
+	namespace, err := os.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(namespace))
+}
+
+var _ Store = &ConfigMapStore{}
+
+// ConfigMapStore saves the instance UID into a ConfigMap created by the agent.
+type ConfigMapStore struct {
+	k8sClient client.Client
+	namespace string
+	name      string
+}
+
+func NewConfigMapStore(k8sClient client.Client, namespace string, name string) *ConfigMapStore {
+	return &ConfigMapStore{
+		k8sClient: k8sClient,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+func (s *ConfigMapStore) Load(ctx context.Context) (ulid.ULID, bool, error) {
+	configMap := corev1.ConfigMap{}
+	err := s.k8sClient.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: s.name}, &configMap)
+	if apierrors.IsNotFound(err) {
+		return ulid.ULID{}, false, nil
+	}
+	if err != nil {
+		return ulid.ULID{}, false, err
+	}
+	value, ok := configMap.Data[instanceUIDKey]
+	if !ok {
+		return ulid.ULID{}, false, nil
+	}
+	instanceId, err := ulid.Parse(value)
+	if err != nil {
+		return ulid.ULID{}, false, fmt.Errorf("failed to parse the instance UID of the ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+	return instanceId, true, nil
+}
+
+func (s *ConfigMapStore) Save(ctx context.Context, instanceId ulid.ULID) error {
+	configMap := corev1.ConfigMap{}
+	err := s.k8sClient.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: s.name}, &configMap)
+	if apierrors.IsNotFound(err) {
+		configMap = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.name,
+				Namespace: s.namespace,
+				Labels: map[string]string{
+					operator.ResourceIdentifierKey: operator.ResourceIdentifierValue,
+				},
+			},
+			Data: map[string]string{instanceUIDKey: instanceId.String()},
+		}
+		return s.k8sClient.Create(ctx, &configMap)
+	}
+	if err != nil {
+		return err
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[instanceUIDKey] = instanceId.String()
+	return s.k8sClient.Update(ctx, &configMap)
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/config"
+)
+
+func getFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme), "Should be able to add the core types")
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newInstanceId() ulid.ULID {
+	return ulid.MustNew(ulid.Now(), ulid.Monotonic(rand.Reader, 0))
+}
+
+func TestConfigMapStore(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := getFakeClient(t)
+	store := NewConfigMapStore(k8sClient, "opamp", "bridge-identity")
+
+	// nothing saved yet
+	_, found, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	first := newInstanceId()
+	require.NoError(t, store.Save(ctx, first))
+	loaded, found, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, first, loaded)
+
+	configMap := corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: "opamp", Name: "bridge-identity"}, &configMap))
+	assert.Equal(t, "operator-opamp-bridge", configMap.Labels["created-by"])
+
+	// a new instance UID replaces the saved one
+	second := newInstanceId()
+	require.NoError(t, store.Save(ctx, second))
+	loaded, found, err = store.Load(ctx)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, second, loaded)
+}
+
+func TestConfigMapStore_LoadInvalid(t *testing.T) {
+	k8sClient := getFakeClient(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "bridge-identity", Namespace: "opamp"},
+		Data:       map[string]string{instanceUIDKey: "not-a-ulid"},
+	})
+	store := NewConfigMapStore(k8sClient, "opamp", "bridge-identity")
+	_, _, err := store.Load(context.Background())
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	serviceAccountNamespaceFile = filepath.Join(t.TempDir(), "namespace")
+	k8sClient := getFakeClient(t)
+	tests := []struct {
+		name              string
+		cfg               config.InstanceUIDConfig
+		env               map[string]string
+		namespaceFile     string
+		expectedName      string
+		expectedNamespace string
+		expectedErr       error
+	}{
+		{
+			name:              "configured",
+			cfg:               config.InstanceUIDConfig{ConfigMapName: "bridge-identity", Namespace: "opamp"},
+			env:               map[string]string{NameEnv: "bridge", NamespaceEnv: "default"},
+			expectedName:      "bridge-identity",
+			expectedNamespace: "opamp",
+		},
+		{
+			name:              "named after the bridge",
+			env:               map[string]string{NameEnv: "bridge", NamespaceEnv: "default"},
+			expectedName:      "bridge-instance-uid",
+			expectedNamespace: "default",
+		},
+		{
+			name:              "default name",
+			env:               map[string]string{NamespaceEnv: "default"},
+			expectedName:      "opamp-bridge-instance-uid",
+			expectedNamespace: "default",
+		},
+		{
+			name:              "namespace of the service account",
+			namespaceFile:     "opamp\n",
+			expectedName:      "opamp-bridge-instance-uid",
+			expectedNamespace: "opamp",
+		},
+		{
+			name:        "unknown namespace",
+			cfg:         config.InstanceUIDConfig{ConfigMapName: "bridge-identity"},
+			expectedErr: ErrUnknownNamespace,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(NameEnv, tt.env[NameEnv])
+			t.Setenv(NamespaceEnv, tt.env[NamespaceEnv])
+			_ = os.Remove(serviceAccountNamespaceFile)
+			if tt.namespaceFile != "" {
+				require.NoError(t, os.WriteFile(serviceAccountNamespaceFile, []byte(tt.namespaceFile), 0600))
+			}
+
+			store, err := New(tt.cfg, k8sClient)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, NewConfigMapStore(k8sClient, tt.expectedNamespace, tt.expectedName), store)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+var _ logr.CallDepthLogSink = &ExportingSink{}
+
+// Record is a log entry of the bridge forwarded to an Exporter.
+type Record struct {
+	Time time.Time
+	// Name is the name of the logger, its segments are separated by dots.
+	Name string
+	// Level is the verbosity of an info entry, it's always 0 for errors.
+	Level int
+	// IsError is true for the entries logged with Error.
+	IsError bool
+	Err     error
+	Message string
+	// KeysAndValues holds the values of the logger followed by the ones of the entry.
+	KeysAndValues []interface{}
+}
+
+// Exporter sends the log entries of the bridge to a remote destination. Export must not block.
+type Exporter interface {
+	Export(record Record)
+}
+
+// ExportingSink is a logr.LogSink writing to another sink and forwarding every entry it writes to an Exporter, if one
+// is set. The loggers derived from an ExportingSink share its exporter.
+type ExportingSink struct {
+	sink   logr.LogSink
+	name   string
+	values []interface{}
+	shared *exporterHolder
+}
+
+type exporterHolder struct {
+	lock     sync.RWMutex
+	exporter Exporter
+}
+
+// NewExportingSink creates an ExportingSink writing to the sink, without any exporter. The sink must come from a
+// logr.Logger, and so already be initialized.
+func NewExportingSink(sink logr.LogSink) *ExportingSink {
+	return &ExportingSink{
+		sink:   sink,
+		shared: &exporterHolder{},
+	}
+}
+
+// SetExporter sets the exporter of the sink and of all the sinks derived from it. A nil exporter stops forwarding
+// the entries.
+func (s *ExportingSink) SetExporter(exporter Exporter) {
+	s.shared.lock.Lock()
+	defer s.shared.lock.Unlock()
+	s.shared.exporter = exporter
+}
+
+func (s *ExportingSink) export(record Record) {
+	s.shared.lock.RLock()
+	defer s.shared.lock.RUnlock()
+	if s.shared.exporter == nil {
+		return
+	}
+	record.Time = time.Now()
+	record.Name = s.name
+	record.KeysAndValues = append(append([]interface{}{}, s.values...), record.KeysAndValues...)
+	s.shared.exporter.Export(record)
+}
+
+// Init doesn't initialize the wrapped sink again, it only accounts for the extra frame of the ExportingSink in its call
+// depth.
+func (s *ExportingSink) Init(_ logr.RuntimeInfo) {
+	if withCallDepth, ok := s.sink.(logr.CallDepthLogSink); ok {
+		s.sink = withCallDepth.WithCallDepth(1)
+	}
+}
+
+func (s *ExportingSink) Enabled(level int) bool {
+	return s.sink.Enabled(level)
+}
+
+func (s *ExportingSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.sink.Info(level, msg, keysAndValues...)
+	s.export(Record{Level: level, Message: msg, KeysAndValues: keysAndValues})
+}
+
+func (s *ExportingSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.sink.Error(err, msg, keysAndValues...)
+	s.export(Record{IsError: true, Err: err, Message: msg, KeysAndValues: keysAndValues})
+}
+
+func (s *ExportingSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	derived := *s
+	derived.sink = s.sink.WithValues(keysAndValues...)
+	derived.values = append(append([]interface{}{}, s.values...), keysAndValues...)
+	return &derived
+}
+
+func (s *ExportingSink) WithName(name string) logr.LogSink {
+	derived := *s
+	derived.sink = s.sink.WithName(name)
+	if s.name == "" {
+		derived.name = name
+	} else {
+		derived.name = s.name + "." + name
+	}
+	return &derived
+}
+
+func (s *ExportingSink) WithCallDepth(depth int) logr.LogSink {
+	withCallDepth, ok := s.sink.(logr.CallDepthLogSink)
+	if !ok {
+		return s
+	}
+	derived := *s
+	derived.sink = withCallDepth.WithCallDepth(depth)
+	return &derived
+}
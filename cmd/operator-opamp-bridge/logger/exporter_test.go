@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingExporter struct {
+	records []Record
+}
+
+func (e *recordingExporter) Export(record Record) {
+	e.records = append(e.records, record)
+}
+
+func TestExportingSink(t *testing.T) {
+	var lines []string
+	sink := NewExportingSink(funcr.New(func(prefix, args string) {
+		lines = append(lines, prefix+" "+args)
+	}, funcr.Options{Verbosity: 1}).GetSink())
+	l := logr.New(sink)
+
+	l.Info("not exported")
+	exporter := &recordingExporter{}
+	sink.SetExporter(exporter)
+
+	agentLogger := l.WithName("bridge").WithName("agent").WithValues("instance", "a")
+	agentLogger.Info("applied", "key", "default/test")
+	agentLogger.V(1).Info("debug")
+	agentLogger.V(2).Info("not enabled")
+	agentLogger.Error(errors.New("boom"), "failed")
+
+	sink.SetExporter(nil)
+	agentLogger.Info("not exported either")
+
+	assert.Len(t, lines, 5)
+	require.Len(t, exporter.records, 3)
+	for _, record := range exporter.records {
+		assert.Equal(t, "bridge.agent", record.Name)
+		assert.False(t, record.Time.IsZero())
+	}
+	assert.Equal(t, "applied", exporter.records[0].Message)
+	assert.Equal(t, []interface{}{"instance", "a", "key", "default/test"}, exporter.records[0].KeysAndValues)
+	assert.Equal(t, 1, exporter.records[1].Level)
+	assert.True(t, exporter.records[2].IsError)
+	assert.EqualError(t, exporter.records[2].Err, "boom")
+	assert.Equal(t, []interface{}{"instance", "a"}, exporter.records[2].KeysAndValues)
+}
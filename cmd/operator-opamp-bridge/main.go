@@ -15,17 +15,26 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"os"
 	"os/signal"
 
+	"github.com/go-logr/logr"
+
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/agent"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/identity"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/logger"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/operator"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/server"
 )
 
 func main() {
-	l := config.GetLogger()
+	// The logs of the bridge are exported through logSink when the OpAMP server asks for them.
+	logSink := logger.NewExportingSink(config.GetLogger().GetSink())
+	l := logr.New(logSink)
 	cliConf, err := config.ParseCLI(l.WithName("cli-config"))
 	if err != nil {
 		l.Error(err, "unable to load ")
@@ -45,10 +54,32 @@ func main() {
 		l.Error(kubeErr, "Couldn't create kubernetes client")
 		os.Exit(1)
 	}
-	operatorClient := operator.NewClient(l.WithName("operator-client"), kubeClient, cfg.GetComponentsAllowed())
+	operatorClient := operator.NewClient(l.WithName("operator-client"), kubeClient, cfg.GetComponentsAllowed(), cfg.Policy)
+
+	instanceUIDConfig := config.InstanceUIDConfig{}
+	if cfg.InstanceUID != nil {
+		instanceUIDConfig = *cfg.InstanceUID
+	}
+	instanceIdStore, err := identity.New(instanceUIDConfig, kubeClient)
+	switch {
+	case errors.Is(err, identity.ErrUnknownNamespace) && cfg.InstanceUID == nil:
+		// outside a cluster, e.g. during development, a new instance UID is generated on every start
+		l.Info("Not persisting the instance UID", "reason", err.Error())
+	case err != nil:
+		l.Error(err, "Couldn't create the instance UID store")
+		os.Exit(1)
+	}
 
 	opampClient := cfg.CreateClient(agentLogger)
-	opampAgent := agent.NewAgent(agentLogger, operatorClient, cfg, opampClient)
+	opampAgent := agent.NewAgent(agentLogger, operatorClient, cfg, opampClient, instanceIdStore, logSink)
+
+	srv := server.NewServer(l.WithName("server"), opampAgent, *cliConf.ListenAddr)
+	go func() {
+		if err := srv.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			l.Error(err, "Can't start the server")
+			os.Exit(1)
+		}
+	}()
 
 	if err := opampAgent.Start(); err != nil {
 		l.Error(err, "Cannot start OpAMP client")
@@ -59,4 +90,7 @@ func main() {
 	signal.Notify(interrupt, os.Interrupt)
 	<-interrupt
 	opampAgent.Shutdown()
+	if err := srv.Shutdown(context.Background()); err != nil {
+		l.Error(err, "Failed to shutdown the server")
+	}
 }
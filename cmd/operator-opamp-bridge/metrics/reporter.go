@@ -17,28 +17,29 @@ package metrics
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"os"
 	"time"
 
 	"github.com/oklog/ulid/v2"
 	"github.com/shirou/gopsutil/process"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/instrument"
 	"go.opentelemetry.io/otel/metric/instrument/asyncfloat64"
 	"go.opentelemetry.io/otel/metric/instrument/asyncint64"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	otelresource "go.opentelemetry.io/otel/sdk/resource"
-	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/open-telemetry/opamp-go/client/types"
 	"github.com/open-telemetry/opamp-go/protobufs"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/telemetry"
 )
 
 // MetricReporter is a metric reporter that collects Agent metrics and sends them to an
-// OTLP/HTTP destination.
+// OTLP/HTTP or OTLP/gRPC destination.
 type MetricReporter struct {
 	logger types.Logger
 
@@ -54,53 +55,29 @@ type MetricReporter struct {
 	processCpuTime        asyncfloat64.Counter
 }
 
-// NewMetricReporter creates an OTLP/HTTP client to the destination address supplied by the server.
-// TODO: do more validation on the endpoint, allow for gRPC.
+// NewMetricReporter creates an OTLP client to the destination address supplied by the server, using the given
+// protocol.
 // TODO: set global provider and add more metrics to be reported.
 func NewMetricReporter(
 	logger types.Logger,
 	dest *protobufs.TelemetryConnectionSettings,
+	protocol telemetry.Protocol,
 	agentType string,
 	agentVersion string,
 	instanceId ulid.ULID,
 ) (*MetricReporter, error) {
 
-	if dest.DestinationEndpoint == "" {
-		return nil, fmt.Errorf("metric destination must specify DestinationEndpoint")
-	}
-
-	u, err := url.Parse(dest.DestinationEndpoint)
+	conn, err := telemetry.ParseConnectionSettings(dest, protocol)
 	if err != nil {
-		return nil, fmt.Errorf("invalid DestinationEndpoint: %w", err)
+		return nil, fmt.Errorf("invalid metric destination: %w", err)
 	}
 
-	// Create OTLP/HTTP metric exporter.
-	opts := []otlpmetrichttp.Option{
-		otlpmetrichttp.WithEndpoint(u.Host),
-		otlpmetrichttp.WithURLPath(u.Path),
-	}
-
-	headers := map[string]string{}
-	for _, header := range dest.Headers.GetHeaders() {
-		headers[header.GetKey()] = header.GetValue()
+	client, err := newExporter(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize otlp metric %s client: %w", conn.Protocol, err)
 	}
-	opts = append(opts, otlpmetrichttp.WithHeaders(headers))
 
-	client, err := otlpmetrichttp.New(context.Background(), opts...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize otlp metric http client: %w", err)
-	}
-
-	// Define the Resource to be exported with all metrics. Use OpenTelemetry semantic
-	// conventions as the OpAMP spec requires:
-	// https://github.com/open-telemetry/opamp-spec/blob/main/specification.md#own-telemetry-reporting
-	resource, resourceErr := otelresource.New(context.Background(),
-		otelresource.WithAttributes(
-			semconv.ServiceNameKey.String(agentType),
-			semconv.ServiceVersionKey.String(agentVersion),
-			semconv.ServiceInstanceIDKey.String(instanceId.String()),
-		),
-	)
+	resource, resourceErr := telemetry.NewResource(agentType, agentVersion, instanceId)
 	if resourceErr != nil {
 		return nil, resourceErr
 	}
@@ -150,6 +127,36 @@ func NewMetricReporter(
 	return reporter, nil
 }
 
+// newExporter creates the OTLP/HTTP or OTLP/gRPC metric exporter of the connection.
+func newExporter(conn *telemetry.Connection) (sdkmetric.Exporter, error) {
+	if conn.Protocol == telemetry.ProtocolGRPC {
+		opts := []otlpmetricgrpc.Option{
+			otlpmetricgrpc.WithEndpoint(conn.Endpoint),
+			otlpmetricgrpc.WithHeaders(conn.Headers),
+		}
+		if conn.TLSConfig != nil {
+			opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(conn.TLSConfig)))
+		} else {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+		return otlpmetricgrpc.New(context.Background(), opts...)
+	}
+
+	opts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpoint(conn.Endpoint),
+		otlpmetrichttp.WithHeaders(conn.Headers),
+	}
+	if conn.URLPath != "" {
+		opts = append(opts, otlpmetrichttp.WithURLPath(conn.URLPath))
+	}
+	if conn.TLSConfig != nil {
+		opts = append(opts, otlpmetrichttp.WithTLSClientConfig(conn.TLSConfig))
+	} else {
+		opts = append(opts, otlpmetrichttp.WithInsecure())
+	}
+	return otlpmetrichttp.New(context.Background(), opts...)
+}
+
 func (reporter *MetricReporter) processCpuTimeFunc(c context.Context) {
 	times, err := reporter.process.Times()
 	if err != nil {
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	// ResourceAdoptionKey is the label adopting a resource which wasn't created by the bridge, so that the server can
+	// manage it. The bridge takes the ownership of an adopted resource, labelling it with ResourceIdentifierKey and
+	// ResourceOwnerKey, when it applies it.
+	ResourceAdoptionKey   = "opentelemetry.io/opamp-bridge-adopt"
+	ResourceAdoptionValue = "true"
+	// ResourceOwnerKey is the label holding the instance UID of the bridge owning a resource, so that several bridges
+	// can share a cluster.
+	ResourceOwnerKey = "opentelemetry.io/opamp-bridge-instance-uid"
+)
+
+var (
+	// managedSelectors select the resources owned by the bridge and the adopted ones. A label selector can't match
+	// either of two labels, so they are listed separately.
+	managedSelectors = []client.MatchingLabels{
+		{ResourceIdentifierKey: ResourceIdentifierValue},
+		{ResourceAdoptionKey: ResourceAdoptionValue},
+	}
+	// unmanagedSelector selects the resources which are neither owned by the bridge nor adopted.
+	unmanagedSelector = client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(
+		mustRequirement(ResourceIdentifierKey, ResourceIdentifierValue),
+		mustRequirement(ResourceAdoptionKey, ResourceAdoptionValue),
+	)}
+)
+
+func mustRequirement(key string, value string) labels.Requirement {
+	requirement, err := labels.NewRequirement(key, selection.NotEquals, []string{value})
+	if err != nil {
+		panic(err)
+	}
+	return *requirement
+}
+
+// IsOwned returns whether a resource was created, or taken over, by the bridge.
+func IsOwned(obj metav1.Object) bool {
+	return obj.GetLabels()[ResourceIdentifierKey] == ResourceIdentifierValue
+}
+
+// IsOwnedBy returns whether a resource is owned by the bridge with the given instance UID.
+func IsOwnedBy(obj metav1.Object, instanceUid string) bool {
+	return IsOwned(obj) && obj.GetLabels()[ResourceOwnerKey] == instanceUid
+}
+
+// IsManaged returns whether the server can manage a resource, either because the bridge owns it or because it was
+// adopted.
+func IsManaged(obj metav1.Object) bool {
+	return IsOwned(obj) || obj.GetLabels()[ResourceAdoptionKey] == ResourceAdoptionValue
+}
+
+// SetOwner sets the instance UID of the bridge the resources are labelled with when they are applied.
+func (c Client) SetOwner(instanceUid string) {
+	c.owner.Store(&instanceUid)
+}
+
+// own labels a resource as owned by the bridge.
+func (c Client) own(obj metav1.Object) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[ResourceIdentifierKey] = ResourceIdentifierValue
+	if owner := c.owner.Load(); owner != nil {
+		labels[ResourceOwnerKey] = *owner
+	}
+	obj.SetLabels(labels)
+}
+
+// unmanagedError is the error of a change of a resource the server can't manage.
+func unmanagedError(kind string, name string, namespace string) error {
+	return errors.NewBadRequest(fmt.Sprintf("%s %s/%s isn't managed by the bridge, label it with %s=%s to adopt it",
+		kind, namespace, name, ResourceAdoptionKey, ResourceAdoptionValue))
+}
+
+// ListUnmanagedInstances retrieves the OpenTelemetryCollector CRDs which are neither owned by the bridge nor adopted.
+func (c Client) ListUnmanagedInstances() ([]v1alpha1.OpenTelemetryCollector, error) {
+	result := v1alpha1.OpenTelemetryCollectorList{}
+	if err := c.k8sClient.List(context.Background(), &result, unmanagedSelector); err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+// listManaged lists the resources the server can manage, the lists of which are created by newList. Only the
+// resources matching the managed selectors are listed.
+func (c Client) listManaged(ctx context.Context, newList func() client.ObjectList) ([]client.Object, error) {
+	var objects []client.Object
+	seen := map[client.ObjectKey]bool{}
+	for _, selector := range managedSelectors {
+		list := newList()
+		if err := c.k8sClient.List(ctx, list, selector); err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				return nil, fmt.Errorf("unexpected list item %T", item)
+			}
+			// an adopted resource applied by the bridge is both owned and adopted
+			if key := client.ObjectKeyFromObject(obj); !seen[key] {
+				seen[key] = true
+				objects = append(objects, obj)
+			}
+		}
+	}
+	return objects, nil
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestClient_Adoption(t *testing.T) {
+	collector := func(name string, labels map[string]string) *v1alpha1.OpenTelemetryCollector {
+		return &v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "testing", Labels: labels},
+			Spec:       v1alpha1.OpenTelemetryCollectorSpec{Config: "receivers: {otlp: {}}"},
+		}
+	}
+	fakeClient := getFakeClient(t,
+		collector("unmanaged", map[string]string{"app": "collector"}),
+		collector("adopted", map[string]string{ResourceAdoptionKey: ResourceAdoptionValue}),
+	)
+	c := NewClient(clientLogger, fakeClient, nil, nil)
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	configmap := &protobufs.AgentConfigFile{Body: colConfig, ContentType: "yaml"}
+
+	instances, err := c.ListInstances()
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, "adopted", instances[0].Name)
+	assert.False(t, IsOwned(&instances[0]))
+	unmanaged, err := c.ListUnmanagedInstances()
+	require.NoError(t, err)
+	require.Len(t, unmanaged, 1)
+	assert.Equal(t, "unmanaged", unmanaged[0].Name)
+
+	// unmanaged collectors are read-only
+	err = c.Apply("unmanaged", "testing", configmap)
+	assert.ErrorContains(t, err, "OpenTelemetryCollector testing/unmanaged isn't managed by the bridge")
+	require.NoError(t, c.Delete("unmanaged", "testing"))
+	instance, err := c.GetInstance("unmanaged", "testing")
+	require.NoError(t, err)
+	require.NotNil(t, instance, "an unmanaged collector shouldn't be deleted")
+	assert.Equal(t, "receivers: {otlp: {}}", instance.Spec.Config)
+
+	// adopted collectors are owned once they are applied
+	c.SetOwner("01GQ1KZ7M3WQ9Y5RT2X8N4B6CD")
+	require.NoError(t, c.Apply("adopted", "testing", configmap))
+	instance, err = c.GetInstance("adopted", "testing")
+	require.NoError(t, err)
+	assert.True(t, IsOwned(instance))
+	assert.True(t, IsOwnedBy(instance, "01GQ1KZ7M3WQ9Y5RT2X8N4B6CD"))
+	assert.False(t, IsOwnedBy(instance, "01GQ1KZ7M3WQ9Y5RT2X8N4B6CE"))
+	assert.Equal(t, ResourceAdoptionValue, instance.Labels[ResourceAdoptionKey])
+	assert.Contains(t, instance.Spec.Config, "processors: []")
+	instances, err = c.ListInstances()
+	require.NoError(t, err)
+	assert.Len(t, instances, 1, "an owned and adopted collector should be listed once")
+}
+
+func TestClient_InstrumentationAdoption(t *testing.T) {
+	unmanaged := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "java", Namespace: "testing"}}
+	fakeClient := getFakeClient(t, unmanaged)
+	c := NewClient(clientLogger, fakeClient, nil, nil)
+	body := []byte("exporter:\n  endpoint: http://otel-collector:4317\n")
+
+	instrumentations, err := c.ListInstrumentations()
+	require.NoError(t, err)
+	assert.Empty(t, instrumentations)
+	err = c.ApplyInstrumentation("java", "testing", &protobufs.AgentConfigFile{Body: body, ContentType: "yaml"})
+	assert.ErrorContains(t, err, "Instrumentation testing/java isn't managed by the bridge")
+
+	unmanaged.Labels = map[string]string{ResourceAdoptionKey: ResourceAdoptionValue}
+	require.NoError(t, fakeClient.Update(context.Background(), unmanaged))
+	err = c.ApplyInstrumentation("java", "testing", &protobufs.AgentConfigFile{Body: body, ContentType: "yaml"})
+	require.NoError(t, err)
+	instrumentations, err = c.ListInstrumentations()
+	require.NoError(t, err)
+	require.Len(t, instrumentations, 1)
+	assert.True(t, IsOwned(&instrumentations[0]))
+}
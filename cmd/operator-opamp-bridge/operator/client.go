@@ -17,6 +17,7 @@ package operator
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/go-logr/logr"
 	"github.com/open-telemetry/opamp-go/protobufs"
@@ -25,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/config"
 )
 
 const (
@@ -40,27 +42,59 @@ type ConfigApplier interface {
 	// GetInstance retrieves an OpenTelemetryCollector CRD given a name and namespace.
 	GetInstance(name string, namespace string) (*v1alpha1.OpenTelemetryCollector, error)
 
-	// ListInstances retrieves all OpenTelemetryCollector CRDs created by the operator-opamp-bridge agent.
+	// ListInstances retrieves all OpenTelemetryCollector CRDs created or adopted by the operator-opamp-bridge agent.
 	ListInstances() ([]v1alpha1.OpenTelemetryCollector, error)
 
+	// ListUnmanagedInstances retrieves all OpenTelemetryCollector CRDs the operator-opamp-bridge agent can't manage.
+	ListUnmanagedInstances() ([]v1alpha1.OpenTelemetryCollector, error)
+
 	// Delete attempts to delete an OpenTelemetryCollector object given a name and namespace.
 	Delete(name string, namespace string) error
+
+	// GetEffectiveConfig retrieves the collector configuration rendered by the operator for an OpenTelemetryCollector.
+	GetEffectiveConfig(instance v1alpha1.OpenTelemetryCollector) ([]byte, error)
+
+	// GetCollectorStatus retrieves the observed state of an OpenTelemetryCollector, along with its health computed from
+	// its workload and its status.
+	GetCollectorStatus(instance v1alpha1.OpenTelemetryCollector) (*CollectorStatus, error)
+
+	// ApplyInstrumentation receives a name and namespace to apply an Instrumentation CRD that is contained in the configmap.
+	ApplyInstrumentation(name string, namespace string, configmap *protobufs.AgentConfigFile) error
+
+	// GetInstrumentation retrieves an Instrumentation CRD given a name and namespace.
+	GetInstrumentation(name string, namespace string) (*v1alpha1.Instrumentation, error)
+
+	// ListInstrumentations retrieves all Instrumentation CRDs created or adopted by the operator-opamp-bridge agent.
+	ListInstrumentations() ([]v1alpha1.Instrumentation, error)
+
+	// DeleteInstrumentation attempts to delete an Instrumentation object given a name and namespace.
+	DeleteInstrumentation(name string, namespace string) error
+
+	// ApplyTransaction applies all the changes or none of them, and returns the error of every change.
+	ApplyTransaction(changes []Change) []error
+
+	// SetOwner sets the instance UID of the operator-opamp-bridge agent the applied resources are labelled with.
+	SetOwner(instanceUid string)
 }
 
 type Client struct {
 	log               logr.Logger
 	componentsAllowed map[string]map[string]bool
+	policy            *config.PolicyConfig
 	k8sClient         client.Client
+	owner             *atomic.Pointer[string]
 	close             chan bool
 }
 
 var _ ConfigApplier = &Client{}
 
-func NewClient(log logr.Logger, c client.Client, componentsAllowed map[string]map[string]bool) *Client {
+func NewClient(log logr.Logger, c client.Client, componentsAllowed map[string]map[string]bool, policy *config.PolicyConfig) *Client {
 	return &Client{
 		log:               log,
 		componentsAllowed: componentsAllowed,
+		policy:            policy,
 		k8sClient:         c,
+		owner:             &atomic.Pointer[string]{},
 		close:             make(chan bool, 1),
 	}
 }
@@ -73,10 +107,7 @@ func (c Client) create(ctx context.Context, name string, namespace string, colle
 	collector.ObjectMeta.Name = name
 	collector.ObjectMeta.Namespace = namespace
 
-	if collector.ObjectMeta.Labels == nil {
-		collector.ObjectMeta.Labels = map[string]string{}
-	}
-	collector.ObjectMeta.Labels[ResourceIdentifierKey] = ResourceIdentifierValue
+	c.own(collector)
 	err := collector.ValidateCreate()
 	if err != nil {
 		return err
@@ -86,8 +117,10 @@ func (c Client) create(ctx context.Context, name string, namespace string, colle
 }
 
 func (c Client) update(ctx context.Context, old *v1alpha1.OpenTelemetryCollector, new *v1alpha1.OpenTelemetryCollector) error {
-	new.ObjectMeta = old.ObjectMeta
+	new.ObjectMeta = *old.ObjectMeta.DeepCopy()
 	new.TypeMeta = old.TypeMeta
+	// Take the ownership of an adopted collector
+	c.own(new)
 	err := new.ValidateUpdate(old)
 	if err != nil {
 		return err
@@ -113,13 +146,23 @@ func (c Client) Apply(name string, namespace string, configmap *protobufs.AgentC
 	if len(reasons) > 0 {
 		return errors.NewBadRequest(fmt.Sprintf("Items in config are not allowed: %v", reasons))
 	}
-	collector := &v1alpha1.OpenTelemetryCollector{Spec: collectorSpec}
 	ctx := context.Background()
+	violations, err := c.checkCollectorPolicy(ctx, namespace, collectorSpec)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return policyError(violations)
+	}
+	collector := &v1alpha1.OpenTelemetryCollector{Spec: collectorSpec}
 	instance, err := c.GetInstance(name, namespace)
 	if err != nil {
 		return err
 	}
 	if instance != nil {
+		if !IsManaged(instance) {
+			return unmanagedError(CollectorResource, name, namespace)
+		}
 		return c.update(ctx, instance, collector)
 	}
 	return c.create(ctx, name, namespace, collector)
@@ -138,19 +181,28 @@ func (c Client) Delete(name string, namespace string) error {
 		}
 		return err
 	}
+	if !IsManaged(&result) {
+		c.log.Info("Not deleting an unmanaged collector", "name", name, "namespace", namespace)
+		return nil
+	}
+	if err := c.checkDeletePolicy(ctx, namespace); err != nil {
+		return err
+	}
 	return c.k8sClient.Delete(ctx, &result)
 }
 
 func (c Client) ListInstances() ([]v1alpha1.OpenTelemetryCollector, error) {
-	ctx := context.Background()
-	result := v1alpha1.OpenTelemetryCollectorList{}
-	err := c.k8sClient.List(ctx, &result, client.MatchingLabels{
-		ResourceIdentifierKey: ResourceIdentifierValue,
+	objects, err := c.listManaged(context.Background(), func() client.ObjectList {
+		return &v1alpha1.OpenTelemetryCollectorList{}
 	})
 	if err != nil {
 		return nil, err
 	}
-	return result.Items, nil
+	var instances []v1alpha1.OpenTelemetryCollector
+	for _, obj := range objects {
+		instances = append(instances, *obj.(*v1alpha1.OpenTelemetryCollector))
+	}
+	return instances, nil
 }
 
 func (c Client) GetInstance(name string, namespace string) (*v1alpha1.OpenTelemetryCollector, error) {
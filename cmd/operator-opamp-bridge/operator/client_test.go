@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -35,16 +36,18 @@ var (
 	clientLogger = logf.Log.WithName("client-tests")
 )
 
-func getFakeClient(t *testing.T) client.WithWatch {
+func getFakeClient(t *testing.T, objs ...client.Object) client.WithWatch {
 	schemeBuilder := runtime.NewSchemeBuilder(func(s *runtime.Scheme) error {
-		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{})
+		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{},
+			&v1alpha1.Instrumentation{}, &v1alpha1.InstrumentationList{})
 		metav1.AddToGroupVersion(s, v1alpha1.GroupVersion)
 		return nil
 	})
 	scheme := runtime.NewScheme()
 	err := schemeBuilder.AddToScheme(scheme)
 	require.NoError(t, err, "Should be able to add custom types")
-	c := fake.NewClientBuilder().WithScheme(scheme)
+	require.NoError(t, clientgoscheme.AddToScheme(scheme), "Should be able to add the core types")
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...)
 	return c.Build()
 }
 
@@ -91,7 +94,7 @@ func TestClient_Apply(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := getFakeClient(t)
-			c := NewClient(clientLogger, fakeClient, nil)
+			c := NewClient(clientLogger, fakeClient, nil, nil)
 			var colConfig []byte
 			var err error
 			if len(tt.args.file) > 0 {
@@ -115,7 +118,7 @@ func Test_collectorUpdate(t *testing.T) {
 	name := "test"
 	namespace := "testing"
 	fakeClient := getFakeClient(t)
-	c := NewClient(clientLogger, fakeClient, nil)
+	c := NewClient(clientLogger, fakeClient, nil, nil)
 	colConfig, err := loadConfig("testdata/collector.yaml")
 	require.NoError(t, err, "Should be no error on loading test configuration")
 	configmap := &protobufs.AgentConfigFile{
@@ -161,7 +164,7 @@ func Test_collectorDelete(t *testing.T) {
 	name := "test"
 	namespace := "testing"
 	fakeClient := getFakeClient(t)
-	c := NewClient(clientLogger, fakeClient, nil)
+	c := NewClient(clientLogger, fakeClient, nil, nil)
 	colConfig, err := loadConfig("testdata/collector.yaml")
 	require.NoError(t, err, "Should be no error on loading test configuration")
 	configmap := &protobufs.AgentConfigFile{
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+const (
+	// reconciledCondition is the condition the operator sets to false when it fails to reconcile a collector.
+	reconciledCondition = "Reconciled"
+)
+
+// unhealthyWaitingReasons are the reasons of waiting containers which won't recover on their own.
+var unhealthyWaitingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"CreateContainerConfigError": true,
+}
+
+// getCollectorHealth computes the health of a collector from the readiness of its workload, the state of the
+// containers of its pods and the Reconciled condition set by the operator. A collector in sidecar mode has no
+// workload of its own and is only checked for reconcile errors. A collector whose workload can't be read is unhealthy.
+func (c Client) getCollectorHealth(ctx context.Context, instance v1alpha1.OpenTelemetryCollector, pods []corev1.Pod, reconciled *metav1.Condition) *protobufs.ComponentHealth {
+	health := &protobufs.ComponentHealth{
+		Healthy:            true,
+		StatusTimeUnixNano: uint64(time.Now().UnixNano()),
+	}
+
+	if instance.Spec.Mode != v1alpha1.ModeSidecar {
+		ready, desired, err := c.getReplicas(ctx, instance)
+		if err != nil {
+			health.Healthy = false
+			health.LastError = err.Error()
+			return health
+		}
+		restarts, podErr := podState(pods)
+		health.Status = fmt.Sprintf("%d/%d replicas ready, %d restarts", ready, desired, restarts)
+		if ready < desired {
+			health.Healthy = false
+			health.LastError = fmt.Sprintf("%d of %d replicas are not ready", desired-ready, desired)
+		}
+		if podErr != "" {
+			health.Healthy = false
+			health.LastError = podErr
+		}
+	} else {
+		health.Status = "sidecar"
+	}
+
+	if reconciled != nil && reconciled.Status == metav1.ConditionFalse {
+		health.Healthy = false
+		health.LastError = reconciled.Message
+	}
+	return health
+}
+
+// getReplicas returns the number of ready and desired replicas of the workload of the collector.
+func (c Client) getReplicas(ctx context.Context, instance v1alpha1.OpenTelemetryCollector) (int32, int32, error) {
+	key := client.ObjectKey{Namespace: instance.Namespace, Name: naming.Collector(instance)}
+	switch instance.Spec.Mode {
+	case v1alpha1.ModeDaemonSet:
+		daemonSet := appsv1.DaemonSet{}
+		if err := c.k8sClient.Get(ctx, key, &daemonSet); err != nil {
+			return 0, 0, workloadError("daemonset", key, err)
+		}
+		return daemonSet.Status.NumberReady, daemonSet.Status.DesiredNumberScheduled, nil
+	case v1alpha1.ModeStatefulSet:
+		statefulSet := appsv1.StatefulSet{}
+		if err := c.k8sClient.Get(ctx, key, &statefulSet); err != nil {
+			return 0, 0, workloadError("statefulset", key, err)
+		}
+		return statefulSet.Status.ReadyReplicas, desiredReplicas(statefulSet.Spec.Replicas), nil
+	default:
+		deployment := appsv1.Deployment{}
+		if err := c.k8sClient.Get(ctx, key, &deployment); err != nil {
+			return 0, 0, workloadError("deployment", key, err)
+		}
+		return deployment.Status.ReadyReplicas, desiredReplicas(deployment.Spec.Replicas), nil
+	}
+}
+
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func workloadError(kind string, key client.ObjectKey, err error) error {
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("the %s %s doesn't exist", kind, key)
+	}
+	return err
+}
+
+// listPods returns the pods of the workload of the collector.
+func (c Client) listPods(ctx context.Context, instance v1alpha1.OpenTelemetryCollector) ([]corev1.Pod, error) {
+	pods := corev1.PodList{}
+	err := c.k8sClient.List(ctx, &pods, client.InNamespace(instance.Namespace), client.MatchingLabels{
+		"app.kubernetes.io/instance":   naming.Truncate("%s.%s", 63, instance.Namespace, instance.Name),
+		"app.kubernetes.io/component":  "opentelemetry-collector",
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// podState returns the sum of the container restarts of the pods, along with a message if a container is stuck in a
+// state it won't recover from on its own.
+func podState(pods []corev1.Pod) (int32, string) {
+	var (
+		restarts int32
+		podErr   string
+	)
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			restarts += status.RestartCount
+			waiting := status.State.Waiting
+			if podErr == "" && waiting != nil && unhealthyWaitingReasons[waiting.Reason] {
+				podErr = fmt.Sprintf("container %s of pod %s is in %s: %s", status.Name, pod.Name, waiting.Reason, waiting.Message)
+			}
+		}
+	}
+	return restarts, podErr
+}
+
+// getReconciledCondition returns the Reconciled condition the operator sets on the collector, nil if it isn't set.
+// The condition is read from the unstructured object, as it's not part of every version of the API types.
+func (c Client) getReconciledCondition(ctx context.Context, instance v1alpha1.OpenTelemetryCollector) (*metav1.Condition, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(v1alpha1.GroupVersion.WithKind(CollectorResource))
+	if err := c.k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), obj); err != nil {
+		return nil, err
+	}
+	return reconciledConditionOf(obj)
+}
+
+func reconciledConditionOf(obj *unstructured.Unstructured) (*metav1.Condition, error) {
+	rawConditions, found, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil || !found {
+		return nil, err
+	}
+	var conditions []metav1.Condition
+	for _, raw := range rawConditions {
+		rawMap, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condition := metav1.Condition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawMap, &condition); err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return meta.FindStatusCondition(conditions, reconciledCondition), nil
+}
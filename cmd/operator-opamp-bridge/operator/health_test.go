@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestClient_GetCollectorStatusHealth(t *testing.T) {
+	three := int32(3)
+	instance := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "opentelemetry",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+		},
+	}
+	deployment := func(ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-collector", Namespace: "opentelemetry"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &three},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: ready},
+		}
+	}
+	pod := func(name string, restarts int32, waitingReason string) *corev1.Pod {
+		status := corev1.ContainerStatus{Name: "otc-container", RestartCount: restarts}
+		if waitingReason != "" {
+			status.State.Waiting = &corev1.ContainerStateWaiting{Reason: waitingReason, Message: "back-off restarting"}
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "opentelemetry",
+				Labels: map[string]string{
+					"app.kubernetes.io/instance":   "opentelemetry.test",
+					"app.kubernetes.io/component":  "opentelemetry-collector",
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				},
+			},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{status}},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		mode          v1alpha1.Mode
+		objs          []client.Object
+		wantHealthy   bool
+		wantStatus    string
+		wantLastError string
+	}{
+		{
+			name:        "all replicas ready",
+			objs:        []client.Object{deployment(3), pod("a", 0, ""), pod("b", 1, ""), pod("c", 0, "")},
+			wantHealthy: true,
+			wantStatus:  "3/3 replicas ready, 1 restarts",
+		},
+		{
+			name:          "replicas not ready",
+			objs:          []client.Object{deployment(1)},
+			wantHealthy:   false,
+			wantStatus:    "1/3 replicas ready, 0 restarts",
+			wantLastError: "2 of 3 replicas are not ready",
+		},
+		{
+			name:          "crash looping container",
+			objs:          []client.Object{deployment(2), pod("a", 5, "CrashLoopBackOff")},
+			wantHealthy:   false,
+			wantStatus:    "2/3 replicas ready, 5 restarts",
+			wantLastError: "container otc-container of pod a is in CrashLoopBackOff: back-off restarting",
+		},
+		{
+			name:        "sidecar",
+			mode:        v1alpha1.ModeSidecar,
+			wantHealthy: true,
+			wantStatus:  "sidecar",
+		},
+		{
+			name:          "missing workload",
+			wantHealthy:   false,
+			wantLastError: "the deployment opentelemetry/test-collector doesn't exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := getFakeClient(t, tt.objs...)
+			c := NewClient(clientLogger, fakeClient, nil, nil)
+			collector := instance.DeepCopy()
+			if tt.mode != "" {
+				collector.Spec.Mode = tt.mode
+			}
+			require.NoError(t, fakeClient.Create(context.Background(), collector))
+
+			status, err := c.GetCollectorStatus(*collector)
+			require.NoError(t, err)
+			health := status.Health
+			assert.Equal(t, tt.wantHealthy, health.Healthy)
+			assert.Equal(t, tt.wantStatus, health.Status)
+			assert.Equal(t, tt.wantLastError, health.LastError)
+		})
+	}
+}
+
+func Test_reconciledConditionOf(t *testing.T) {
+	tests := []struct {
+		name        string
+		conditions  []interface{}
+		wantStatus  metav1.ConditionStatus
+		wantMessage string
+	}{
+		{
+			name: "no conditions",
+		},
+		{
+			name: "reconciled",
+			conditions: []interface{}{
+				map[string]interface{}{
+					"type":               "Reconciled",
+					"status":             "True",
+					"reason":             "Reconciled",
+					"message":            "",
+					"lastTransitionTime": "2023-01-01T00:00:00Z",
+				},
+			},
+			wantStatus: metav1.ConditionTrue,
+		},
+		{
+			name: "reconcile failed",
+			conditions: []interface{}{
+				map[string]interface{}{
+					"type":               "ConfigValid",
+					"status":             "True",
+					"reason":             "ConfigValid",
+					"message":            "",
+					"lastTransitionTime": "2023-01-01T00:00:00Z",
+				},
+				map[string]interface{}{
+					"type":               "Reconciled",
+					"status":             "False",
+					"reason":             "ServicesFailed",
+					"message":            "failed to reconcile services: boom",
+					"lastTransitionTime": "2023-01-01T00:00:00Z",
+				},
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: "failed to reconcile services: boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			if tt.conditions != nil {
+				require.NoError(t, unstructured.SetNestedSlice(obj.Object, tt.conditions, "status", "conditions"))
+			}
+			got, err := reconciledConditionOf(obj)
+			require.NoError(t, err)
+			if tt.wantStatus == "" {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.Equal(t, tt.wantMessage, got.Message)
+		})
+	}
+}
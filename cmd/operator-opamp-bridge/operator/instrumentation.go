@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	InstrumentationResource = "Instrumentation"
+)
+
+func (c Client) createInstrumentation(ctx context.Context, name string, namespace string, instrumentation *v1alpha1.Instrumentation) error {
+	instrumentation.TypeMeta.Kind = InstrumentationResource
+	instrumentation.TypeMeta.APIVersion = v1alpha1.GroupVersion.String()
+	instrumentation.ObjectMeta.Name = name
+	instrumentation.ObjectMeta.Namespace = namespace
+	// Set the defaults
+	instrumentation.Default()
+
+	c.own(instrumentation)
+	err := instrumentation.ValidateCreate()
+	if err != nil {
+		return err
+	}
+	c.log.Info("Creating instrumentation")
+	return c.k8sClient.Create(ctx, instrumentation)
+}
+
+func (c Client) updateInstrumentation(ctx context.Context, old *v1alpha1.Instrumentation, new *v1alpha1.Instrumentation) error {
+	new.ObjectMeta = *old.ObjectMeta.DeepCopy()
+	new.TypeMeta = old.TypeMeta
+	// Take the ownership of an adopted instrumentation
+	c.own(new)
+	err := new.ValidateUpdate(old)
+	if err != nil {
+		return err
+	}
+	c.log.Info("Updating instrumentation")
+	return c.k8sClient.Update(ctx, new)
+}
+
+// ApplyInstrumentation creates or updates the Instrumentation with the given name and namespace from the spec contained
+// in the configmap. Unlike a collector spec, the spec is decoded with its JSON field names, e.g. resourceAttributes.
+func (c Client) ApplyInstrumentation(name string, namespace string, configmap *protobufs.AgentConfigFile) error {
+	c.log.Info("Received new instrumentation", "name", name, "namespace", namespace)
+	var instrumentationSpec v1alpha1.InstrumentationSpec
+	err := yaml.Unmarshal(configmap.Body, &instrumentationSpec)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	violations, err := c.checkNamespacePolicy(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return policyError(violations)
+	}
+	instrumentation := &v1alpha1.Instrumentation{Spec: instrumentationSpec}
+	instance, err := c.GetInstrumentation(name, namespace)
+	if err != nil {
+		return err
+	}
+	if instance != nil {
+		if !IsManaged(instance) {
+			return unmanagedError(InstrumentationResource, name, namespace)
+		}
+		return c.updateInstrumentation(ctx, instance, instrumentation)
+	}
+	return c.createInstrumentation(ctx, name, namespace, instrumentation)
+}
+
+func (c Client) DeleteInstrumentation(name string, namespace string) error {
+	ctx := context.Background()
+	result := v1alpha1.Instrumentation{}
+	err := c.k8sClient.Get(ctx, client.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}, &result)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !IsManaged(&result) {
+		c.log.Info("Not deleting an unmanaged instrumentation", "name", name, "namespace", namespace)
+		return nil
+	}
+	if err := c.checkDeletePolicy(ctx, namespace); err != nil {
+		return err
+	}
+	return c.k8sClient.Delete(ctx, &result)
+}
+
+func (c Client) ListInstrumentations() ([]v1alpha1.Instrumentation, error) {
+	objects, err := c.listManaged(context.Background(), func() client.ObjectList {
+		return &v1alpha1.InstrumentationList{}
+	})
+	if err != nil {
+		return nil, err
+	}
+	var instrumentations []v1alpha1.Instrumentation
+	for _, obj := range objects {
+		instrumentations = append(instrumentations, *obj.(*v1alpha1.Instrumentation))
+	}
+	return instrumentations, nil
+}
+
+func (c Client) GetInstrumentation(name string, namespace string) (*v1alpha1.Instrumentation, error) {
+	ctx := context.Background()
+	result := v1alpha1.Instrumentation{}
+	err := c.k8sClient.Get(ctx, client.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}, &result)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
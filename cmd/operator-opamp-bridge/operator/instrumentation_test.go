@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func Test_instrumentationUpdate(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	c := NewClient(clientLogger, fakeClient, nil, nil)
+	instConfig, err := loadConfig("testdata/instrumentation.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	configmap := &protobufs.AgentConfigFile{
+		Body:        instConfig,
+		ContentType: "yaml",
+	}
+	// Apply a valid initial configuration
+	err = c.ApplyInstrumentation(name, namespace, configmap)
+	require.NoError(t, err, "Should apply base config")
+
+	// Get the newly created instrumentation
+	instance, err := c.GetInstrumentation(name, namespace)
+	require.NoError(t, err, "Should be able to get the newly created instrumentation")
+	require.NotNil(t, instance)
+	assert.Equal(t, ResourceIdentifierValue, instance.Labels[ResourceIdentifierKey])
+	assert.Equal(t, "http://otel-collector:4317", instance.Spec.Exporter.Endpoint)
+	assert.Equal(t, v1alpha1.ParentBasedTraceIDRatio, instance.Spec.Sampler.Type)
+	assert.Equal(t, "0.25", instance.Spec.Sampler.Argument)
+	assert.Equal(t, "staging", instance.Spec.Resource.Attributes["deployment.environment"])
+
+	// Try updating with an invalid one
+	invalidConfig, err := loadConfig("testdata/invalid-instrumentation.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	err = c.ApplyInstrumentation(name, namespace, &protobufs.AgentConfigFile{Body: invalidConfig, ContentType: "yaml"})
+	assert.Error(t, err, "Should be unable to update")
+
+	// Update successfully with a valid configuration
+	newInstConfig, err := loadConfig("testdata/updated-instrumentation.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	err = c.ApplyInstrumentation(name, namespace, &protobufs.AgentConfigFile{Body: newInstConfig, ContentType: "yaml"})
+	require.NoError(t, err, "Should be able to update instrumentation")
+
+	// Get the updated instrumentation
+	updatedInstance, err := c.GetInstrumentation(name, namespace)
+	require.NoError(t, err, "Should be able to get the updated instrumentation")
+	assert.Equal(t, "1", updatedInstance.Spec.Sampler.Argument)
+	assert.Len(t, updatedInstance.Spec.Java.Env, 1)
+	assert.Empty(t, updatedInstance.Spec.Resource.Attributes)
+
+	allInstances, err := c.ListInstrumentations()
+	require.NoError(t, err, "Should be able to list all instrumentations")
+	assert.Len(t, allInstances, 1)
+	assert.Equal(t, allInstances[0], *updatedInstance)
+}
+
+func Test_instrumentationCreateInvalid(t *testing.T) {
+	c := NewClient(clientLogger, getFakeClient(t), nil, nil)
+	invalidConfig, err := loadConfig("testdata/invalid-instrumentation.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	err = c.ApplyInstrumentation("test", "testing", &protobufs.AgentConfigFile{Body: invalidConfig, ContentType: "yaml"})
+	assert.Error(t, err, "Should be unable to create")
+
+	allInstances, err := c.ListInstrumentations()
+	require.NoError(t, err, "Should be able to list all instrumentations")
+	assert.Len(t, allInstances, 0)
+}
+
+func Test_instrumentationDelete(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	c := NewClient(clientLogger, fakeClient, nil, nil)
+	instConfig, err := loadConfig("testdata/instrumentation.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	err = c.ApplyInstrumentation(name, namespace, &protobufs.AgentConfigFile{Body: instConfig, ContentType: "yaml"})
+	require.NoError(t, err, "Should apply base config")
+
+	// Delete it
+	err = c.DeleteInstrumentation(name, namespace)
+	require.NoError(t, err, "Should be able to delete an instrumentation")
+
+	// Deleting it again is a no-op
+	err = c.DeleteInstrumentation(name, namespace)
+	require.NoError(t, err, "Should ignore missing instrumentations")
+
+	// Check there's nothing left
+	allInstances, err := c.ListInstrumentations()
+	require.NoError(t, err, "Should be able to list all instrumentations")
+	assert.Len(t, allInstances, 0)
+}
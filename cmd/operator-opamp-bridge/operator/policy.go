@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// checkCollectorPolicy returns the reasons why the policy doesn't allow to apply the collector spec to the namespace.
+func (c Client) checkCollectorPolicy(ctx context.Context, namespace string, spec v1alpha1.OpenTelemetryCollectorSpec) ([]string, error) {
+	violations, err := c.checkNamespacePolicy(ctx, namespace)
+	if err != nil || c.policy == nil {
+		return violations, err
+	}
+
+	if len(c.policy.AllowedModes) > 0 {
+		mode := spec.Mode
+		if mode == "" {
+			mode = v1alpha1.ModeDeployment
+		}
+		if !contains(c.policy.AllowedModes, string(mode)) {
+			violations = append(violations, fmt.Sprintf("mode %s is not allowed", mode))
+		}
+	}
+
+	if maxReplicas := c.policy.MaxReplicas; maxReplicas != nil {
+		if spec.Replicas != nil && *spec.Replicas > *maxReplicas {
+			violations = append(violations, fmt.Sprintf("replicas %d exceed the maximum of %d", *spec.Replicas, *maxReplicas))
+		}
+		if spec.MaxReplicas != nil && *spec.MaxReplicas > *maxReplicas {
+			violations = append(violations, fmt.Sprintf("maxReplicas %d exceed the maximum of %d", *spec.MaxReplicas, *maxReplicas))
+		}
+	}
+
+	if len(c.policy.AllowedImages) > 0 {
+		// an empty image is defaulted by the operator
+		for _, image := range []string{spec.Image, spec.TargetAllocator.Image} {
+			if image != "" && !imageAllowed(image, c.policy.AllowedImages) {
+				violations = append(violations, fmt.Sprintf("image %s is not allowed", image))
+			}
+		}
+	}
+
+	if len(c.policy.ForbiddenFields) > 0 {
+		fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range c.policy.ForbiddenFields {
+			value, found, err := unstructured.NestedFieldNoCopy(fields, strings.Split(field, ".")...)
+			if err == nil && found && !isEmpty(value) {
+				violations = append(violations, fmt.Sprintf("field %s must not be set", field))
+			}
+		}
+	}
+	return violations, nil
+}
+
+// checkNamespacePolicy returns the reasons why the policy doesn't allow to apply resources to the namespace.
+func (c Client) checkNamespacePolicy(ctx context.Context, namespace string) ([]string, error) {
+	if c.policy == nil {
+		return nil, nil
+	}
+	var violations []string
+	if len(c.policy.AllowedNamespaces) > 0 && !matchesAny(namespace, c.policy.AllowedNamespaces) {
+		violations = append(violations, fmt.Sprintf("namespace %s is not allowed", namespace))
+	}
+	if len(c.policy.NamespaceSelector) > 0 {
+		ns := corev1.Namespace{}
+		err := c.k8sClient.Get(ctx, client.ObjectKey{Name: namespace}, &ns)
+		switch {
+		case errors.IsNotFound(err):
+			violations = append(violations, fmt.Sprintf("namespace %s doesn't exist", namespace))
+		case err != nil:
+			return nil, err
+		case !labels.SelectorFromSet(c.policy.NamespaceSelector).Matches(labels.Set(ns.Labels)):
+			violations = append(violations, fmt.Sprintf("namespace %s doesn't match the namespace selector", namespace))
+		}
+	}
+	return violations, nil
+}
+
+// checkDeletePolicy returns an error if the policy doesn't allow to delete resources from the namespace, e.g. because
+// the namespace was excluded since the resources were applied.
+func (c Client) checkDeletePolicy(ctx context.Context, namespace string) error {
+	violations, err := c.checkNamespacePolicy(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return policyError(violations)
+	}
+	return nil
+}
+
+func policyError(violations []string) error {
+	return errors.NewBadRequest(fmt.Sprintf("Policy violations: %s", strings.Join(violations, "; ")))
+}
+
+func imageAllowed(image string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "/") && strings.HasPrefix(image, pattern) {
+			return true
+		}
+	}
+	return matchesAny(image, patterns)
+}
+
+func matchesAny(s string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, s); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmpty returns whether a field of the unstructured spec is unset. Zero numbers and booleans are only present if they
+// were set, as the fields holding them are either omitted when empty or pointers.
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/config"
+)
+
+func TestClient_ApplyPolicy(t *testing.T) {
+	two := int32(2)
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"opamp": "enabled"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+	}
+	spec := func(extra string) []byte {
+		return []byte("config: |\n  receivers:\n    otlp:\n" + extra)
+	}
+
+	tests := []struct {
+		name           string
+		policy         config.PolicyConfig
+		namespace      string
+		body           []byte
+		wantViolations []string
+	}{
+		{
+			name:      "no policy",
+			namespace: "kube-system",
+			body:      spec("mode: daemonset\nhostnetwork: true\n"),
+		},
+		{
+			name:      "allowed namespace",
+			policy:    config.PolicyConfig{AllowedNamespaces: []string{"team-*"}},
+			namespace: "team-a",
+			body:      spec(""),
+		},
+		{
+			name:           "namespace not allowed",
+			policy:         config.PolicyConfig{AllowedNamespaces: []string{"team-*"}},
+			namespace:      "kube-system",
+			body:           spec(""),
+			wantViolations: []string{"namespace kube-system is not allowed"},
+		},
+		{
+			name:      "namespace selected",
+			policy:    config.PolicyConfig{NamespaceSelector: map[string]string{"opamp": "enabled"}},
+			namespace: "team-a",
+			body:      spec(""),
+		},
+		{
+			name:           "namespace not selected",
+			policy:         config.PolicyConfig{NamespaceSelector: map[string]string{"opamp": "enabled"}},
+			namespace:      "team-b",
+			body:           spec(""),
+			wantViolations: []string{"namespace team-b doesn't match the namespace selector"},
+		},
+		{
+			name:           "missing namespace",
+			policy:         config.PolicyConfig{NamespaceSelector: map[string]string{"opamp": "enabled"}},
+			namespace:      "team-c",
+			body:           spec(""),
+			wantViolations: []string{"namespace team-c doesn't exist"},
+		},
+		{
+			name:      "forbidden fields not set",
+			policy:    config.PolicyConfig{ForbiddenFields: []string{"hostNetwork", "volumes", "resources", "upgradeStrategy"}},
+			namespace: "team-a",
+			body:      spec("hostnetwork: false\n"),
+		},
+		{
+			name:      "default mode",
+			policy:    config.PolicyConfig{AllowedModes: []string{"deployment"}},
+			namespace: "team-a",
+			body:      spec(""),
+		},
+		{
+			name:           "mode not allowed",
+			policy:         config.PolicyConfig{AllowedModes: []string{"deployment", "statefulset"}},
+			namespace:      "team-a",
+			body:           spec("mode: daemonset\n"),
+			wantViolations: []string{"mode daemonset is not allowed"},
+		},
+		{
+			name:           "too many replicas",
+			policy:         config.PolicyConfig{MaxReplicas: &two},
+			namespace:      "team-a",
+			body:           spec("replicas: 3\nmaxreplicas: 5\n"),
+			wantViolations: []string{"replicas 3 exceed the maximum of 2", "maxReplicas 5 exceed the maximum of 2"},
+		},
+		{
+			name:      "allowed registry",
+			policy:    config.PolicyConfig{AllowedImages: []string{"ghcr.io/open-telemetry/"}},
+			namespace: "team-a",
+			body:      spec("image: ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector-contrib:0.68.0\n"),
+		},
+		{
+			name:           "image not allowed",
+			policy:         config.PolicyConfig{AllowedImages: []string{"ghcr.io/open-telemetry/", "otel/opentelemetry-collector:*"}},
+			namespace:      "team-a",
+			body:           spec("image: docker.io/someone/collector:latest\n"),
+			wantViolations: []string{"image docker.io/someone/collector:latest is not allowed"},
+		},
+		{
+			name:      "allowed image",
+			policy:    config.PolicyConfig{AllowedImages: []string{"otel/opentelemetry-collector:*"}},
+			namespace: "team-a",
+			body:      spec("image: otel/opentelemetry-collector:0.68.0\n"),
+		},
+		{
+			name:           "forbidden fields",
+			policy:         config.PolicyConfig{ForbiddenFields: []string{"hostNetwork", "volumes", "podSecurityContext.runAsUser", "nodeSelector"}},
+			namespace:      "team-a",
+			body:           spec("hostnetwork: true\npodsecuritycontext:\n  runasuser: 0\n"),
+			wantViolations: []string{"field hostNetwork must not be set", "field podSecurityContext.runAsUser must not be set"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := getFakeClient(t, namespaces[0].DeepCopy(), namespaces[1].DeepCopy())
+			c := NewClient(clientLogger, fakeClient, nil, &tt.policy)
+			err := c.Apply("test", tt.namespace, &protobufs.AgentConfigFile{Body: tt.body, ContentType: "yaml"})
+			instance, getErr := c.GetInstance("test", tt.namespace)
+			require.NoError(t, getErr)
+			if len(tt.wantViolations) == 0 {
+				require.NoError(t, err)
+				assert.NotNil(t, instance)
+				return
+			}
+			require.Error(t, err)
+			for _, violation := range tt.wantViolations {
+				assert.Contains(t, err.Error(), violation)
+			}
+			assert.Nil(t, instance, "the collector shouldn't be created")
+		})
+	}
+}
+
+func TestClient_ApplyInstrumentationPolicy(t *testing.T) {
+	policy := &config.PolicyConfig{AllowedNamespaces: []string{"team-*"}}
+	c := NewClient(clientLogger, getFakeClient(t), nil, policy)
+	body := []byte("exporter:\n  endpoint: http://otel-collector:4317\n")
+
+	err := c.ApplyInstrumentation("test", "kube-system", &protobufs.AgentConfigFile{Body: body, ContentType: "yaml"})
+	assert.ErrorContains(t, err, "namespace kube-system is not allowed")
+
+	err = c.ApplyInstrumentation("test", "team-a", &protobufs.AgentConfigFile{Body: body, ContentType: "yaml"})
+	assert.NoError(t, err)
+}
+
+func TestClient_DeletePolicy(t *testing.T) {
+	owned := map[string]string{ResourceIdentifierKey: ResourceIdentifierValue}
+	fakeClient := getFakeClient(t,
+		&v1alpha1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "kube-system", Labels: owned}},
+		&v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "kube-system", Labels: owned}},
+	)
+	policy := &config.PolicyConfig{AllowedNamespaces: []string{"team-*"}}
+	c := NewClient(clientLogger, fakeClient, nil, policy)
+
+	err := c.Delete("test", "kube-system")
+	assert.ErrorContains(t, err, "namespace kube-system is not allowed")
+	instance, err := c.GetInstance("test", "kube-system")
+	require.NoError(t, err)
+	assert.NotNil(t, instance, "the collector shouldn't be deleted")
+
+	err = c.DeleteInstrumentation("test", "kube-system")
+	assert.ErrorContains(t, err, "namespace kube-system is not allowed")
+	instrumentation, err := c.GetInstrumentation("test", "kube-system")
+	require.NoError(t, err)
+	assert.NotNil(t, instrumentation, "the instrumentation shouldn't be deleted")
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+const (
+	// collectorConfigKey is the key of the rendered collector configuration in the ConfigMap created by the operator.
+	collectorConfigKey = "collector.yaml"
+	collectorContainer = "otc-container"
+)
+
+const (
+	// ReconcileResultSucceeded is reported if the last reconciliation of the collector succeeded.
+	ReconcileResultSucceeded = "succeeded"
+	// ReconcileResultUnknown is reported as long as the operator hasn't reconciled the collector.
+	ReconcileResultUnknown = "unknown"
+)
+
+// CollectorStatus is the observed state of a collector, reported apart from its configuration.
+type CollectorStatus struct {
+	// Pods is the number of pods of the collector's workload.
+	Pods int
+	// ReadyPods is the number of pods ready to serve.
+	ReadyPods int
+	// Image is the image the collector pods are running, or the one the operator will deploy if there's no pod.
+	Image string
+	// Version is the version of the collector reported by the operator.
+	Version string
+	// ReconcileResult is "succeeded" or the error of the last reconciliation of the collector by the operator.
+	ReconcileResult string
+	// Health is the health of the collector, computed from the same pods and reconciliation result.
+	Health *protobufs.ComponentHealth
+}
+
+// GetEffectiveConfig returns the collector configuration the operator rendered into the collector's ConfigMap,
+// which includes the changes made for the target allocator. The configuration of the spec is returned as long as
+// the operator hasn't created the ConfigMap.
+func (c Client) GetEffectiveConfig(instance v1alpha1.OpenTelemetryCollector) ([]byte, error) {
+	configMap := corev1.ConfigMap{}
+	err := c.k8sClient.Get(context.Background(), client.ObjectKey{
+		Namespace: instance.Namespace,
+		Name:      naming.ConfigMap(instance),
+	}, &configMap)
+	if errors.IsNotFound(err) {
+		return []byte(instance.Spec.Config), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rendered, ok := configMap.Data[collectorConfigKey]
+	if !ok {
+		return nil, fmt.Errorf("the configmap %s/%s has no %s key", configMap.Namespace, configMap.Name, collectorConfigKey)
+	}
+	return []byte(rendered), nil
+}
+
+// GetCollectorStatus returns the observed state of the collector along with its health. The pods of the collector and
+// its Reconciled condition are read once for both.
+func (c Client) GetCollectorStatus(instance v1alpha1.OpenTelemetryCollector) (*CollectorStatus, error) {
+	ctx := context.Background()
+	status := &CollectorStatus{
+		Image:           instance.Spec.Image,
+		Version:         instance.Status.Version,
+		ReconcileResult: ReconcileResultUnknown,
+	}
+
+	pods, err := c.listPods(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	status.Pods = len(pods)
+	for _, pod := range pods {
+		if isPodReady(pod) {
+			status.ReadyPods++
+		}
+		for _, container := range pod.Spec.Containers {
+			if container.Name == collectorContainer {
+				status.Image = container.Image
+			}
+		}
+	}
+
+	reconciled, err := c.getReconciledCondition(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	if reconciled != nil {
+		status.ReconcileResult = ReconcileResultSucceeded
+		if reconciled.Status == metav1.ConditionFalse {
+			status.ReconcileResult = reconciled.Message
+		}
+	}
+	status.Health = c.getCollectorHealth(ctx, instance, pods, reconciled)
+	return status, nil
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
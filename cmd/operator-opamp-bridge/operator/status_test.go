@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestClient_GetEffectiveConfig(t *testing.T) {
+	instance := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "opentelemetry",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: "receivers: {prometheus: {}}",
+		},
+	}
+	configMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-collector", Namespace: "opentelemetry"},
+			Data:       data,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		objs    []client.Object
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "rendered by the operator",
+			objs: []client.Object{configMap(map[string]string{
+				"collector.yaml": "receivers: {prometheus: {target_allocator: {}}}",
+			})},
+			want: "receivers: {prometheus: {target_allocator: {}}}",
+		},
+		{
+			name: "not rendered yet",
+			want: "receivers: {prometheus: {}}",
+		},
+		{
+			name:    "configmap without configuration",
+			objs:    []client.Object{configMap(map[string]string{})},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(clientLogger, getFakeClient(t, tt.objs...), nil, nil)
+			got, err := c.GetEffectiveConfig(instance)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestClient_GetCollectorStatus(t *testing.T) {
+	instance := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "opentelemetry",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Image: "otel/opentelemetry-collector:0.68.0",
+		},
+		Status: v1alpha1.OpenTelemetryCollectorStatus{
+			Version: "0.68.0",
+		},
+	}
+	pod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "opentelemetry",
+				Labels: map[string]string{
+					"app.kubernetes.io/instance":   "opentelemetry.test",
+					"app.kubernetes.io/component":  "opentelemetry-collector",
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "otc-container", Image: "otel/opentelemetry-collector:0.69.0"}},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	t.Run("without pods", func(t *testing.T) {
+		fakeClient := getFakeClient(t)
+		require.NoError(t, fakeClient.Create(context.Background(), instance.DeepCopy()))
+		c := NewClient(clientLogger, fakeClient, nil, nil)
+
+		status, err := c.GetCollectorStatus(*instance)
+		require.NoError(t, err)
+		require.NotNil(t, status.Health)
+		status.Health = nil
+		assert.Equal(t, &CollectorStatus{
+			Image:           "otel/opentelemetry-collector:0.68.0",
+			Version:         "0.68.0",
+			ReconcileResult: ReconcileResultUnknown,
+		}, status)
+	})
+
+	t.Run("with pods", func(t *testing.T) {
+		fakeClient := getFakeClient(t, pod("a", corev1.ConditionTrue), pod("b", corev1.ConditionFalse))
+		require.NoError(t, fakeClient.Create(context.Background(), instance.DeepCopy()))
+		c := NewClient(clientLogger, fakeClient, nil, nil)
+
+		status, err := c.GetCollectorStatus(*instance)
+		require.NoError(t, err)
+		require.NotNil(t, status.Health)
+		status.Health = nil
+		assert.Equal(t, &CollectorStatus{
+			Pods:            2,
+			ReadyPods:       1,
+			Image:           "otel/opentelemetry-collector:0.69.0",
+			Version:         "0.68.0",
+			ReconcileResult: ReconcileResultUnknown,
+		}, status)
+	})
+}
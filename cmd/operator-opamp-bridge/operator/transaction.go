@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+var (
+	// ErrNotApplied is the error of the valid changes of a transaction which weren't applied because another change
+	// failed its validation.
+	ErrNotApplied = errors.New("not applied: another change of the transaction is invalid")
+	// ErrRolledBack is the error of the changes of a transaction which were applied and then rolled back because
+	// another change failed.
+	ErrRolledBack = errors.New("rolled back: another change of the transaction failed")
+	// ErrRollbackFailed is the error of the changes of a transaction which were applied and couldn't be rolled back
+	// when another change failed.
+	ErrRollbackFailed = errors.New("applied but couldn't be rolled back")
+)
+
+var (
+	// recreateInterval is how often a deleted resource is checked before it's created again.
+	recreateInterval = time.Second
+	// recreateTimeout is how long the deletion of a resource is waited for before it's created again.
+	recreateTimeout = 30 * time.Second
+)
+
+// Change is a change of a resource managed by the bridge, of kind CollectorResource or InstrumentationResource. The
+// resource is deleted if Config is nil, otherwise the spec contained in Config is applied.
+type Change struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Config    *protobufs.AgentConfigFile
+}
+
+// ApplyTransaction applies all the changes or none of them, and returns the error of every change, nil if it was
+// applied. The changes are first validated with a dry run, which goes through the admission webhooks of the cluster, so
+// nothing is written if any of them is invalid. The deletions are written after the other changes, so that they only
+// need to be rolled back when another deletion fails. If a change still fails when it's written, the changes already
+// written are rolled back in reverse order: the previous spec of an updated resource is restored, a created resource
+// is deleted and a deleted resource is created again once it's gone.
+func (c Client) ApplyTransaction(changes []Change) []error {
+	errs := make([]error, len(changes))
+	dryRun := c.dryRun()
+	valid := true
+	for i, change := range changes {
+		errs[i] = dryRun.applyChange(change)
+		valid = valid && errs[i] == nil
+	}
+	if !valid {
+		for i := range errs {
+			if errs[i] == nil {
+				errs[i] = ErrNotApplied
+			}
+		}
+		return errs
+	}
+
+	previous := make([]client.Object, len(changes))
+	for i, change := range changes {
+		var err error
+		previous[i], err = c.getResource(change)
+		if err != nil {
+			errs[i] = err
+			for j := range errs {
+				if errs[j] == nil {
+					errs[j] = ErrNotApplied
+				}
+			}
+			return errs
+		}
+	}
+
+	order := applyOrder(changes)
+	for k, i := range order {
+		change := changes[i]
+		if err := c.applyChange(change); err != nil {
+			errs[i] = err
+			c.log.Info("Rolling back the transaction", "name", change.Name, "namespace", change.Namespace, "error", err.Error())
+			for l := k - 1; l >= 0; l-- {
+				j := order[l]
+				errs[j] = ErrRolledBack
+				if rollbackErr := c.rollback(changes[j], previous[j]); rollbackErr != nil {
+					errs[j] = fmt.Errorf("%w: %v", ErrRollbackFailed, rollbackErr)
+				}
+			}
+			for _, j := range order[k+1:] {
+				errs[j] = ErrNotApplied
+			}
+			return errs
+		}
+	}
+	return errs
+}
+
+// applyOrder returns the indexes of the changes in the order they're applied: the deletions come last, otherwise the
+// order of the changes is kept.
+func applyOrder(changes []Change) []int {
+	order := make([]int, 0, len(changes))
+	for i, change := range changes {
+		if change.Config != nil {
+			order = append(order, i)
+		}
+	}
+	for i, change := range changes {
+		if change.Config == nil {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// dryRun returns a copy of the client which validates the changes against the cluster without persisting them.
+func (c Client) dryRun() Client {
+	c.log = c.log.WithValues("dryRun", true)
+	c.k8sClient = client.NewDryRunClient(c.k8sClient)
+	return c
+}
+
+func (c Client) applyChange(change Change) error {
+	switch {
+	case change.Kind == InstrumentationResource && change.Config == nil:
+		return c.DeleteInstrumentation(change.Name, change.Namespace)
+	case change.Kind == InstrumentationResource:
+		return c.ApplyInstrumentation(change.Name, change.Namespace, change.Config)
+	case change.Config == nil:
+		return c.Delete(change.Name, change.Namespace)
+	default:
+		return c.Apply(change.Name, change.Namespace, change.Config)
+	}
+}
+
+// getResource retrieves the resource of a change, nil if it doesn't exist.
+func (c Client) getResource(change Change) (client.Object, error) {
+	if change.Kind == InstrumentationResource {
+		instrumentation, err := c.GetInstrumentation(change.Name, change.Namespace)
+		if instrumentation == nil {
+			return nil, err
+		}
+		return instrumentation, nil
+	}
+	collector, err := c.GetInstance(change.Name, change.Namespace)
+	if collector == nil {
+		return nil, err
+	}
+	return collector, nil
+}
+
+// rollback restores the resource of an applied change to its previous state, nil if it didn't exist. The labels are
+// restored along with the spec, so that an adopted resource isn't owned by the bridge after the rollback.
+func (c Client) rollback(change Change, previous client.Object) error {
+	ctx := context.Background()
+	if previous == nil {
+		if change.Config == nil {
+			return nil
+		}
+		if change.Kind == InstrumentationResource {
+			return c.DeleteInstrumentation(change.Name, change.Namespace)
+		}
+		return c.Delete(change.Name, change.Namespace)
+	}
+	if change.Config == nil {
+		return c.recreate(ctx, previous)
+	}
+
+	switch previousResource := previous.(type) {
+	case *v1alpha1.Instrumentation:
+		current, err := c.GetInstrumentation(change.Name, change.Namespace)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return c.recreate(ctx, previous)
+		}
+		current.Spec = previousResource.Spec
+		current.Labels = previousResource.Labels
+		return c.k8sClient.Update(ctx, current)
+	case *v1alpha1.OpenTelemetryCollector:
+		current, err := c.GetInstance(change.Name, change.Namespace)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return c.recreate(ctx, previous)
+		}
+		current.Spec = previousResource.Spec
+		current.Labels = previousResource.Labels
+		return c.k8sClient.Update(ctx, current)
+	}
+	return fmt.Errorf("unsupported resource %T", previous)
+}
+
+// recreate creates a resource again from its previous state. A deleted resource isn't gone until its finalizers are
+// done, so its deletion is waited for first.
+func (c Client) recreate(ctx context.Context, previous client.Object) error {
+	key := client.ObjectKeyFromObject(previous)
+	err := wait.PollImmediate(recreateInterval, recreateTimeout, func() (bool, error) {
+		current := previous.DeepCopyObject().(client.Object)
+		err := c.k8sClient.Get(ctx, key, current)
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		// a resource which isn't being deleted won't go away, creating it fails right away
+		return current.GetDeletionTimestamp() == nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for the deletion of %s: %w", key, err)
+	}
+	previous.SetResourceVersion("")
+	previous.SetUID("")
+	return c.k8sClient.Create(ctx, previous)
+}
@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// failingClient fails to write the resources with the given name, unless it's a dry run. If failAll is set, every
+// write fails after the first failure. The names of the resources written are recorded in writes.
+type failingClient struct {
+	client.WithWatch
+	name    string
+	failAll bool
+	failed  bool
+	writes  []string
+}
+
+func (c *failingClient) fail(obj client.Object, dryRun []string) bool {
+	if len(dryRun) > 0 {
+		return false
+	}
+	if obj.GetName() == c.name || (c.failAll && c.failed) {
+		c.failed = true
+		return true
+	}
+	c.writes = append(c.writes, obj.GetName())
+	return false
+}
+
+func (c *failingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	createOpts := &client.CreateOptions{}
+	createOpts.ApplyOptions(opts)
+	if c.fail(obj, createOpts.DryRun) {
+		return errors.New("create failed")
+	}
+	return c.WithWatch.Create(ctx, obj, opts...)
+}
+
+func (c *failingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	updateOpts := &client.UpdateOptions{}
+	updateOpts.ApplyOptions(opts)
+	if c.fail(obj, updateOpts.DryRun) {
+		return errors.New("update failed")
+	}
+	return c.WithWatch.Update(ctx, obj, opts...)
+}
+
+func (c *failingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	deleteOpts := &client.DeleteOptions{}
+	deleteOpts.ApplyOptions(opts)
+	if c.fail(obj, deleteOpts.DryRun) {
+		return errors.New("delete failed")
+	}
+	return c.WithWatch.Delete(ctx, obj, opts...)
+}
+
+func TestClient_ApplyTransaction(t *testing.T) {
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	updatedColConfig, err := loadConfig("testdata/updated-collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	instConfig, err := loadConfig("testdata/instrumentation.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	collector := &protobufs.AgentConfigFile{Body: colConfig, ContentType: "yaml"}
+	updatedCollector := &protobufs.AgentConfigFile{Body: updatedColConfig, ContentType: "yaml"}
+	instrumentation := &protobufs.AgentConfigFile{Body: instConfig, ContentType: "yaml"}
+
+	// setup creates the existing and the deleted collectors
+	setup := func(t *testing.T, c *Client) {
+		require.NoError(t, c.Apply("existing", "testing", collector))
+		require.NoError(t, c.Apply("deleted", "testing", collector))
+	}
+	changes := func(last Change) []Change {
+		return []Change{
+			{Kind: CollectorResource, Name: "existing", Namespace: "testing", Config: updatedCollector},
+			{Kind: CollectorResource, Name: "created", Namespace: "testing", Config: collector},
+			{Kind: InstrumentationResource, Name: "java", Namespace: "testing", Config: instrumentation},
+			{Kind: CollectorResource, Name: "deleted", Namespace: "testing"},
+			last,
+		}
+	}
+
+	t.Run("all applied", func(t *testing.T) {
+		c := NewClient(clientLogger, getFakeClient(t), nil, nil)
+		setup(t, c)
+
+		errs := c.ApplyTransaction(changes(Change{Kind: CollectorResource, Name: "last", Namespace: "testing", Config: collector}))
+		assert.Equal(t, make([]error, 5), errs)
+
+		existing, err := c.GetInstance("existing", "testing")
+		require.NoError(t, err)
+		assert.Contains(t, existing.Spec.Config, "processors: [memory_limiter, batch]")
+		instances, err := c.ListInstances()
+		require.NoError(t, err)
+		assert.Len(t, instances, 3)
+		deleted, err := c.GetInstance("deleted", "testing")
+		require.NoError(t, err)
+		assert.Nil(t, deleted)
+		java, err := c.GetInstrumentation("java", "testing")
+		require.NoError(t, err)
+		assert.NotNil(t, java)
+	})
+
+	t.Run("invalid change", func(t *testing.T) {
+		c := NewClient(clientLogger, getFakeClient(t), nil, nil)
+		setup(t, c)
+
+		errs := c.ApplyTransaction(changes(Change{Kind: CollectorResource, Name: "last", Namespace: "testing", Config: &protobufs.AgentConfigFile{Body: []byte("empty, invalid!")}}))
+		require.Len(t, errs, 5)
+		for _, err := range errs[:4] {
+			assert.ErrorIs(t, err, ErrNotApplied)
+		}
+		assert.Error(t, errs[4])
+		assert.NotErrorIs(t, errs[4], ErrNotApplied)
+
+		existing, err := c.GetInstance("existing", "testing")
+		require.NoError(t, err)
+		assert.Contains(t, existing.Spec.Config, "processors: []")
+		instances, err := c.ListInstances()
+		require.NoError(t, err)
+		assert.Len(t, instances, 2)
+		instrumentations, err := c.ListInstrumentations()
+		require.NoError(t, err)
+		assert.Empty(t, instrumentations)
+	})
+
+	t.Run("rolled back", func(t *testing.T) {
+		fakeClient := getFakeClient(t)
+		c := NewClient(clientLogger, fakeClient, nil, nil)
+		setup(t, c)
+		c = NewClient(clientLogger, &failingClient{WithWatch: fakeClient, name: "failing"}, nil, nil)
+
+		errs := c.ApplyTransaction(changes(Change{Kind: CollectorResource, Name: "failing", Namespace: "testing", Config: collector}))
+		require.Len(t, errs, 5)
+		for _, err := range errs[:3] {
+			assert.ErrorIs(t, err, ErrRolledBack)
+		}
+		assert.ErrorIs(t, errs[3], ErrNotApplied, "the deletion should come last")
+		assert.EqualError(t, errs[4], "create failed")
+
+		existing, err := c.GetInstance("existing", "testing")
+		require.NoError(t, err)
+		assert.Contains(t, existing.Spec.Config, "processors: []")
+		deleted, err := c.GetInstance("deleted", "testing")
+		require.NoError(t, err)
+		assert.NotNil(t, deleted, "the deleted collector should not be deleted")
+		instances, err := c.ListInstances()
+		require.NoError(t, err)
+		assert.Len(t, instances, 2)
+		instrumentations, err := c.ListInstrumentations()
+		require.NoError(t, err)
+		assert.Empty(t, instrumentations)
+	})
+
+	t.Run("rolled back in reverse order", func(t *testing.T) {
+		fakeClient := getFakeClient(t)
+		c := NewClient(clientLogger, fakeClient, nil, nil)
+		setup(t, c)
+		failing := &failingClient{WithWatch: fakeClient, name: "failing"}
+		c = NewClient(clientLogger, failing, nil, nil)
+
+		errs := c.ApplyTransaction([]Change{
+			{Kind: CollectorResource, Name: "existing", Namespace: "testing", Config: updatedCollector},
+			{Kind: CollectorResource, Name: "created", Namespace: "testing", Config: collector},
+			{Kind: CollectorResource, Name: "failing", Namespace: "testing", Config: collector},
+		})
+		require.Len(t, errs, 3)
+		assert.ErrorIs(t, errs[0], ErrRolledBack)
+		assert.ErrorIs(t, errs[1], ErrRolledBack)
+		assert.Equal(t, []string{"existing", "created", "created", "existing"}, failing.writes)
+	})
+
+	t.Run("deletion rolled back once gone", func(t *testing.T) {
+		recreateInterval = 10 * time.Millisecond
+		defer func() { recreateInterval = time.Second }()
+		fakeClient := getFakeClient(t)
+		c := NewClient(clientLogger, fakeClient, nil, nil)
+		setup(t, c)
+		require.NoError(t, c.Apply("failing", "testing", collector))
+		deleted, err := c.GetInstance("deleted", "testing")
+		require.NoError(t, err)
+		deleted.Finalizers = []string{"opentelemetrycollector.opentelemetry.io/finalizer"}
+		require.NoError(t, fakeClient.Update(context.Background(), deleted))
+		c = NewClient(clientLogger, &failingClient{WithWatch: fakeClient, name: "failing"}, nil, nil)
+
+		// the finalizer is done while the rollback waits for the deletion
+		go func() {
+			require.Eventually(t, func() bool {
+				current, err := c.GetInstance("deleted", "testing")
+				return err == nil && current != nil && current.DeletionTimestamp != nil
+			}, time.Second, 10*time.Millisecond)
+			current, err := c.GetInstance("deleted", "testing")
+			require.NoError(t, err)
+			current.Finalizers = nil
+			require.NoError(t, fakeClient.Update(context.Background(), current))
+		}()
+
+		errs := c.ApplyTransaction([]Change{
+			{Kind: CollectorResource, Name: "deleted", Namespace: "testing"},
+			{Kind: CollectorResource, Name: "failing", Namespace: "testing"},
+		})
+		require.Len(t, errs, 2)
+		assert.ErrorIs(t, errs[0], ErrRolledBack)
+		assert.EqualError(t, errs[1], "delete failed")
+
+		deleted, err = c.GetInstance("deleted", "testing")
+		require.NoError(t, err)
+		require.NotNil(t, deleted, "the deleted collector should be created again")
+		assert.Nil(t, deleted.DeletionTimestamp)
+	})
+
+	t.Run("rollback failed", func(t *testing.T) {
+		fakeClient := getFakeClient(t)
+		c := NewClient(clientLogger, fakeClient, nil, nil)
+		setup(t, c)
+		c = NewClient(clientLogger, &failingClient{WithWatch: fakeClient, name: "failing", failAll: true}, nil, nil)
+
+		errs := c.ApplyTransaction([]Change{
+			{Kind: CollectorResource, Name: "existing", Namespace: "testing", Config: updatedCollector},
+			{Kind: CollectorResource, Name: "failing", Namespace: "testing", Config: collector},
+		})
+		require.Len(t, errs, 2)
+		assert.ErrorIs(t, errs[0], ErrRollbackFailed)
+		assert.ErrorContains(t, errs[0], "update failed")
+		assert.EqualError(t, errs[1], "create failed")
+	})
+}
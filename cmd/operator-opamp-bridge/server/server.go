@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package server serves the health, readiness, metrics and debug endpoints of the bridge.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/agent"
+)
+
+// StatusProvider exposes the state of the agent.
+type StatusProvider interface {
+	Ready() bool
+	Status() agent.Status
+}
+
+type Server struct {
+	logger logr.Logger
+	status StatusProvider
+	server *http.Server
+}
+
+func NewServer(log logr.Logger, status StatusProvider, listenAddr string) *Server {
+	s := &Server{
+		logger: log,
+		status: status,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.HealthHandler)
+	mux.HandleFunc("/readyz", s.ReadyHandler)
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/debug/status", s.StatusHandler)
+
+	s.server = &http.Server{Addr: listenAddr, Handler: mux, ReadHeaderTimeout: 90 * time.Second}
+	return s
+}
+
+func (s *Server) Start() error {
+	s.logger.Info("Starting server...")
+	return s.server.ListenAndServe()
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.logger.Info("Shutting down server...")
+	return s.server.Shutdown(ctx)
+}
+
+// HealthHandler responds as long as the bridge is running.
+func (s *Server) HealthHandler(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte("ok"))
+}
+
+// ReadyHandler responds with 503 until the agent is started.
+func (s *Server) ReadyHandler(w http.ResponseWriter, _ *http.Request) {
+	if !s.status.Ready() {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		return
+	}
+	_, _ = w.Write([]byte("ok"))
+}
+
+// StatusHandler returns the connection state of the agent, the hash of the last remote configuration and the result
+// of applying each of its entries.
+func (s *Server) StatusHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.status.Status()); err != nil {
+		s.logger.Error(err, "failed to encode the status")
+	}
+}
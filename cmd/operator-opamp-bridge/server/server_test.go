@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/agent"
+)
+
+var logger = logf.Log.WithName("server-unit-tests")
+
+type mockStatusProvider struct {
+	ready  bool
+	status agent.Status
+}
+
+func (m *mockStatusProvider) Ready() bool {
+	return m.ready
+}
+
+func (m *mockStatusProvider) Status() agent.Status {
+	return m.status
+}
+
+func TestServer_Endpoints(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		ready      bool
+		wantStatus int
+	}{
+		{name: "healthy", path: "/healthz", wantStatus: http.StatusOK},
+		{name: "not ready", path: "/readyz", wantStatus: http.StatusServiceUnavailable},
+		{name: "ready", path: "/readyz", ready: true, wantStatus: http.StatusOK},
+		{name: "metrics", path: "/metrics", wantStatus: http.StatusOK},
+		{name: "status", path: "/debug/status", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer(logger, &mockStatusProvider{ready: tt.ready}, ":0")
+			request := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+
+			s.server.Handler.ServeHTTP(w, request)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestServer_StatusHandler(t *testing.T) {
+	status := agent.Status{
+		InstanceUID:          "01GQ4H3Z0J1Z5QZ0Y8Y6W3D6EN",
+		Connected:            true,
+		LastRemoteConfigHash: "abcd",
+		Configs: map[string]agent.ApplyResult{
+			"good/testnamespace": {Applied: true},
+			"bad/testnamespace":  {Error: "invalid config"},
+		},
+	}
+	s := NewServer(logger, &mockStatusProvider{status: status}, ":0")
+	request := httptest.NewRequest("GET", "/debug/status", nil)
+	w := httptest.NewRecorder()
+
+	s.server.Handler.ServeHTTP(w, request)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var got agent.Status
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, status, got)
+}
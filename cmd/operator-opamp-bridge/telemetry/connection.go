@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	otelresource "go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// Protocol is the OTLP protocol used to send the own telemetry of the bridge. The values are the ones of the
+// OTEL_EXPORTER_OTLP_PROTOCOL environment variable.
+type Protocol string
+
+const (
+	ProtocolHTTP Protocol = "http/protobuf"
+	ProtocolGRPC Protocol = "grpc"
+)
+
+// Connection is an OTLP destination parsed from the connection settings sent by the OpAMP server.
+type Connection struct {
+	Protocol Protocol
+	// Endpoint is the host and port of the destination.
+	Endpoint string
+	// URLPath is the path of an OTLP/HTTP destination, it's empty for gRPC.
+	URLPath string
+	Headers map[string]string
+	// TLSConfig is nil when the connection isn't secured by TLS.
+	TLSConfig *tls.Config
+}
+
+// ParseConnectionSettings parses the own telemetry connection settings sent by the OpAMP server. The OpAMP server only
+// supplies the URL of the destination, so the protocol is configured on the bridge. The https connections are secured
+// by TLS, using the certificate of the settings if there is one. The path of the URL is ignored for gRPC.
+func ParseConnectionSettings(settings *protobufs.TelemetryConnectionSettings, protocol Protocol) (*Connection, error) {
+	if settings.GetDestinationEndpoint() == "" {
+		return nil, errors.New("destination must specify DestinationEndpoint")
+	}
+	u, err := url.Parse(settings.GetDestinationEndpoint())
+	if err != nil {
+		return nil, fmt.Errorf("invalid DestinationEndpoint: %w", err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid DestinationEndpoint %s: missing host", settings.GetDestinationEndpoint())
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported DestinationEndpoint scheme %q, must be http or https", u.Scheme)
+	}
+	secure := u.Scheme == "https"
+
+	conn := &Connection{
+		Protocol: protocol,
+		Endpoint: u.Host,
+		Headers:  map[string]string{},
+	}
+	switch protocol {
+	case ProtocolHTTP:
+		conn.URLPath = u.Path
+	case ProtocolGRPC:
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q, must be %s or %s", protocol, ProtocolHTTP, ProtocolGRPC)
+	}
+
+	for _, header := range settings.GetHeaders().GetHeaders() {
+		conn.Headers[header.GetKey()] = header.GetValue()
+	}
+
+	if settings.GetCertificate() != nil && !secure {
+		return nil, fmt.Errorf("a certificate can't be used with the insecure scheme %s", u.Scheme)
+	}
+	if secure {
+		conn.TLSConfig, err = getTLSConfig(settings.GetCertificate())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// getTLSConfig creates the TLS configuration of a connection, trusting the CA of the certificate in addition to the
+// system pool and presenting the certificate to the destination if it has a key pair.
+func getTLSConfig(certificate *protobufs.TLSCertificate) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if certificate == nil {
+		return tlsConfig, nil
+	}
+	if len(certificate.GetCaPublicKey()) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(certificate.GetCaPublicKey()) {
+			return nil, errors.New("invalid CA certificate: no certificate found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(certificate.GetPublicKey()) > 0 || len(certificate.GetPrivateKey()) > 0 {
+		cert, err := tls.X509KeyPair(certificate.GetPublicKey(), certificate.GetPrivateKey())
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// NewResource creates the resource describing the bridge in its own telemetry. Use OpenTelemetry semantic conventions
+// as the OpAMP spec requires:
+// https://github.com/open-telemetry/opamp-spec/blob/main/specification.md#own-telemetry-reporting
+func NewResource(agentType string, agentVersion string, instanceId ulid.ULID) (*otelresource.Resource, error) {
+	return otelresource.New(context.Background(),
+		otelresource.WithAttributes(
+			semconv.ServiceNameKey.String(agentType),
+			semconv.ServiceVersionKey.String(agentVersion),
+			semconv.ServiceInstanceIDKey.String(instanceId.String()),
+		),
+	)
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConnectionSettings(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t)
+	headers := &protobufs.Headers{Headers: []*protobufs.Header{{Key: "Authorization", Value: "Bearer token"}}}
+
+	tests := []struct {
+		name      string
+		settings  *protobufs.TelemetryConnectionSettings
+		protocol  Protocol
+		want      *Connection
+		wantTLS   bool
+		wantCerts int
+		wantErr   string
+	}{
+		{
+			name:     "http",
+			protocol: ProtocolHTTP,
+			settings: &protobufs.TelemetryConnectionSettings{DestinationEndpoint: "http://collector:4318/v1/metrics", Headers: headers},
+			want: &Connection{
+				Protocol: ProtocolHTTP,
+				Endpoint: "collector:4318",
+				URLPath:  "/v1/metrics",
+				Headers:  map[string]string{"Authorization": "Bearer token"},
+			},
+		},
+		{
+			name:     "https",
+			protocol: ProtocolHTTP,
+			settings: &protobufs.TelemetryConnectionSettings{DestinationEndpoint: "https://collector:4318"},
+			want: &Connection{
+				Protocol: ProtocolHTTP,
+				Endpoint: "collector:4318",
+				Headers:  map[string]string{},
+			},
+			wantTLS: true,
+		},
+		{
+			name:     "grpc",
+			protocol: ProtocolGRPC,
+			settings: &protobufs.TelemetryConnectionSettings{DestinationEndpoint: "http://collector:4317/ignored", Headers: headers},
+			want: &Connection{
+				Protocol: ProtocolGRPC,
+				Endpoint: "collector:4317",
+				Headers:  map[string]string{"Authorization": "Bearer token"},
+			},
+		},
+		{
+			name:     "grpc with certificate",
+			protocol: ProtocolGRPC,
+			settings: &protobufs.TelemetryConnectionSettings{
+				DestinationEndpoint: "https://collector:4317",
+				Certificate:         &protobufs.TLSCertificate{PublicKey: certPEM, PrivateKey: keyPEM, CaPublicKey: certPEM},
+			},
+			want: &Connection{
+				Protocol: ProtocolGRPC,
+				Endpoint: "collector:4317",
+				Headers:  map[string]string{},
+			},
+			wantTLS:   true,
+			wantCerts: 1,
+		},
+		{
+			name:     "missing endpoint",
+			settings: &protobufs.TelemetryConnectionSettings{},
+			wantErr:  "destination must specify DestinationEndpoint",
+		},
+		{
+			name:     "missing host",
+			settings: &protobufs.TelemetryConnectionSettings{DestinationEndpoint: "collector:4317"},
+			wantErr:  "missing host",
+		},
+		{
+			name:     "unsupported scheme",
+			settings: &protobufs.TelemetryConnectionSettings{DestinationEndpoint: "grpc://collector:4317"},
+			protocol: ProtocolGRPC,
+			wantErr:  "unsupported DestinationEndpoint scheme",
+		},
+		{
+			name:     "unsupported protocol",
+			settings: &protobufs.TelemetryConnectionSettings{DestinationEndpoint: "http://collector:4318"},
+			protocol: "http/json",
+			wantErr:  "unsupported protocol",
+		},
+		{
+			name:     "certificate with insecure scheme",
+			protocol: ProtocolHTTP,
+			settings: &protobufs.TelemetryConnectionSettings{
+				DestinationEndpoint: "http://collector:4318",
+				Certificate:         &protobufs.TLSCertificate{CaPublicKey: certPEM},
+			},
+			wantErr: "can't be used with the insecure scheme http",
+		},
+		{
+			name:     "invalid CA",
+			protocol: ProtocolHTTP,
+			settings: &protobufs.TelemetryConnectionSettings{
+				DestinationEndpoint: "https://collector:4318",
+				Certificate:         &protobufs.TLSCertificate{CaPublicKey: keyPEM},
+			},
+			wantErr: "invalid CA certificate",
+		},
+		{
+			name:     "mismatched key pair",
+			protocol: ProtocolHTTP,
+			settings: &protobufs.TelemetryConnectionSettings{
+				DestinationEndpoint: "https://collector:4318",
+				Certificate:         &protobufs.TLSCertificate{PublicKey: certPEM},
+			},
+			wantErr: "invalid client certificate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseConnectionSettings(tt.settings, tt.protocol)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantTLS {
+				require.NotNil(t, got.TLSConfig)
+				assert.Len(t, got.TLSConfig.Certificates, tt.wantCerts)
+			} else {
+				assert.Nil(t, got.TLSConfig)
+			}
+			got.TLSConfig = nil
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// generateCertificate generates a self-signed certificate and its key.
+func generateCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "operator-opamp-bridge"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.opentelemetry.io/otel"
+	otelresource "go.opentelemetry.io/otel/sdk/resource"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/logger"
+)
+
+const (
+	defaultLogsURLPath = "/v1/logs"
+	// logQueueSize is the number of records buffered before the new ones are dropped.
+	logQueueSize      = 2048
+	logBatchSize      = 512
+	logExportInterval = 5 * time.Second
+	logExportTimeout  = 10 * time.Second
+	scopeName         = "github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge"
+)
+
+var _ logger.Exporter = &LogExporter{}
+
+// LogExporter sends the log entries of the bridge to an OTLP destination in batches. Exporting a record never blocks:
+// the records are dropped when the queue is full. The errors of the exports are passed to the global OpenTelemetry
+// error handler, as logging them would export them again.
+//
+// Unlike the traces and metrics, which go through the SDK exporters like in the target allocator, the logs are
+// encoded and batched here: the OpenTelemetry Go SDK has no logs signal nor OTLP logs exporter yet. This should be
+// replaced by the SDK exporter once there is one.
+type LogExporter struct {
+	conn     *Connection
+	resource *resourcepb.Resource
+
+	httpClient *http.Client
+	grpcConn   *grpc.ClientConn
+	logsClient collogspb.LogsServiceClient
+
+	records chan *logspb.LogRecord
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewLogExporter creates a log exporter to the destination supplied by the server, over OTLP/HTTP or OTLP/gRPC
+// depending on the protocol, and starts exporting the records in the background.
+func NewLogExporter(settings *protobufs.TelemetryConnectionSettings, protocol Protocol, resource *otelresource.Resource) (*LogExporter, error) {
+	conn, err := ParseConnectionSettings(settings, protocol)
+	if err != nil {
+		return nil, err
+	}
+	exporter := &LogExporter{
+		conn:     conn,
+		resource: &resourcepb.Resource{},
+		records:  make(chan *logspb.LogRecord, logQueueSize),
+		done:     make(chan struct{}),
+	}
+	for _, attr := range resource.Attributes() {
+		exporter.resource.Attributes = append(exporter.resource.Attributes, keyValue(string(attr.Key), attr.Value.AsInterface()))
+	}
+
+	if conn.Protocol == ProtocolGRPC {
+		creds := insecure.NewCredentials()
+		if conn.TLSConfig != nil {
+			creds = credentials.NewTLS(conn.TLSConfig)
+		}
+		exporter.grpcConn, err = grpc.Dial(conn.Endpoint, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize otlp log grpc client: %w", err)
+		}
+		exporter.logsClient = collogspb.NewLogsServiceClient(exporter.grpcConn)
+	} else {
+		exporter.httpClient = &http.Client{
+			Timeout:   logExportTimeout,
+			Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: conn.TLSConfig},
+		}
+	}
+
+	exporter.wg.Add(1)
+	go exporter.run()
+	return exporter, nil
+}
+
+// Export queues the record for the next batch.
+func (e *LogExporter) Export(record logger.Record) {
+	logRecord := &logspb.LogRecord{
+		TimeUnixNano:         uint64(record.Time.UnixNano()),
+		ObservedTimeUnixNano: uint64(record.Time.UnixNano()),
+		Body:                 &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: record.Message}},
+	}
+	switch {
+	case record.IsError:
+		logRecord.SeverityNumber = logspb.SeverityNumber_SEVERITY_NUMBER_ERROR
+		logRecord.SeverityText = "ERROR"
+	case record.Level > 0:
+		logRecord.SeverityNumber = logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG
+		logRecord.SeverityText = "DEBUG"
+	default:
+		logRecord.SeverityNumber = logspb.SeverityNumber_SEVERITY_NUMBER_INFO
+		logRecord.SeverityText = "INFO"
+	}
+	if record.Name != "" {
+		logRecord.Attributes = append(logRecord.Attributes, keyValue("logger", record.Name))
+	}
+	if record.Err != nil {
+		logRecord.Attributes = append(logRecord.Attributes, keyValue("error", record.Err.Error()))
+	}
+	for i := 0; i+1 < len(record.KeysAndValues); i += 2 {
+		logRecord.Attributes = append(logRecord.Attributes, keyValue(fmt.Sprint(record.KeysAndValues[i]), record.KeysAndValues[i+1]))
+	}
+
+	select {
+	case e.records <- logRecord:
+	default:
+	}
+}
+
+// Shutdown exports the queued records and closes the connection to the destination.
+func (e *LogExporter) Shutdown() {
+	close(e.done)
+	e.wg.Wait()
+	if e.grpcConn != nil {
+		_ = e.grpcConn.Close()
+	}
+}
+
+// run exports the queued records every logExportInterval or as soon as a batch is full, until the exporter is shut
+// down.
+func (e *LogExporter) run() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(logExportInterval)
+	defer ticker.Stop()
+	var batch []*logspb.LogRecord
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.export(batch); err != nil {
+			otel.Handle(err)
+		}
+		batch = nil
+	}
+	for {
+		select {
+		case record := <-e.records:
+			batch = append(batch, record)
+			if len(batch) >= logBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.done:
+			for {
+				select {
+				case record := <-e.records:
+					batch = append(batch, record)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *LogExporter) export(records []*logspb.LogRecord) error {
+	request := &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: e.resource,
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      &commonpb.InstrumentationScope{Name: scopeName},
+				LogRecords: records,
+			}},
+		}},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), logExportTimeout)
+	defer cancel()
+
+	if e.logsClient != nil {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(e.conn.Headers))
+		if _, err := e.logsClient.Export(ctx, request); err != nil {
+			return fmt.Errorf("failed to export logs: %w", err)
+		}
+		return nil
+	}
+
+	body, err := proto.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal logs: %w", err)
+	}
+	u := url.URL{Scheme: "http", Host: e.conn.Endpoint, Path: e.conn.URLPath}
+	if e.conn.TLSConfig != nil {
+		u.Scheme = "https"
+	}
+	if u.Path == "" {
+		u.Path = defaultLogsURLPath
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create the logs request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for key, value := range e.conn.Headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export logs: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to export logs: %s", resp.Status)
+	}
+	return nil
+}
+
+// keyValue converts a logr key and value to an OTLP attribute, formatting the values of unsupported types.
+func keyValue(key string, value interface{}) *commonpb.KeyValue {
+	anyValue := &commonpb.AnyValue{}
+	switch v := value.(type) {
+	case string:
+		anyValue.Value = &commonpb.AnyValue_StringValue{StringValue: v}
+	case bool:
+		anyValue.Value = &commonpb.AnyValue_BoolValue{BoolValue: v}
+	case int:
+		anyValue.Value = &commonpb.AnyValue_IntValue{IntValue: int64(v)}
+	case int32:
+		anyValue.Value = &commonpb.AnyValue_IntValue{IntValue: int64(v)}
+	case int64:
+		anyValue.Value = &commonpb.AnyValue_IntValue{IntValue: v}
+	case float64:
+		anyValue.Value = &commonpb.AnyValue_DoubleValue{DoubleValue: v}
+	case error:
+		anyValue.Value = &commonpb.AnyValue_StringValue{StringValue: v.Error()}
+	default:
+		anyValue.Value = &commonpb.AnyValue_StringValue{StringValue: fmt.Sprint(v)}
+	}
+	return &commonpb.KeyValue{Key: key, Value: anyValue}
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/logger"
+)
+
+func TestLogExporter_Export(t *testing.T) {
+	var lock sync.Mutex
+	var requests []*collogspb.ExportLogsServiceRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/logs", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		request := &collogspb.ExportLogsServiceRequest{}
+		require.NoError(t, proto.Unmarshal(body, request))
+		lock.Lock()
+		defer lock.Unlock()
+		requests = append(requests, request)
+	}))
+	defer srv.Close()
+
+	resource, err := NewResource("io.opentelemetry.operator-opamp-bridge", "0.1.0", ulid.Make())
+	require.NoError(t, err)
+	exporter, err := NewLogExporter(&protobufs.TelemetryConnectionSettings{
+		DestinationEndpoint: srv.URL,
+		Headers:             &protobufs.Headers{Headers: []*protobufs.Header{{Key: "Authorization", Value: "Bearer token"}}},
+	}, ProtocolHTTP, resource)
+	require.NoError(t, err)
+
+	now := time.Now()
+	exporter.Export(logger.Record{Time: now, Name: "agent", Message: "Agent started", KeysAndValues: []interface{}{"collectors", 2}})
+	exporter.Export(logger.Record{Time: now, Level: 4, Message: "Starting OpAMP client..."})
+	exporter.Export(logger.Record{Time: now, IsError: true, Err: errors.New("boom"), Message: "Cannot apply"})
+	exporter.Shutdown()
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, requests, 1)
+	require.Len(t, requests[0].ResourceLogs, 1)
+	resourceLogs := requests[0].ResourceLogs[0]
+	assert.Len(t, resourceLogs.Resource.Attributes, 3)
+	records := resourceLogs.ScopeLogs[0].LogRecords
+	require.Len(t, records, 3)
+
+	assert.Equal(t, "Agent started", records[0].Body.GetStringValue())
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_INFO, records[0].SeverityNumber)
+	assert.Equal(t, uint64(now.UnixNano()), records[0].TimeUnixNano)
+	require.Len(t, records[0].Attributes, 2)
+	assert.Equal(t, "logger", records[0].Attributes[0].Key)
+	assert.Equal(t, "agent", records[0].Attributes[0].Value.GetStringValue())
+	assert.Equal(t, "collectors", records[0].Attributes[1].Key)
+	assert.Equal(t, int64(2), records[0].Attributes[1].Value.GetIntValue())
+
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG, records[1].SeverityNumber)
+
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_ERROR, records[2].SeverityNumber)
+	require.Len(t, records[2].Attributes, 1)
+	assert.Equal(t, "error", records[2].Attributes[0].Key)
+	assert.Equal(t, "boom", records[2].Attributes[0].Value.GetStringValue())
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	otelresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+// NewTracerProvider creates a tracer provider sending the spans of the bridge to the destination supplied by the
+// server, over OTLP/HTTP or OTLP/gRPC depending on the protocol.
+func NewTracerProvider(settings *protobufs.TelemetryConnectionSettings, protocol Protocol, resource *otelresource.Resource) (*sdktrace.TracerProvider, error) {
+	conn, err := ParseConnectionSettings(settings, protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	var exporter *otlptrace.Exporter
+	if conn.Protocol == ProtocolGRPC {
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(conn.Endpoint),
+			otlptracegrpc.WithHeaders(conn.Headers),
+		}
+		if conn.TLSConfig != nil {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(conn.TLSConfig)))
+		} else {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(context.Background(), opts...)
+	} else {
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(conn.Endpoint),
+			otlptracehttp.WithHeaders(conn.Headers),
+		}
+		if conn.URLPath != "" {
+			opts = append(opts, otlptracehttp.WithURLPath(conn.URLPath))
+		}
+		if conn.TLSConfig != nil {
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(conn.TLSConfig))
+		} else {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(context.Background(), opts...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize otlp trace %s client: %w", conn.Protocol, err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithResource(resource),
+		sdktrace.WithBatcher(exporter),
+	), nil
+}
@@ -15,6 +15,7 @@
 package allocation
 
 import (
+	"context"
 	"sync"
 
 	"github.com/buraksezer/consistent"
@@ -173,9 +174,10 @@ func (c *consistentHashingAllocator) handleCollectors(diff diff.Changes[*Collect
 // SetTargets accepts a list of targets that will be used to make
 // load balancing decisions. This method should be called when there are
 // new targets discovered or existing targets are shutdown.
-func (c *consistentHashingAllocator) SetTargets(targets map[string]*target.Item) {
+func (c *consistentHashingAllocator) SetTargets(ctx context.Context, targets map[string]*target.Item) {
 	timer := prometheus.NewTimer(TimeToAssign.WithLabelValues("SetTargets", consistentHashingStrategyName))
 	defer timer.ObserveDuration()
+	defer startSpan(ctx, "SetTargets", consistentHashingStrategyName, len(targets)).End()
 
 	if c.filter != nil {
 		targets = c.filter.Apply(targets)
@@ -199,9 +201,10 @@ func (c *consistentHashingAllocator) SetTargets(targets map[string]*target.Item)
 
 // SetCollectors sets the set of collectors with key=collectorName, value=Collector object.
 // This method is called when Collectors are added or removed.
-func (c *consistentHashingAllocator) SetCollectors(collectors map[string]*Collector) {
+func (c *consistentHashingAllocator) SetCollectors(ctx context.Context, collectors map[string]*Collector) {
 	timer := prometheus.NewTimer(TimeToAssign.WithLabelValues("SetCollectors", consistentHashingStrategyName))
 	defer timer.ObserveDuration()
+	defer startSpan(ctx, "SetCollectors", consistentHashingStrategyName, len(collectors)).End()
 
 	CollectorsAllocatable.WithLabelValues(consistentHashingStrategyName).Set(float64(len(collectors)))
 	if len(collectors) == 0 {
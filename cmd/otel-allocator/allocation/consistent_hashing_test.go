@@ -15,6 +15,7 @@
 package allocation
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,8 +24,8 @@ import (
 func TestCanSetSingleTarget(t *testing.T) {
 	cols := MakeNCollectors(3, 0)
 	c := newConsistentHashingAllocator(logger)
-	c.SetCollectors(cols)
-	c.SetTargets(MakeNNewTargets(1, 3, 0))
+	c.SetCollectors(context.Background(), cols)
+	c.SetTargets(context.Background(), MakeNNewTargets(1, 3, 0))
 	actualTargetItems := c.TargetItems()
 	assert.Len(t, actualTargetItems, 1)
 	for _, item := range actualTargetItems {
@@ -39,8 +40,8 @@ func TestRelativelyEvenDistribution(t *testing.T) {
 	var expectedPerCollector = float64(numItems / numCols)
 	expectedDelta := (expectedPerCollector * 1.5) - expectedPerCollector
 	c := newConsistentHashingAllocator(logger)
-	c.SetCollectors(cols)
-	c.SetTargets(MakeNNewTargets(numItems, 0, 0))
+	c.SetCollectors(context.Background(), cols)
+	c.SetTargets(context.Background(), MakeNNewTargets(numItems, 0, 0))
 	actualTargetItems := c.TargetItems()
 	assert.Len(t, actualTargetItems, numItems)
 	actualCollectors := c.Collectors()
@@ -53,14 +54,14 @@ func TestRelativelyEvenDistribution(t *testing.T) {
 func TestFullReallocation(t *testing.T) {
 	cols := MakeNCollectors(10, 0)
 	c := newConsistentHashingAllocator(logger)
-	c.SetCollectors(cols)
-	c.SetTargets(MakeNNewTargets(10000, 10, 0))
+	c.SetCollectors(context.Background(), cols)
+	c.SetTargets(context.Background(), MakeNNewTargets(10000, 10, 0))
 	actualTargetItems := c.TargetItems()
 	assert.Len(t, actualTargetItems, 10000)
 	actualCollectors := c.Collectors()
 	assert.Len(t, actualCollectors, 10)
 	newCols := MakeNCollectors(10, 10)
-	c.SetCollectors(newCols)
+	c.SetCollectors(context.Background(), newCols)
 	updatedTargetItems := c.TargetItems()
 	assert.Len(t, updatedTargetItems, 10000)
 	updatedCollectors := c.Collectors()
@@ -78,14 +79,14 @@ func TestNumRemapped(t *testing.T) {
 	expectedDelta := float64((numFinalCols - numInitialCols) * (numItems / numFinalCols))
 	cols := MakeNCollectors(numInitialCols, 0)
 	c := newConsistentHashingAllocator(logger)
-	c.SetCollectors(cols)
-	c.SetTargets(MakeNNewTargets(numItems, numInitialCols, 0))
+	c.SetCollectors(context.Background(), cols)
+	c.SetTargets(context.Background(), MakeNNewTargets(numItems, numInitialCols, 0))
 	actualTargetItems := c.TargetItems()
 	assert.Len(t, actualTargetItems, numItems)
 	actualCollectors := c.Collectors()
 	assert.Len(t, actualCollectors, numInitialCols)
 	newCols := MakeNCollectors(numFinalCols, 0)
-	c.SetCollectors(newCols)
+	c.SetCollectors(context.Background(), newCols)
 	updatedTargetItems := c.TargetItems()
 	assert.Len(t, updatedTargetItems, numItems)
 	updatedCollectors := c.Collectors()
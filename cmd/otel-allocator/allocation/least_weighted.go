@@ -15,6 +15,7 @@
 package allocation
 
 import (
+	"context"
 	"sync"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/diff"
@@ -50,6 +51,12 @@ type leastWeightedAllocator struct {
 	// collectorKey -> job -> target item hash -> true
 	targetItemsPerJobPerCollector map[string]map[string]map[string]bool
 
+	// previousAssignments maps a target item's hash to the collector it was assigned to before a restart.
+	// Entries are removed once the target has been allocated again. The assignments are only used as long as the
+	// collectors are the previousCollectors, they are dropped once a target is allocated to other collectors.
+	previousAssignments map[string]string
+	previousCollectors  map[string]bool
+
 	log logr.Logger
 
 	filter Filter
@@ -100,6 +107,53 @@ func (allocator *leastWeightedAllocator) Collectors() map[string]*Collector {
 	return collectorsCopy
 }
 
+// restoreAssignments sets the assignments to restore when the targets are allocated to the given collectors the next
+// time.
+func (allocator *leastWeightedAllocator) restoreAssignments(collectors []string, assignments map[string]string) {
+	allocator.m.Lock()
+	defer allocator.m.Unlock()
+	allocator.previousCollectors = make(map[string]bool, len(collectors))
+	for _, name := range collectors {
+		allocator.previousCollectors[name] = true
+	}
+	allocator.previousAssignments = make(map[string]string, len(assignments))
+	for k, v := range assignments {
+		allocator.previousAssignments[k] = v
+	}
+}
+
+// previousAssignment returns the collector a target was assigned to before a restart, if the collectors didn't change
+// since. The previous assignments are dropped otherwise, as restoring them would unbalance the allocation.
+func (allocator *leastWeightedAllocator) previousAssignment(tg *target.Item) (*Collector, bool) {
+	if allocator.previousAssignments == nil {
+		return nil, false
+	}
+	if !allocator.collectorsArePrevious() {
+		allocator.previousAssignments = nil
+		allocator.previousCollectors = nil
+		return nil, false
+	}
+	previous, ok := allocator.previousAssignments[tg.Hash()]
+	if !ok {
+		return nil, false
+	}
+	delete(allocator.previousAssignments, tg.Hash())
+	col, ok := allocator.collectors[previous]
+	return col, ok
+}
+
+func (allocator *leastWeightedAllocator) collectorsArePrevious() bool {
+	if len(allocator.collectors) != len(allocator.previousCollectors) {
+		return false
+	}
+	for name := range allocator.collectors {
+		if !allocator.previousCollectors[name] {
+			return false
+		}
+	}
+	return true
+}
+
 // findNextCollector finds the next collector with fewer number of targets.
 // This method is called from within SetTargets and SetCollectors, whose caller
 // acquires the needed lock. This method assumes there are is at least 1 collector set.
@@ -138,6 +192,9 @@ func (allocator *leastWeightedAllocator) addCollectorTargetItemMapping(tg *targe
 // item while it's being encoded by the server JSON handler.
 func (allocator *leastWeightedAllocator) addTargetToTargetItems(tg *target.Item) {
 	chosenCollector := allocator.findNextCollector()
+	if col, ok := allocator.previousAssignment(tg); ok {
+		chosenCollector = col
+	}
 	tg.CollectorName = chosenCollector.Name
 	allocator.targetItems[tg.Hash()] = tg
 	allocator.addCollectorTargetItemMapping(tg)
@@ -199,9 +256,10 @@ func (allocator *leastWeightedAllocator) handleCollectors(diff diff.Changes[*Col
 // SetTargets accepts a list of targets that will be used to make
 // load balancing decisions. This method should be called when there are
 // new targets discovered or existing targets are shutdown.
-func (allocator *leastWeightedAllocator) SetTargets(targets map[string]*target.Item) {
+func (allocator *leastWeightedAllocator) SetTargets(ctx context.Context, targets map[string]*target.Item) {
 	timer := prometheus.NewTimer(TimeToAssign.WithLabelValues("SetTargets", leastWeightedStrategyName))
 	defer timer.ObserveDuration()
+	defer startSpan(ctx, "SetTargets", leastWeightedStrategyName, len(targets)).End()
 
 	if allocator.filter != nil {
 		targets = allocator.filter.Apply(targets)
@@ -225,9 +283,10 @@ func (allocator *leastWeightedAllocator) SetTargets(targets map[string]*target.I
 
 // SetCollectors sets the set of collectors with key=collectorName, value=Collector object.
 // This method is called when Collectors are added or removed.
-func (allocator *leastWeightedAllocator) SetCollectors(collectors map[string]*Collector) {
+func (allocator *leastWeightedAllocator) SetCollectors(ctx context.Context, collectors map[string]*Collector) {
 	timer := prometheus.NewTimer(TimeToAssign.WithLabelValues("SetCollectors", leastWeightedStrategyName))
 	defer timer.ObserveDuration()
+	defer startSpan(ctx, "SetCollectors", leastWeightedStrategyName, len(collectors)).End()
 
 	CollectorsAllocatable.WithLabelValues(leastWeightedStrategyName).Set(float64(len(collectors)))
 	if len(collectors) == 0 {
@@ -15,6 +15,7 @@
 package allocation
 
 import (
+	"context"
 	"math"
 	"math/rand"
 	"testing"
@@ -32,7 +33,7 @@ func TestSetCollectors(t *testing.T) {
 	s, _ := New("least-weighted", logger)
 
 	cols := MakeNCollectors(3, 0)
-	s.SetCollectors(cols)
+	s.SetCollectors(context.Background(), cols)
 
 	expectedColLen := len(cols)
 	collectors := s.Collectors()
@@ -48,12 +49,12 @@ func TestAddingAndRemovingTargets(t *testing.T) {
 	s, _ := New("least-weighted", logger)
 
 	cols := MakeNCollectors(3, 0)
-	s.SetCollectors(cols)
+	s.SetCollectors(context.Background(), cols)
 
 	initTargets := MakeNNewTargets(6, 3, 0)
 
 	// test that targets and collectors are added properly
-	s.SetTargets(initTargets)
+	s.SetTargets(context.Background(), initTargets)
 
 	// verify
 	expectedTargetLen := len(initTargets)
@@ -63,7 +64,7 @@ func TestAddingAndRemovingTargets(t *testing.T) {
 	tar := MakeNNewTargets(4, 3, 0)
 
 	// test that fewer targets are found - removed
-	s.SetTargets(tar)
+	s.SetTargets(context.Background(), tar)
 
 	// verify
 	targetItems := s.TargetItems()
@@ -83,7 +84,7 @@ func TestAllocationCollision(t *testing.T) {
 	s, _ := New("least-weighted", logger)
 
 	cols := MakeNCollectors(3, 0)
-	s.SetCollectors(cols)
+	s.SetCollectors(context.Background(), cols)
 	firstLabels := model.LabelSet{
 		"test": "test1",
 	}
@@ -99,7 +100,7 @@ func TestAllocationCollision(t *testing.T) {
 	}
 
 	// test that targets and collectors are added properly
-	s.SetTargets(targetList)
+	s.SetTargets(context.Background(), targetList)
 
 	// verify
 	targetItems := s.TargetItems()
@@ -117,7 +118,7 @@ func TestNoCollectorReassignment(t *testing.T) {
 	s, _ := New("least-weighted", logger)
 
 	cols := MakeNCollectors(3, 0)
-	s.SetCollectors(cols)
+	s.SetCollectors(context.Background(), cols)
 
 	expectedColLen := len(cols)
 	assert.Len(t, s.Collectors(), expectedColLen)
@@ -128,7 +129,7 @@ func TestNoCollectorReassignment(t *testing.T) {
 	initTargets := MakeNNewTargets(6, 3, 0)
 
 	// test that targets and collectors are added properly
-	s.SetTargets(initTargets)
+	s.SetTargets(context.Background(), initTargets)
 
 	// verify
 	expectedTargetLen := len(initTargets)
@@ -137,7 +138,7 @@ func TestNoCollectorReassignment(t *testing.T) {
 
 	// assign new set of collectors with the same names
 	newCols := MakeNCollectors(3, 0)
-	s.SetCollectors(newCols)
+	s.SetCollectors(context.Background(), newCols)
 
 	newTargetItems := s.TargetItems()
 	assert.Equal(t, targetItems, newTargetItems)
@@ -149,7 +150,7 @@ func TestSmartCollectorReassignment(t *testing.T) {
 	s, _ := New("least-weighted", logger)
 
 	cols := MakeNCollectors(4, 0)
-	s.SetCollectors(cols)
+	s.SetCollectors(context.Background(), cols)
 
 	expectedColLen := len(cols)
 	assert.Len(t, s.Collectors(), expectedColLen)
@@ -159,7 +160,7 @@ func TestSmartCollectorReassignment(t *testing.T) {
 	}
 	initTargets := MakeNNewTargets(6, 0, 0)
 	// test that targets and collectors are added properly
-	s.SetTargets(initTargets)
+	s.SetTargets(context.Background(), initTargets)
 
 	// verify
 	expectedTargetLen := len(initTargets)
@@ -178,7 +179,7 @@ func TestSmartCollectorReassignment(t *testing.T) {
 			Name: "collector-4",
 		},
 	}
-	s.SetCollectors(newCols)
+	s.SetCollectors(context.Background(), newCols)
 
 	newTargetItems := s.TargetItems()
 	assert.Equal(t, len(targetItems), len(newTargetItems))
@@ -200,10 +201,10 @@ func TestCollectorBalanceWhenAddingAndRemovingAtRandom(t *testing.T) {
 	s, _ := New("least-weighted", logger)
 
 	cols := MakeNCollectors(3, 0)
-	s.SetCollectors(cols)
+	s.SetCollectors(context.Background(), cols)
 
 	targets := MakeNNewTargets(27, 3, 0)
-	s.SetTargets(targets)
+	s.SetTargets(context.Background(), targets)
 
 	// Divisor needed to get 15%
 	divisor := 6.7
@@ -229,7 +230,7 @@ func TestCollectorBalanceWhenAddingAndRemovingAtRandom(t *testing.T) {
 		}
 		counter++
 	}
-	s.SetTargets(targets)
+	s.SetTargets(context.Background(), targets)
 
 	targetItemLen = len(s.TargetItems())
 	collectors = s.Collectors()
@@ -244,7 +245,7 @@ func TestCollectorBalanceWhenAddingAndRemovingAtRandom(t *testing.T) {
 	for _, item := range MakeNNewTargets(13, 3, 100) {
 		targets[item.Hash()] = item
 	}
-	s.SetTargets(targets)
+	s.SetTargets(context.Background(), targets)
 
 	targetItemLen = len(s.TargetItems())
 	collectors = s.Collectors()
@@ -256,3 +257,37 @@ func TestCollectorBalanceWhenAddingAndRemovingAtRandom(t *testing.T) {
 		assert.InDelta(t, i.NumTargets, count, math.Round(percent))
 	}
 }
+
+func TestRestoreAssignments(t *testing.T) {
+	cols := MakeNCollectors(3, 0)
+	targets := MakeNNewTargets(9, 3, 0)
+	previousCollectors := []string{"collector-0", "collector-1", "collector-2"}
+
+	// assign every target to the last collector, which is not what least-weighted would do on its own
+	assignments := map[string]string{}
+	for hash := range targets {
+		assignments[hash] = "collector-2"
+	}
+
+	t.Run("same collectors", func(t *testing.T) {
+		s, _ := New("least-weighted", logger, WithAssignments(previousCollectors, assignments))
+		s.SetCollectors(context.Background(), cols)
+		s.SetTargets(context.Background(), targets)
+
+		for _, item := range s.TargetItems() {
+			assert.Equal(t, "collector-2", item.CollectorName)
+		}
+		assert.Equal(t, 9, s.Collectors()["collector-2"].NumTargets)
+	})
+
+	t.Run("different collectors", func(t *testing.T) {
+		// the previous assignments would unbalance the allocation to a new set of collectors
+		s, _ := New("least-weighted", logger, WithAssignments([]string{"collector-2", "collector-9"}, assignments))
+		s.SetCollectors(context.Background(), cols)
+		s.SetTargets(context.Background(), targets)
+
+		for _, col := range s.Collectors() {
+			assert.Equal(t, 3, col.NumTargets)
+		}
+	})
+}
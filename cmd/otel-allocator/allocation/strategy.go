@@ -15,6 +15,7 @@
 package allocation
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -22,6 +23,9 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
 )
@@ -49,6 +53,7 @@ var (
 		Name: "opentelemetry_allocator_targets_remaining",
 		Help: "Number of targets kept after filtering.",
 	})
+	tracer = otel.Tracer("github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation")
 )
 
 type AllocationOption func(Allocator)
@@ -63,6 +68,32 @@ func WithFilter(filter Filter) AllocationOption {
 	}
 }
 
+// assignmentRestorer is implemented by allocators which would otherwise reshuffle the targets after a restart.
+// Consistent hashing doesn't need it, as it assigns the same targets to the same collectors anyway.
+type assignmentRestorer interface {
+	restoreAssignments(collectors []string, assignments map[string]string)
+}
+
+// WithAssignments makes the allocator assign targets to the collectors they were assigned to before, as long as the
+// collectors are the same as the given ones. The assignments map a target item's hash to a collector name.
+func WithAssignments(collectors []string, assignments map[string]string) AllocationOption {
+	return func(allocator Allocator) {
+		if restorer, ok := allocator.(assignmentRestorer); ok && len(assignments) > 0 {
+			restorer.restoreAssignments(collectors, assignments)
+		}
+	}
+}
+
+// startSpan starts a child span of the span in ctx for an allocation method, which is a no-op unless the telemetry
+// export is configured.
+func startSpan(ctx context.Context, method, strategy string, items int) trace.Span {
+	_, span := tracer.Start(ctx, method, trace.WithAttributes(
+		attribute.String("allocator.strategy", strategy),
+		attribute.Int("allocator.items", items),
+	))
+	return span
+}
+
 func RecordTargetsKept(targets map[string]*target.Item) {
 	targetsRemaining.Add(float64(len(targets)))
 }
@@ -91,8 +122,8 @@ func GetRegisteredAllocatorNames() []string {
 }
 
 type Allocator interface {
-	SetCollectors(collectors map[string]*Collector)
-	SetTargets(targets map[string]*target.Item)
+	SetCollectors(ctx context.Context, collectors map[string]*Collector)
+	SetTargets(ctx context.Context, targets map[string]*target.Item)
 	TargetItems() map[string]*target.Item
 	Collectors() map[string]*Collector
 	GetTargetsForCollectorAndJob(collector string, job string) []*target.Item
@@ -15,10 +15,17 @@
 package allocation
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/diff"
 )
 
@@ -45,8 +52,8 @@ func BenchmarkGetAllTargetsByCollectorAndJob(b *testing.B) {
 			}
 			cols := MakeNCollectors(v.numCollectors, 0)
 			jobs := MakeNNewTargets(v.numJobs, v.numCollectors, 0)
-			a.SetCollectors(cols)
-			a.SetTargets(jobs)
+			a.SetCollectors(context.Background(), cols)
+			a.SetTargets(context.Background(), jobs)
 			b.Run(fmt.Sprintf("%s_num_cols_%d_num_jobs_%d", s, v.numCollectors, v.numJobs), func(b *testing.B) {
 				b.ReportAllocs()
 				for i := 0; i < b.N; i++ {
@@ -80,8 +87,8 @@ func Benchmark_Setting(b *testing.B) {
 			b.Run(fmt.Sprintf("%s_num_cols_%d_num_jobs_%d", s, v.numCollectors, v.numTargets), func(b *testing.B) {
 				b.ReportAllocs()
 				for i := 0; i < b.N; i++ {
-					a.SetCollectors(cols)
-					a.SetTargets(targets)
+					a.SetCollectors(context.Background(), cols)
+					a.SetTargets(context.Background(), targets)
 				}
 			})
 		}
@@ -134,3 +141,27 @@ func TestCollectorDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestSpansAreChildrenOfCaller(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	for _, s := range GetRegisteredAllocatorNames() {
+		t.Run(s, func(t *testing.T) {
+			a, err := New(s, logger)
+			require.NoError(t, err)
+			ended := len(recorder.Ended())
+			ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+
+			a.SetCollectors(ctx, MakeNCollectors(3, 0))
+			a.SetTargets(ctx, MakeNNewTargets(10, 3, 0))
+			parent.End()
+
+			spans := recorder.Ended()[ended:]
+			require.Len(t, spans, 3)
+			for _, span := range spans[:2] {
+				assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID(), span.Name())
+			}
+		})
+	}
+}
@@ -16,7 +16,8 @@ package collector
 
 import (
 	"context"
-	"os"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -25,118 +26,182 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/diff"
 )
 
 const (
-	watcherTimeout = 15 * time.Minute
+	// syncTimeout bounds the initial sync of the informers. The informers retry failed lists and watches with
+	// a backoff on their own, the timeout only makes sure the allocator doesn't wait forever for a broken API server.
+	syncTimeout = 5 * time.Minute
 )
 
 var (
-	ns                   = os.Getenv("OTELCOL_NAMESPACE")
 	collectorsDiscovered = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "opentelemetry_allocator_collectors_discovered",
 		Help: "Number of collectors discovered.",
 	})
+	watchErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "opentelemetry_allocator_collector_watch_errors",
+		Help: "Number of errors while watching the collector pods.",
+	}, []string{"namespace"})
 )
 
+// Client discovers the collector pods using shared informers, one per watched namespace.
 type Client struct {
-	log       logr.Logger
-	k8sClient kubernetes.Interface
-	close     chan struct{}
+	log          logr.Logger
+	k8sClient    kubernetes.Interface
+	namespaces   []string
+	resyncPeriod time.Duration
+	close        chan struct{}
 }
 
-func NewClient(logger logr.Logger, kubeConfig *rest.Config) (*Client, error) {
+// NewClient creates a client watching the collector pods in the given namespaces. All namespaces are
+// watched if none are given.
+func NewClient(logger logr.Logger, kubeConfig *rest.Config, namespaces []string, resyncPeriod time.Duration) (*Client, error) {
 	clientset, err := kubernetes.NewForConfig(kubeConfig)
 	if err != nil {
 		return &Client{}, err
 	}
 
-	return &Client{
-		log:       logger.WithValues("component", "opentelemetry-targetallocator"),
-		k8sClient: clientset,
-		close:     make(chan struct{}),
-	}, nil
+	return newClient(logger, clientset, namespaces, resyncPeriod), nil
 }
 
-func (k *Client) Watch(ctx context.Context, labelMap map[string]string, fn func(collectors map[string]*allocation.Collector)) error {
-	collectorMap := map[string]*allocation.Collector{}
-
-	opts := metav1.ListOptions{
-		LabelSelector: labels.SelectorFromSet(labelMap).String(),
+func newClient(logger logr.Logger, k8sClient kubernetes.Interface, namespaces []string, resyncPeriod time.Duration) *Client {
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
 	}
-	pods, err := k.k8sClient.CoreV1().Pods(ns).List(ctx, opts)
-	if err != nil {
-		k.log.Error(err, "Pod failure")
-		os.Exit(1)
+	return &Client{
+		log:          logger.WithValues("component", "opentelemetry-targetallocator"),
+		k8sClient:    k8sClient,
+		namespaces:   namespaces,
+		resyncPeriod: resyncPeriod,
+		close:        make(chan struct{}),
 	}
-	for i := range pods.Items {
-		pod := pods.Items[i]
-		if pod.GetObjectMeta().GetDeletionTimestamp() == nil {
-			collectorMap[pod.Name] = allocation.NewCollector(pod.Name)
+}
+
+// Watch calls fn with all ready collectors matching the label selector once the informers are synced, then whenever a
+// collector becomes ready, stops being ready or is deleted, until Close is called. An error is returned if the initial
+// sync of the informers doesn't succeed.
+func (k *Client) Watch(ctx context.Context, labelMap map[string]string, fn func(ctx context.Context, collectors map[string]*allocation.Collector)) error {
+	selector := labels.SelectorFromSet(labelMap).String()
+	var (
+		mtx      sync.Mutex
+		stores   []cache.Store
+		synced   []cache.InformerSynced
+		started  bool
+		previous map[string]*allocation.Collector
+	)
+	// the informers call the handlers sequentially, but every namespace has its own informer
+	update := func() {
+		mtx.Lock()
+		defer mtx.Unlock()
+		// the handlers are called for every pod of the initial list, only report the collectors once synced
+		if !started {
+			return
+		}
+		// most pod updates don't change the collectors, e.g. the status of a container
+		collectors := collectorsOf(stores)
+		if previous != nil {
+			if changes := diff.Maps(previous, collectors); len(changes.Additions()) == 0 && len(changes.Removals()) == 0 {
+				return
+			}
 		}
+		previous = collectors
+		fn(ctx, collectors)
+	}
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(_ interface{}) { update() },
+		UpdateFunc: func(_, _ interface{}) { update() },
+		DeleteFunc: func(_ interface{}) { update() },
 	}
 
-	fn(collectorMap)
-
-	for {
-		if !k.restartWatch(ctx, opts, collectorMap, fn) {
-			return nil
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	for _, namespace := range k.namespaces {
+		factory := informers.NewSharedInformerFactoryWithOptions(k.k8sClient, k.resyncPeriod,
+			informers.WithNamespace(namespace),
+			informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+				opts.LabelSelector = selector
+			}))
+		informer := factory.Core().V1().Pods().Informer()
+		namespace := namespace
+		if err := informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+			watchErrors.WithLabelValues(namespace).Inc()
+			k.log.Error(err, "Collector pod watch failed, retrying", "namespace", namespace)
+		}); err != nil {
+			return err
 		}
+		informer.AddEventHandler(handler)
+		stores = append(stores, informer.GetStore())
+		synced = append(synced, informer.HasSynced)
+		factory.Start(stopCh)
 	}
-}
 
-func (k *Client) restartWatch(ctx context.Context, opts metav1.ListOptions, collectorMap map[string]*allocation.Collector, fn func(collectors map[string]*allocation.Collector)) bool {
-	// add timeout to the context before calling Watch
-	ctx, cancel := context.WithTimeout(ctx, watcherTimeout)
+	syncCtx, cancel := context.WithTimeout(ctx, syncTimeout)
 	defer cancel()
-	watcher, err := k.k8sClient.CoreV1().Pods(ns).Watch(ctx, opts)
-	if err != nil {
-		k.log.Error(err, "unable to create collector pod watcher")
-		return false
+	syncStopCh := make(chan struct{})
+	go func() {
+		select {
+		case <-syncCtx.Done():
+		case <-k.close:
+		}
+		close(syncStopCh)
+	}()
+	if !cache.WaitForCacheSync(syncStopCh, synced...) {
+		select {
+		case <-k.close:
+			return nil
+		default:
+			return fmt.Errorf("failed to sync the collector pod informers within %s", syncTimeout)
+		}
 	}
-	k.log.Info("Successfully started a collector pod watcher")
-	if msg := runWatch(ctx, k, watcher.ResultChan(), collectorMap, fn); msg != "" {
-		k.log.Info("Collector pod watch event stopped " + msg)
-		return false
+	k.log.Info("Successfully started the collector pod informers", "namespaces", k.namespaces)
+
+	mtx.Lock()
+	started = true
+	mtx.Unlock()
+	update()
+
+	select {
+	case <-k.close:
+		k.log.Info("Collector pod watch stopped: kubernetes client closed")
+	case <-ctx.Done():
+		k.log.Info("Collector pod watch stopped: context done")
 	}
-
-	return true
+	return nil
 }
 
-func runWatch(ctx context.Context, k *Client, c <-chan watch.Event, collectorMap map[string]*allocation.Collector, fn func(collectors map[string]*allocation.Collector)) string {
-	for {
-		collectorsDiscovered.Set(float64(len(collectorMap)))
-		select {
-		case <-k.close:
-			return "kubernetes client closed"
-		case <-ctx.Done():
-			return ""
-		case event, ok := <-c:
-			if !ok {
-				k.log.Info("No event found. Restarting watch routine")
-				return ""
-			}
-
-			pod, ok := event.Object.(*v1.Pod)
-			if !ok {
-				k.log.Info("No pod found in event Object. Restarting watch routine")
-				return ""
+// collectorsOf returns the collectors of all ready pods in the stores, skipping pods which are being deleted. The
+// collectors are named <namespace>/<name>, as pods in different namespaces may have the same name.
+func collectorsOf(stores []cache.Store) map[string]*allocation.Collector {
+	collectorMap := map[string]*allocation.Collector{}
+	for _, store := range stores {
+		for _, obj := range store.List() {
+			pod, ok := obj.(*v1.Pod)
+			if !ok || pod.GetDeletionTimestamp() != nil || !isReady(pod) {
+				continue
 			}
+			name := pod.Namespace + "/" + pod.Name
+			collectorMap[name] = allocation.NewCollector(name)
+		}
+	}
+	collectorsDiscovered.Set(float64(len(collectorMap)))
+	return collectorMap
+}
 
-			switch event.Type { //nolint:exhaustive
-			case watch.Added:
-				collectorMap[pod.Name] = allocation.NewCollector(pod.Name)
-			case watch.Deleted:
-				delete(collectorMap, pod.Name)
-			}
-			fn(collectorMap)
+func isReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
 		}
 	}
+	return false
 }
 
 func (k *Client) Close() {
@@ -16,50 +16,29 @@ package collector
 
 import (
 	"context"
-	"fmt"
-	"os"
 	"sync"
 	"testing"
 	"time"
 
-	"k8s.io/apimachinery/pkg/watch"
-	logf "sigs.k8s.io/controller-runtime/pkg/log"
-
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
 )
 
-var logger = logf.Log.WithName("collector-unit-tests")
-
-func getTestClient() (Client, watch.Interface) {
-	kubeClient := Client{
-		k8sClient: fake.NewSimpleClientset(),
-		close:     make(chan struct{}),
-		log:       logger,
-	}
-
-	labelMap := map[string]string{
+var (
+	logger   = logf.Log.WithName("collector-unit-tests")
+	labelMap = map[string]string{
 		"app.kubernetes.io/instance":   "default.test",
 		"app.kubernetes.io/managed-by": "opentelemetry-operator",
 	}
+)
 
-	opts := metav1.ListOptions{
-		LabelSelector: labels.SelectorFromSet(labelMap).String(),
-	}
-	watcher, err := kubeClient.k8sClient.CoreV1().Pods("test-ns").Watch(context.Background(), opts)
-	if err != nil {
-		fmt.Printf("failed to setup a Collector Pod watcher: %v", err)
-		os.Exit(1)
-	}
-	return kubeClient, watcher
-}
-
-func pod(name string) *v1.Pod {
+func pod(name, namespace string) *v1.Pod {
 	labelSet := make(map[string]string)
 	labelSet["app.kubernetes.io/instance"] = "default.test"
 	labelSet["app.kubernetes.io/managed-by"] = "opentelemetry-operator"
@@ -67,155 +46,213 @@ func pod(name string) *v1.Pod {
 	return &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: "test-ns",
+			Namespace: namespace,
 			Labels:    labelSet,
 		},
+		Status: v1.PodStatus{
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+		},
 	}
 }
 
-func Test_runWatch(t *testing.T) {
-	type args struct {
-		kubeFn       func(t *testing.T, client Client, group *sync.WaitGroup)
-		collectorMap map[string]*allocation.Collector
-	}
+// collectorRecorder records the collectors reported by the client, and how many times they were reported.
+type collectorRecorder struct {
+	mtx        sync.Mutex
+	collectors map[string]*allocation.Collector
+	calls      int
+}
+
+func (r *collectorRecorder) set(_ context.Context, collectors map[string]*allocation.Collector) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.collectors = collectors
+	r.calls++
+}
+
+func (r *collectorRecorder) getCalls() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.calls
+}
+
+func (r *collectorRecorder) get() map[string]*allocation.Collector {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.collectors
+}
+
+func TestWatch(t *testing.T) {
 	tests := []struct {
-		name string
-		args args
-		want map[string]*allocation.Collector
+		name       string
+		namespaces []string
+		existing   []*v1.Pod
+		kubeFn     func(t *testing.T, client *Client)
+		want       map[string]*allocation.Collector
 	}{
 		{
-			name: "pod add",
-			args: args{
-				kubeFn: func(t *testing.T, client Client, group *sync.WaitGroup) {
-					for _, k := range []string{"test-pod1", "test-pod2", "test-pod3"} {
-						p := pod(k)
-						group.Add(1)
-						_, err := client.k8sClient.CoreV1().Pods("test-ns").Create(context.Background(), p, metav1.CreateOptions{})
-						assert.NoError(t, err)
-					}
-				},
-				collectorMap: map[string]*allocation.Collector{},
+			name:       "pod add",
+			namespaces: []string{"test-ns"},
+			kubeFn: func(t *testing.T, client *Client) {
+				for _, k := range []string{"test-pod1", "test-pod2", "test-pod3"} {
+					_, err := client.k8sClient.CoreV1().Pods("test-ns").Create(context.Background(), pod(k, "test-ns"), metav1.CreateOptions{})
+					assert.NoError(t, err)
+				}
+			},
+			want: map[string]*allocation.Collector{
+				"test-ns/test-pod1": {Name: "test-ns/test-pod1"},
+				"test-ns/test-pod2": {Name: "test-ns/test-pod2"},
+				"test-ns/test-pod3": {Name: "test-ns/test-pod3"},
+			},
+		},
+		{
+			name:       "pod delete",
+			namespaces: []string{"test-ns"},
+			existing:   []*v1.Pod{pod("test-pod1", "test-ns"), pod("test-pod2", "test-ns"), pod("test-pod3", "test-ns")},
+			kubeFn: func(t *testing.T, client *Client) {
+				for _, k := range []string{"test-pod2", "test-pod3"} {
+					err := client.k8sClient.CoreV1().Pods("test-ns").Delete(context.Background(), k, metav1.DeleteOptions{})
+					assert.NoError(t, err)
+				}
+			},
+			want: map[string]*allocation.Collector{
+				"test-ns/test-pod1": {Name: "test-ns/test-pod1"},
+			},
+		},
+		{
+			name:       "pod terminating",
+			namespaces: []string{"test-ns"},
+			existing:   []*v1.Pod{pod("test-pod1", "test-ns"), pod("test-pod2", "test-ns")},
+			kubeFn: func(t *testing.T, client *Client) {
+				p := pod("test-pod2", "test-ns")
+				p.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				_, err := client.k8sClient.CoreV1().Pods("test-ns").Update(context.Background(), p, metav1.UpdateOptions{})
+				assert.NoError(t, err)
+			},
+			want: map[string]*allocation.Collector{
+				"test-ns/test-pod1": {Name: "test-ns/test-pod1"},
+			},
+		},
+		{
+			name:       "pod not ready",
+			namespaces: []string{"test-ns"},
+			existing:   []*v1.Pod{pod("test-pod1", "test-ns"), pod("test-pod2", "test-ns")},
+			kubeFn: func(t *testing.T, client *Client) {
+				p := pod("test-pod2", "test-ns")
+				p.Status.Conditions[0].Status = v1.ConditionFalse
+				_, err := client.k8sClient.CoreV1().Pods("test-ns").UpdateStatus(context.Background(), p, metav1.UpdateOptions{})
+				assert.NoError(t, err)
+			},
+			want: map[string]*allocation.Collector{
+				"test-ns/test-pod1": {Name: "test-ns/test-pod1"},
+			},
+		},
+		{
+			name:       "same pod name in multiple namespaces",
+			namespaces: []string{"test-ns", "other-ns"},
+			existing:   []*v1.Pod{pod("test-pod1", "test-ns")},
+			kubeFn: func(t *testing.T, client *Client) {
+				_, err := client.k8sClient.CoreV1().Pods("other-ns").Create(context.Background(), pod("test-pod1", "other-ns"), metav1.CreateOptions{})
+				assert.NoError(t, err)
 			},
 			want: map[string]*allocation.Collector{
-				"test-pod1": {
-					Name: "test-pod1",
-				},
-				"test-pod2": {
-					Name: "test-pod2",
-				},
-				"test-pod3": {
-					Name: "test-pod3",
-				},
+				"test-ns/test-pod1":  {Name: "test-ns/test-pod1"},
+				"other-ns/test-pod1": {Name: "other-ns/test-pod1"},
 			},
 		},
 		{
-			name: "pod delete",
-			args: args{
-				kubeFn: func(t *testing.T, client Client, group *sync.WaitGroup) {
-					for _, k := range []string{"test-pod2", "test-pod3"} {
-						group.Add(1)
-						err := client.k8sClient.CoreV1().Pods("test-ns").Delete(context.Background(), k, metav1.DeleteOptions{})
-						assert.NoError(t, err)
-					}
-				},
-				collectorMap: map[string]*allocation.Collector{
-					"test-pod1": {
-						Name: "test-pod1",
-					},
-					"test-pod2": {
-						Name: "test-pod2",
-					},
-					"test-pod3": {
-						Name: "test-pod3",
-					},
-				},
+			name:       "multiple namespaces",
+			namespaces: []string{"test-ns", "other-ns"},
+			existing:   []*v1.Pod{pod("test-pod1", "test-ns"), pod("ignored-pod", "ignored-ns")},
+			kubeFn: func(t *testing.T, client *Client) {
+				_, err := client.k8sClient.CoreV1().Pods("other-ns").Create(context.Background(), pod("test-pod2", "other-ns"), metav1.CreateOptions{})
+				assert.NoError(t, err)
 			},
 			want: map[string]*allocation.Collector{
-				"test-pod1": {
-					Name: "test-pod1",
-				},
+				"test-ns/test-pod1":  {Name: "test-ns/test-pod1"},
+				"other-ns/test-pod2": {Name: "other-ns/test-pod2"},
+			},
+		},
+		{
+			name:     "all namespaces",
+			existing: []*v1.Pod{pod("test-pod1", "test-ns")},
+			kubeFn: func(t *testing.T, client *Client) {
+				_, err := client.k8sClient.CoreV1().Pods("other-ns").Create(context.Background(), pod("test-pod2", "other-ns"), metav1.CreateOptions{})
+				assert.NoError(t, err)
+			},
+			want: map[string]*allocation.Collector{
+				"test-ns/test-pod1":  {Name: "test-ns/test-pod1"},
+				"other-ns/test-pod2": {Name: "other-ns/test-pod2"},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			kubeClient, watcher := getTestClient()
-			defer func() {
-				close(kubeClient.close)
-				watcher.Stop()
-			}()
-			var wg sync.WaitGroup
-			actual := make(map[string]*allocation.Collector)
-			for _, k := range tt.args.collectorMap {
-				p := pod(k.Name)
-				_, err := kubeClient.k8sClient.CoreV1().Pods("test-ns").Create(context.Background(), p, metav1.CreateOptions{})
-				wg.Add(1)
-				assert.NoError(t, err)
+			kubeClient := newClient(logger, fake.NewSimpleClientset(), tt.namespaces, time.Minute)
+			for _, p := range tt.existing {
+				_, err := kubeClient.k8sClient.CoreV1().Pods(p.Namespace).Create(context.Background(), p, metav1.CreateOptions{})
+				require.NoError(t, err)
 			}
-			go runWatch(context.Background(), &kubeClient, watcher.ResultChan(), map[string]*allocation.Collector{}, func(colMap map[string]*allocation.Collector) {
-				actual = colMap
-				wg.Done()
-			})
 
-			tt.args.kubeFn(t, kubeClient, &wg)
-			wg.Wait()
+			recorder := &collectorRecorder{}
+			watchErr := make(chan error, 1)
+			go func() {
+				watchErr <- kubeClient.Watch(context.Background(), labelMap, recorder.set)
+			}()
+			// the collectors are reported once the informers are synced
+			assert.Eventually(t, func() bool {
+				return recorder.get() != nil
+			}, 5*time.Second, 10*time.Millisecond)
+
+			tt.kubeFn(t, kubeClient)
+			assert.Eventually(t, func() bool {
+				return assert.ObjectsAreEqual(tt.want, recorder.get())
+			}, 5*time.Second, 10*time.Millisecond)
 
-			assert.Len(t, actual, len(tt.want))
-			assert.Equal(t, actual, tt.want)
+			kubeClient.Close()
+			assert.NoError(t, <-watchErr)
 		})
 	}
 }
 
-// this tests runWatch in the case of watcher channel closing and watcher timing out.
-func Test_closeChannel(t *testing.T) {
-	tests := []struct {
-		description    string
-		isCloseChannel bool
-		timeoutSeconds time.Duration
-	}{
-		{
-			// event is triggered by channel closing.
-			description:    "close_channel",
-			isCloseChannel: true,
-			// channel should be closed before this timeout occurs
-			timeoutSeconds: 10 * time.Second,
-		},
-		{
-			// event triggered by timeout.
-			description:    "watcher_timeout",
-			isCloseChannel: false,
-			timeoutSeconds: 0 * time.Second,
-		},
-	}
+func TestWatchOnlyReportsChanges(t *testing.T) {
+	kubeClient := newClient(logger, fake.NewSimpleClientset(), []string{"test-ns"}, time.Minute)
+	_, err := kubeClient.k8sClient.CoreV1().Pods("test-ns").Create(context.Background(), pod("test-pod1", "test-ns"), metav1.CreateOptions{})
+	require.NoError(t, err)
 
-	for _, tc := range tests {
-		t.Run(tc.description, func(t *testing.T) {
-			kubeClient, watcher := getTestClient()
+	recorder := &collectorRecorder{}
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- kubeClient.Watch(context.Background(), labelMap, recorder.set)
+	}()
+	assert.Eventually(t, func() bool {
+		return recorder.getCalls() == 1
+	}, 5*time.Second, 10*time.Millisecond)
 
-			defer func() {
-				close(kubeClient.close)
-				watcher.Stop()
-			}()
-			var wg sync.WaitGroup
-			wg.Add(1)
-			terminated := false
-
-			go func(watcher watch.Interface) {
-				defer wg.Done()
-				ctx, cancel := context.WithTimeout(context.Background(), tc.timeoutSeconds)
-				defer cancel()
-				if msg := runWatch(ctx, &kubeClient, watcher.ResultChan(), map[string]*allocation.Collector{}, func(colMap map[string]*allocation.Collector) {}); msg != "" {
-					terminated = true
-					return
-				}
-			}(watcher)
+	// neither the membership nor the readiness of the collectors change
+	updated := pod("test-pod1", "test-ns")
+	updated.Annotations = map[string]string{"updated": "true"}
+	_, err = kubeClient.k8sClient.CoreV1().Pods("test-ns").Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	// a pod which isn't ready isn't a collector yet
+	notReady := pod("test-pod2", "test-ns")
+	notReady.Status.Conditions = nil
+	_, err = kubeClient.k8sClient.CoreV1().Pods("test-ns").Create(context.Background(), notReady, metav1.CreateOptions{})
+	require.NoError(t, err)
+	// the collector is reported once ready
+	_, err = kubeClient.k8sClient.CoreV1().Pods("test-ns").UpdateStatus(context.Background(), pod("test-pod2", "test-ns"), metav1.UpdateOptions{})
+	require.NoError(t, err)
 
-			if tc.isCloseChannel {
-				// stop pod watcher to trigger event.
-				watcher.Stop()
-			}
-			wg.Wait()
-			assert.False(t, terminated)
-		})
-	}
+	assert.Eventually(t, func() bool {
+		return len(recorder.get()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, recorder.getCalls())
+
+	kubeClient.Close()
+	assert.NoError(t, <-watchErr)
+}
+
+func TestWatchClosedBeforeSync(t *testing.T) {
+	kubeClient := newClient(logger, fake.NewSimpleClientset(), []string{"test-ns"}, time.Minute)
+	kubeClient.Close()
+	assert.NoError(t, kubeClient.Watch(context.Background(), labelMap, func(_ context.Context, _ map[string]*allocation.Collector) {}))
 }
@@ -39,6 +39,14 @@ import (
 const DefaultResyncTime = 5 * time.Minute
 const DefaultConfigFilePath string = "/conf/targetallocator.yaml"
 
+const DefaultPersistenceInterval = 30 * time.Second
+const DefaultTelemetryInterval = 30 * time.Second
+
+const (
+	PersistenceTypeFile      = "file"
+	PersistenceTypeConfigMap = "configmap"
+)
+
 type Config struct {
 	LabelSelector          map[string]string  `yaml:"label_selector,omitempty"`
 	Config                 *promconfig.Config `yaml:"config"`
@@ -46,6 +54,50 @@ type Config struct {
 	FilterStrategy         *string            `yaml:"filter_strategy,omitempty"`
 	PodMonitorSelector     map[string]string  `yaml:"pod_monitor_selector,omitempty"`
 	ServiceMonitorSelector map[string]string  `yaml:"service_monitor_selector,omitempty"`
+	Persistence            *PersistenceConfig `yaml:"persistence,omitempty"`
+	Telemetry              *TelemetryConfig   `yaml:"telemetry,omitempty"`
+	CollectorNamespaces    []string           `yaml:"collector_namespaces,omitempty"`
+}
+
+// PersistenceConfig defines where the target allocator persists its target assignments,
+// so that the assignments survive restarts of the target allocator.
+type PersistenceConfig struct {
+	// Type is either "file" or "configmap".
+	Type string `yaml:"type"`
+	// Path is the file the assignments are written to when the type is "file", usually on a persistent volume.
+	Path string `yaml:"path,omitempty"`
+	// ConfigMapName is the name of the ConfigMap the assignments are written to when the type is "configmap".
+	ConfigMapName string `yaml:"configmap_name,omitempty"`
+	// Namespace of the ConfigMap the assignments are written to when the type is "configmap".
+	Namespace string `yaml:"namespace,omitempty"`
+	// Interval defines how often the assignments are persisted.
+	Interval time.Duration `yaml:"interval,omitempty"`
+}
+
+func (p PersistenceConfig) GetInterval() time.Duration {
+	if p.Interval > 0 {
+		return p.Interval
+	}
+	return DefaultPersistenceInterval
+}
+
+// TelemetryConfig defines where the target allocator exports its own metrics and traces to, using OTLP/HTTP.
+type TelemetryConfig struct {
+	// Endpoint is the host and port of the OTLP/HTTP receiver, for example "otel-collector:4318".
+	Endpoint string `yaml:"endpoint"`
+	// Insecure disables TLS for the connection to the endpoint.
+	Insecure bool `yaml:"insecure,omitempty"`
+	// Headers are added to every export request, for example to authenticate against the receiver.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Interval defines how often the metrics are exported.
+	Interval time.Duration `yaml:"interval,omitempty"`
+}
+
+func (t TelemetryConfig) GetInterval() time.Duration {
+	if t.Interval > 0 {
+		return t.Interval
+	}
+	return DefaultTelemetryInterval
 }
 
 func (c Config) GetAllocationStrategy() string {
@@ -55,6 +107,18 @@ func (c Config) GetAllocationStrategy() string {
 	return "least-weighted"
 }
 
+// GetCollectorNamespaces returns the namespaces the collector pods are watched in. It falls back to the
+// OTELCOL_NAMESPACE environment variable, an empty result means all namespaces are watched.
+func (c Config) GetCollectorNamespaces() []string {
+	if len(c.CollectorNamespaces) > 0 {
+		return c.CollectorNamespaces
+	}
+	if ns := os.Getenv("OTELCOL_NAMESPACE"); ns != "" {
+		return []string{ns}
+	}
+	return nil
+}
+
 func (c Config) GetTargetsFilterStrategy() string {
 	if c.FilterStrategy != nil {
 		return *c.FilterStrategy
@@ -81,9 +145,47 @@ func Load(file string) (Config, error) {
 	if err := unmarshal(&cfg, file); err != nil {
 		return Config{}, err
 	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
 	return cfg, nil
 }
 
+// Validate checks the parts of the configuration that can't be verified while unmarshaling.
+func (c Config) Validate() error {
+	if c.Config == nil {
+		return errors.New("no prometheus configuration found")
+	}
+	jobNames := map[string]struct{}{}
+	for _, scrapeConfig := range c.Config.ScrapeConfigs {
+		if scrapeConfig == nil {
+			return errors.New("empty scrape config")
+		}
+		if _, ok := jobNames[scrapeConfig.JobName]; ok {
+			return fmt.Errorf("found multiple scrape configs with job name %q", scrapeConfig.JobName)
+		}
+		jobNames[scrapeConfig.JobName] = struct{}{}
+	}
+	if c.Persistence != nil {
+		switch c.Persistence.Type {
+		case PersistenceTypeFile:
+			if c.Persistence.Path == "" {
+				return errors.New("persistence of type file requires a path")
+			}
+		case PersistenceTypeConfigMap:
+			if c.Persistence.ConfigMapName == "" || c.Persistence.Namespace == "" {
+				return errors.New("persistence of type configmap requires a configmap_name and a namespace")
+			}
+		default:
+			return fmt.Errorf("unsupported persistence type: %q", c.Persistence.Type)
+		}
+	}
+	if c.Telemetry != nil && c.Telemetry.Endpoint == "" {
+		return errors.New("telemetry requires an endpoint")
+	}
+	return nil
+}
+
 func unmarshal(cfg *Config, configFile string) error {
 
 	yamlFile, err := os.ReadFile(configFile)
@@ -146,6 +146,14 @@ func TestLoad(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "no prometheus config",
+			args: args{
+				file: "./testdata/no_config_test.yaml",
+			},
+			want:    Config{},
+			wantErr: assert.Error,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -157,3 +165,66 @@ func TestLoad(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	cfg := Config{
+		Config: &promconfig.Config{
+			ScrapeConfigs: []*promconfig.ScrapeConfig{
+				{JobName: "prometheus"},
+				{JobName: "prometheus"},
+			},
+		},
+	}
+	assert.EqualError(t, cfg.Validate(), `found multiple scrape configs with job name "prometheus"`)
+
+	cfg.Config.ScrapeConfigs[1].JobName = "other"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Persistence = &PersistenceConfig{Type: PersistenceTypeConfigMap, ConfigMapName: "assignments"}
+	assert.Error(t, cfg.Validate())
+
+	cfg.Persistence.Namespace = "default"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Persistence = &PersistenceConfig{Type: "unknown"}
+	assert.EqualError(t, cfg.Validate(), `unsupported persistence type: "unknown"`)
+
+	cfg.Persistence = nil
+	cfg.Telemetry = &TelemetryConfig{}
+	assert.EqualError(t, cfg.Validate(), "telemetry requires an endpoint")
+
+	cfg.Telemetry.Endpoint = "otel-collector:4318"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestGetCollectorNamespaces(t *testing.T) {
+	t.Setenv("OTELCOL_NAMESPACE", "")
+	assert.Nil(t, Config{}.GetCollectorNamespaces())
+
+	t.Setenv("OTELCOL_NAMESPACE", "default")
+	assert.Equal(t, []string{"default"}, Config{}.GetCollectorNamespaces())
+
+	cfg := Config{CollectorNamespaces: []string{"team-a", "team-b"}}
+	assert.Equal(t, []string{"team-a", "team-b"}, cfg.GetCollectorNamespaces())
+}
+
+func TestReloadStatus(t *testing.T) {
+	now := time.Unix(1000, 0)
+	status := NewReloadStatus()
+	status.now = func() time.Time { return now }
+
+	status.Record("configmap", nil)
+	assert.True(t, status.Successful())
+
+	now = now.Add(time.Minute)
+	status.Record("configmap", fmt.Errorf("bad config"))
+	assert.False(t, status.Successful())
+	assert.Equal(t, map[string]SourceStatus{
+		"configmap": {
+			Successful:         false,
+			LastReload:         time.Unix(1060, 0),
+			LastSuccessfulLoad: time.Unix(1000, 0),
+			Error:              "bad config",
+		},
+	}, status.Status())
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	lastReloadSuccessful = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_config_last_reload_successful",
+		Help: "Whether the last configuration reload attempt was successful.",
+	}, []string{"source"})
+	lastReloadTimestamp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_config_last_reload_timestamp_seconds",
+		Help: "Timestamp of the last successful configuration reload.",
+	}, []string{"source"})
+)
+
+// SourceStatus describes the outcome of the most recent configuration reload for a single source.
+type SourceStatus struct {
+	Successful         bool      `json:"successful"`
+	LastReload         time.Time `json:"last_reload"`
+	LastSuccessfulLoad time.Time `json:"last_successful_reload,omitempty"`
+	Error              string    `json:"error,omitempty"`
+}
+
+// ReloadStatus keeps track of configuration reloads, so that rejected configurations are visible
+// through the metrics and the status endpoint instead of only in the logs.
+type ReloadStatus struct {
+	mtx     sync.RWMutex
+	sources map[string]SourceStatus
+	now     func() time.Time
+}
+
+func NewReloadStatus() *ReloadStatus {
+	return &ReloadStatus{
+		sources: map[string]SourceStatus{},
+		now:     time.Now,
+	}
+}
+
+// Record stores the result of a reload attempt for the given source. A nil error marks the reload as successful.
+func (s *ReloadStatus) Record(source string, err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := s.now()
+	status := s.sources[source]
+	status.LastReload = now
+	if err != nil {
+		status.Successful = false
+		status.Error = err.Error()
+		lastReloadSuccessful.WithLabelValues(source).Set(0)
+	} else {
+		status.Successful = true
+		status.Error = ""
+		status.LastSuccessfulLoad = now
+		lastReloadSuccessful.WithLabelValues(source).Set(1)
+		lastReloadTimestamp.WithLabelValues(source).Set(float64(now.Unix()))
+	}
+	s.sources[source] = status
+}
+
+// Status returns a copy of the reload status of every source seen so far.
+func (s *ReloadStatus) Status() map[string]SourceStatus {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	statuses := make(map[string]SourceStatus, len(s.sources))
+	for source, status := range s.sources {
+		statuses[source] = status
+	}
+	return statuses
+}
+
+// Successful returns false if the last reload of any source was rejected.
+func (s *ReloadStatus) Successful() bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	for _, status := range s.sources {
+		if !status.Successful {
+			return false
+		}
+	}
+	return true
+}
@@ -25,15 +25,18 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/prometheus/discovery"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/collector"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/persistence"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/prehook"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/server"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/telemetry"
 	allocatorWatcher "github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/watcher"
 )
 
@@ -51,11 +54,13 @@ func main() {
 		// unrecognized. No filtering will be used in this case.
 		allocatorPrehook prehook.Hook
 		allocator        allocation.Allocator
+		assignmentStore  persistence.Store
 		discoveryManager *discovery.Manager
 		collectorWatcher *collector.Client
 		fileWatcher      allocatorWatcher.Watcher
 		promWatcher      allocatorWatcher.Watcher
 		targetDiscoverer *target.Discoverer
+		selfTelemetry    *telemetry.Telemetry
 
 		discoveryCancel context.CancelFunc
 		runGroup        run.Group
@@ -69,17 +74,45 @@ func main() {
 		setupLog.Error(err, "Failed to parse parameters")
 		os.Exit(1)
 	}
+	reloadStatus := config.NewReloadStatus()
 	cfg, configLoadErr := config.Load(*cliConf.ConfigFilePath)
 	if configLoadErr != nil {
 		setupLog.Error(configLoadErr, "Unable to load configuration")
 	}
+	reloadStatus.Record(allocatorWatcher.EventSourceConfigMap.String(), configLoadErr)
 
 	cliConf.RootLogger.Info("Starting the Target Allocator")
 	ctx := context.Background()
 	log := ctrl.Log.WithName("allocator")
 
+	if cfg.Telemetry != nil {
+		selfTelemetry, err = telemetry.New(ctx, log.WithName("telemetry"), *cfg.Telemetry)
+		if err != nil {
+			setupLog.Error(err, "Unable to initialize the telemetry export")
+			os.Exit(1)
+		}
+	}
 	allocatorPrehook = prehook.New(cfg.GetTargetsFilterStrategy(), log)
-	allocator, err = allocation.New(cfg.GetAllocationStrategy(), log, allocation.WithFilter(allocatorPrehook))
+	allocationOpts := []allocation.AllocationOption{allocation.WithFilter(allocatorPrehook)}
+	if cfg.Persistence != nil {
+		assignmentStore, err = persistence.New(*cfg.Persistence, cliConf.ClusterConfig)
+		if err != nil {
+			setupLog.Error(err, "Unable to initialize the assignment persistence")
+			os.Exit(1)
+		}
+		// the previous assignments have to be loaded before the first collectors and targets are set
+		snapshot, loadErr := assignmentStore.Load(ctx)
+		if apierrors.IsForbidden(loadErr) {
+			// the assignments couldn't be persisted either, the service account lacks the permissions on the config map
+			setupLog.Error(loadErr, "Not allowed to load the persisted assignments")
+			os.Exit(1)
+		}
+		if loadErr != nil {
+			setupLog.Error(loadErr, "Unable to load the persisted assignments, targets will be reassigned")
+		}
+		allocationOpts = append(allocationOpts, allocation.WithAssignments(snapshot.Collectors, snapshot.Assignments))
+	}
+	allocator, err = allocation.New(cfg.GetAllocationStrategy(), log, allocationOpts...)
 	if err != nil {
 		setupLog.Error(err, "Unable to initialize allocation strategy")
 		os.Exit(1)
@@ -87,7 +120,7 @@ func main() {
 	discoveryCtx, discoveryCancel := context.WithCancel(ctx)
 	discoveryManager = discovery.NewManager(discoveryCtx, gokitlog.NewNopLogger())
 	targetDiscoverer = target.NewDiscoverer(log, discoveryManager, allocatorPrehook)
-	collectorWatcher, collectorWatcherErr := collector.NewClient(log, cliConf.ClusterConfig)
+	collectorWatcher, collectorWatcherErr := collector.NewClient(log, cliConf.ClusterConfig, cfg.GetCollectorNamespaces(), config.DefaultResyncTime)
 	if collectorWatcherErr != nil {
 		setupLog.Error(collectorWatcherErr, "Unable to initialize collector watcher")
 		os.Exit(1)
@@ -97,7 +130,7 @@ func main() {
 		setupLog.Error(err, "Can't start the file watcher")
 		os.Exit(1)
 	}
-	srv := server.NewServer(log, allocator, targetDiscoverer, cliConf.ListenAddr)
+	srv := server.NewServer(log, allocator, targetDiscoverer, cliConf.ListenAddr, server.WithReloadStatus(reloadStatus))
 	signal.Notify(interrupts, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 	defer close(interrupts)
 
@@ -146,13 +179,16 @@ func main() {
 		})
 	runGroup.Add(
 		func() error {
-			// Initial loading of the config file's scrape config
-			err = targetDiscoverer.ApplyConfig(allocatorWatcher.EventSourceConfigMap, cfg.Config)
-			if err != nil {
-				setupLog.Error(err, "Unable to apply initial configuration")
-				return err
+			// Initial loading of the config file's scrape config. If it couldn't be loaded, we wait for
+			// a valid configuration to be written instead of exiting.
+			if configLoadErr == nil {
+				err = targetDiscoverer.ApplyConfig(allocatorWatcher.EventSourceConfigMap, cfg.Config)
+				if err != nil {
+					setupLog.Error(err, "Unable to apply initial configuration")
+					return err
+				}
 			}
-			err := targetDiscoverer.Watch(allocator.SetTargets)
+			err := targetDiscoverer.Watch(ctx, allocator.SetTargets)
 			setupLog.Info("Target discoverer exited")
 			return err
 		},
@@ -170,6 +206,31 @@ func main() {
 			setupLog.Info("Closing collector watcher")
 			collectorWatcher.Close()
 		})
+	if assignmentStore != nil {
+		persister := persistence.NewPersister(log.WithName("persister"), assignmentStore, allocator, cfg.Persistence.GetInterval())
+		runGroup.Add(
+			func() error {
+				err := persister.Start(ctx)
+				setupLog.Info("Assignment persister exited")
+				return err
+			},
+			func(_ error) {
+				setupLog.Info("Closing assignment persister")
+				persister.Close()
+			})
+	}
+	if selfTelemetry != nil {
+		runGroup.Add(
+			func() error {
+				err := selfTelemetry.Start(ctx)
+				setupLog.Info("Telemetry exporter exited")
+				return err
+			},
+			func(_ error) {
+				setupLog.Info("Closing telemetry exporter")
+				selfTelemetry.Close()
+			})
+	}
 	runGroup.Add(
 		func() error {
 			err := srv.Start()
@@ -188,16 +249,20 @@ func main() {
 				select {
 				case event := <-eventChan:
 					eventsMetric.WithLabelValues(event.Source.String()).Inc()
+					// on failure, the discoverer keeps using the last configuration that was applied successfully
 					loadConfig, err := event.Watcher.LoadConfig()
 					if err != nil {
-						setupLog.Error(err, "Unable to load configuration")
+						setupLog.Error(err, "Unable to load configuration, keeping the last good configuration")
+						reloadStatus.Record(event.Source.String(), err)
 						continue
 					}
 					err = targetDiscoverer.ApplyConfig(event.Source, loadConfig)
 					if err != nil {
-						setupLog.Error(err, "Unable to apply configuration")
+						setupLog.Error(err, "Unable to apply configuration, keeping the last good configuration")
+						reloadStatus.Record(event.Source.String(), err)
 						continue
 					}
+					reloadStatus.Record(event.Source.String(), nil)
 				case err := <-errChan:
 					setupLog.Error(err, "Watcher error")
 				case <-eventCloser:
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const assignmentsKey = "assignments.json"
+
+var _ Store = &ConfigMapStore{}
+
+// ConfigMapStore saves the assignments into a ConfigMap, creating it when needed.
+type ConfigMapStore struct {
+	k8sClient kubernetes.Interface
+	namespace string
+	name      string
+}
+
+func NewConfigMapStore(k8sClient kubernetes.Interface, namespace, name string) *ConfigMapStore {
+	return &ConfigMapStore{
+		k8sClient: k8sClient,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+func (c *ConfigMapStore) Load(ctx context.Context) (Snapshot, error) {
+	cm, err := c.k8sClient.CoreV1().ConfigMaps(c.namespace).Get(ctx, c.name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return Snapshot{}, nil
+	}
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return unmarshalSnapshot([]byte(cm.Data[assignmentsKey]))
+}
+
+func (c *ConfigMapStore) Save(ctx context.Context, snapshot Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	cm, err := c.k8sClient.CoreV1().ConfigMaps(c.namespace).Get(ctx, c.name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      c.name,
+				Namespace: c.namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/component": "opentelemetry-targetallocator",
+				},
+			},
+			Data: map[string]string{
+				assignmentsKey: string(data),
+			},
+		}
+		_, err = c.k8sClient.CoreV1().ConfigMaps(c.namespace).Create(ctx, cm, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	updated := cm.DeepCopy()
+	if updated.Data == nil {
+		updated.Data = map[string]string{}
+	}
+	updated.Data[assignmentsKey] = string(data)
+	_, err = c.k8sClient.CoreV1().ConfigMaps(c.namespace).Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
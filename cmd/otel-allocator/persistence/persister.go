@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+var (
+	persistFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "opentelemetry_allocator_assignments_persist_failures",
+		Help: "Number of failed attempts to persist the target assignments.",
+	})
+)
+
+// AllocationProvider returns the current collectors and allocated target items.
+type AllocationProvider interface {
+	TargetItems() map[string]*target.Item
+	Collectors() map[string]*allocation.Collector
+}
+
+// Persister periodically saves the assignments of the allocator into a store.
+type Persister struct {
+	log      logr.Logger
+	store    Store
+	provider AllocationProvider
+	interval time.Duration
+	close    chan struct{}
+
+	last Snapshot
+}
+
+func NewPersister(log logr.Logger, store Store, provider AllocationProvider, interval time.Duration) *Persister {
+	return &Persister{
+		log:      log,
+		store:    store,
+		provider: provider,
+		interval: interval,
+		close:    make(chan struct{}),
+	}
+}
+
+// AssignmentsOf returns the assignments of the given target items.
+func AssignmentsOf(items map[string]*target.Item) Assignments {
+	assignments := make(Assignments, len(items))
+	for hash, item := range items {
+		if item.CollectorName != "" {
+			assignments[hash] = item.CollectorName
+		}
+	}
+	return assignments
+}
+
+// SnapshotOf returns the snapshot of the given collectors and target items.
+func SnapshotOf(collectors map[string]*allocation.Collector, items map[string]*target.Item) Snapshot {
+	names := make([]string, 0, len(collectors))
+	for name := range collectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return Snapshot{Collectors: names, Assignments: AssignmentsOf(items)}
+}
+
+// Start persists the assignments every interval until Close is called. The assignments are persisted
+// one last time before returning.
+func (p *Persister) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.close:
+			p.persist(ctx)
+			return nil
+		case <-ticker.C:
+			p.persist(ctx)
+		}
+	}
+}
+
+func (p *Persister) Close() {
+	close(p.close)
+}
+
+// persist saves the current snapshot, unless it didn't change since the last save. Nothing is saved as long
+// as no target is allocated, so that a restarting allocator doesn't overwrite the snapshot it is about to restore.
+func (p *Persister) persist(ctx context.Context) {
+	snapshot := SnapshotOf(p.provider.Collectors(), p.provider.TargetItems())
+	if len(snapshot.Assignments) == 0 || reflect.DeepEqual(snapshot, p.last) {
+		return
+	}
+	if err := p.store.Save(ctx, snapshot); err != nil {
+		persistFailures.Inc()
+		p.log.Error(err, "Unable to persist the target assignments")
+		return
+	}
+	p.last = snapshot
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package persistence stores the target to collector assignments, so that they survive restarts of the target allocator.
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
+)
+
+// Assignments maps a target item's hash to the name of the collector the target is assigned to.
+type Assignments map[string]string
+
+// Snapshot is the persisted state of the allocator. The collectors are saved along with the assignments, as the
+// assignments are only meaningful for the same set of collectors.
+type Snapshot struct {
+	// Collectors are the sorted names of the collectors the targets were assigned to.
+	Collectors []string `json:"collectors"`
+	// Assignments are the assignments of the targets to the collectors.
+	Assignments Assignments `json:"assignments"`
+}
+
+// Store loads and saves snapshots.
+type Store interface {
+	// Load returns the last saved snapshot, or an empty snapshot if nothing was saved yet.
+	Load(ctx context.Context) (Snapshot, error)
+	Save(ctx context.Context, snapshot Snapshot) error
+}
+
+// New creates the store matching the given configuration.
+func New(cfg config.PersistenceConfig, kubeConfig *rest.Config) (Store, error) {
+	switch cfg.Type {
+	case config.PersistenceTypeFile:
+		return NewFileStore(cfg.Path), nil
+	case config.PersistenceTypeConfigMap:
+		clientset, err := kubernetes.NewForConfig(kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+		return NewConfigMapStore(clientset, cfg.Namespace, cfg.ConfigMapName), nil
+	}
+	return nil, fmt.Errorf("unsupported persistence type: %q", cfg.Type)
+}
+
+var _ Store = &FileStore{}
+
+// FileStore saves the assignments as JSON into a local file, usually on a persistent volume.
+type FileStore struct {
+	path string
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (f *FileStore) Load(_ context.Context) (Snapshot, error) {
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return Snapshot{}, nil
+	}
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return unmarshalSnapshot(data)
+}
+
+func (f *FileStore) Save(_ context.Context, snapshot Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	// write to a temporary file first, so that a crash while writing doesn't leave a truncated snapshot behind
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func unmarshalSnapshot(data []byte) (Snapshot, error) {
+	snapshot := Snapshot{}
+	if len(data) == 0 {
+		return snapshot, nil
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to unmarshal the persisted assignments: %w", err)
+	}
+	return snapshot, nil
+}
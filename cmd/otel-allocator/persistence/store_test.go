@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+func TestStores(t *testing.T) {
+	stores := map[string]Store{
+		"file":      NewFileStore(filepath.Join(t.TempDir(), "assignments.json")),
+		"configmap": NewConfigMapStore(fake.NewSimpleClientset(), "default", "test-targetallocator-assignments"),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			loaded, err := store.Load(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, loaded.Assignments)
+
+			snapshot := Snapshot{
+				Collectors:  []string{"collector-0", "collector-1"},
+				Assignments: Assignments{"target-1": "collector-0", "target-2": "collector-1"},
+			}
+			require.NoError(t, store.Save(ctx, snapshot))
+			loaded, err = store.Load(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, snapshot, loaded)
+
+			// saving again overwrites the previous snapshot
+			snapshot = Snapshot{Collectors: []string{"collector-1"}, Assignments: Assignments{"target-1": "collector-1"}}
+			require.NoError(t, store.Save(ctx, snapshot))
+			loaded, err = store.Load(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, snapshot, loaded)
+		})
+	}
+}
+
+type allocationProvider struct {
+	collectors map[string]*allocation.Collector
+	items      map[string]*target.Item
+}
+
+func (a allocationProvider) TargetItems() map[string]*target.Item {
+	return a.items
+}
+
+func (a allocationProvider) Collectors() map[string]*allocation.Collector {
+	return a.collectors
+}
+
+func TestPersister(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileStore(filepath.Join(t.TempDir(), "assignments.json"))
+	item := target.NewItem("job", "target:8080", model.LabelSet{}, "collector-0")
+	provider := allocationProvider{
+		collectors: map[string]*allocation.Collector{
+			"collector-1": allocation.NewCollector("collector-1"),
+			"collector-0": allocation.NewCollector("collector-0"),
+		},
+		items: map[string]*target.Item{},
+	}
+	p := NewPersister(logf.Log.WithName("unit-tests"), store, provider, 0)
+
+	// nothing allocated yet, a snapshot from a previous run must not be overwritten
+	previous := Snapshot{Collectors: []string{"collector-1"}, Assignments: Assignments{"previous": "collector-1"}}
+	require.NoError(t, store.Save(ctx, previous))
+	p.persist(ctx)
+	loaded, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, previous, loaded)
+
+	provider.items[item.Hash()] = item
+	p.persist(ctx)
+	loaded, err = store.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Snapshot{Collectors: []string{"collector-0", "collector-1"}, Assignments: Assignments{item.Hash(): "collector-0"}}, loaded)
+}
@@ -15,6 +15,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"net/http/httptest"
@@ -51,8 +52,8 @@ func BenchmarkServerTargetsHandler(b *testing.B) {
 			cols := allocation.MakeNCollectors(v.numCollectors, 0)
 			targets := allocation.MakeNNewTargets(v.numJobs, v.numCollectors, 0)
 			listenAddr := ":8080"
-			a.SetCollectors(cols)
-			a.SetTargets(targets)
+			a.SetCollectors(context.Background(), cols)
+			a.SetTargets(context.Background(), targets)
 			s := NewServer(logger, a, nil, &listenAddr)
 			b.Run(fmt.Sprintf("%s_num_cols_%d_num_jobs_%d", allocatorName, v.numCollectors, v.numJobs), func(b *testing.B) {
 				b.ReportAllocs()
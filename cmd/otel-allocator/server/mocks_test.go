@@ -15,6 +15,7 @@
 package server
 
 import (
+	"context"
 	promconfig "github.com/prometheus/prometheus/config"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
@@ -40,11 +41,11 @@ type mockAllocator struct {
 	targetItems map[string]*target.Item
 }
 
-func (m *mockAllocator) SetCollectors(_ map[string]*allocation.Collector)               {}
-func (m *mockAllocator) SetTargets(_ map[string]*target.Item)                           {}
-func (m *mockAllocator) Collectors() map[string]*allocation.Collector                   { return nil }
-func (m *mockAllocator) GetTargetsForCollectorAndJob(_ string, _ string) []*target.Item { return nil }
-func (m *mockAllocator) SetFilter(_ allocation.Filter)                                  {}
+func (m *mockAllocator) SetCollectors(_ context.Context, _ map[string]*allocation.Collector) {}
+func (m *mockAllocator) SetTargets(_ context.Context, _ map[string]*target.Item)             {}
+func (m *mockAllocator) Collectors() map[string]*allocation.Collector                        { return nil }
+func (m *mockAllocator) GetTargetsForCollectorAndJob(_ string, _ string) []*target.Item      { return nil }
+func (m *mockAllocator) SetFilter(_ allocation.Filter)                                       {}
 
 func (m *mockAllocator) TargetItems() map[string]*target.Item {
 	return m.targetItems
@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"net/http/pprof"
 	"net/url"
+	"strings"
 	"time"
 
 	yaml2 "github.com/ghodss/yaml"
@@ -31,9 +32,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	promconfig "github.com/prometheus/prometheus/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/yaml.v2"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
 )
 
@@ -42,6 +48,7 @@ var (
 		Name: "opentelemetry_allocator_http_duration_seconds",
 		Help: "Duration of received HTTP requests.",
 	}, []string{"path"})
+	tracer = otel.Tracer("github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/server")
 )
 
 type collectorJSON struct {
@@ -53,32 +60,55 @@ type DiscoveryManager interface {
 	GetScrapeConfigs() map[string]*promconfig.ScrapeConfig
 }
 
+// ReloadStatusProvider exposes the outcome of the configuration reloads.
+type ReloadStatusProvider interface {
+	Status() map[string]config.SourceStatus
+	Successful() bool
+}
+
+type Option func(*Server)
+
+// WithReloadStatus enables the /status endpoint, reporting the configuration reload status.
+func WithReloadStatus(provider ReloadStatusProvider) Option {
+	return func(s *Server) {
+		s.reloadStatus = provider
+	}
+}
+
 type Server struct {
 	logger           logr.Logger
 	allocator        allocation.Allocator
 	discoveryManager DiscoveryManager
+	reloadStatus     ReloadStatusProvider
 	server           *http.Server
 
 	compareHash          uint64
 	scrapeConfigResponse []byte
 }
 
-func NewServer(log logr.Logger, allocator allocation.Allocator, discoveryManager DiscoveryManager, listenAddr *string) *Server {
+func NewServer(log logr.Logger, allocator allocation.Allocator, discoveryManager DiscoveryManager, listenAddr *string, opts ...Option) *Server {
 	s := &Server{
 		logger:           log,
 		allocator:        allocator,
 		discoveryManager: discoveryManager,
 		compareHash:      uint64(0),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
 
 	router := gin.Default()
 	router.UseRawPath = true
 	router.UnescapePathValues = false
+	router.Use(s.TracingMiddleware)
 	router.Use(s.PrometheusMiddleware)
 	router.GET("/scrape_configs", s.ScrapeConfigsHandler)
 	router.GET("/jobs", s.JobHandler)
 	router.GET("/jobs/:job_id/targets", s.TargetsHandler)
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	if s.reloadStatus != nil {
+		router.GET("/status", s.StatusHandler)
+	}
 	registerPprof(router.Group("/debug/pprof/"))
 
 	s.server = &http.Server{Addr: *listenAddr, Handler: router, ReadHeaderTimeout: 90 * time.Second}
@@ -140,6 +170,15 @@ func (s *Server) JobHandler(c *gin.Context) {
 	s.jsonHandler(c.Writer, displayData)
 }
 
+// StatusHandler returns the configuration reload status of every config source. The response code is
+// 503 if the last reload of any source was rejected, in which case the allocator runs on the last good configuration.
+func (s *Server) StatusHandler(c *gin.Context) {
+	if !s.reloadStatus.Successful() {
+		c.Writer.WriteHeader(http.StatusServiceUnavailable)
+	}
+	s.jsonHandler(c.Writer, s.reloadStatus.Status())
+}
+
 func (s *Server) PrometheusMiddleware(c *gin.Context) {
 	path := c.FullPath()
 	timer := prometheus.NewTimer(httpDuration.WithLabelValues(path))
@@ -147,6 +186,28 @@ func (s *Server) PrometheusMiddleware(c *gin.Context) {
 	timer.ObserveDuration()
 }
 
+// TracingMiddleware starts a server span for every request, continuing the trace of the caller if it sent one.
+func (s *Server) TracingMiddleware(c *gin.Context) {
+	ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+	route := c.FullPath()
+	spanName := route
+	if spanName == "" {
+		spanName = fmt.Sprintf("HTTP %s", c.Request.Method)
+	}
+	ctx, span := tracer.Start(ctx, spanName,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest("", route, c.Request)...),
+	)
+	defer span.End()
+
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+
+	status := c.Writer.Status()
+	span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(status)...)
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(status, trace.SpanKindServer))
+}
+
 func (s *Server) TargetsHandler(c *gin.Context) {
 	q := c.Request.URL.Query()["collector_id"]
 
@@ -162,7 +223,7 @@ func (s *Server) TargetsHandler(c *gin.Context) {
 		s.jsonHandler(c.Writer, displayData)
 
 	} else {
-		tgs := s.allocator.GetTargetsForCollectorAndJob(q[0], jobId)
+		tgs := s.allocator.GetTargetsForCollectorAndJob(s.collectorName(q[0]), jobId)
 		// Displays empty list if nothing matches
 		if len(tgs) == 0 {
 			s.jsonHandler(c.Writer, []interface{}{})
@@ -172,6 +233,27 @@ func (s *Server) TargetsHandler(c *gin.Context) {
 	}
 }
 
+// collectorName returns the name of the collector with the given id. The collectors are named after the namespace and
+// name of their pod, but collectors which only know the name of their pod can use it as id, as long as no other
+// collector has a pod with the same name.
+func (s *Server) collectorName(id string) string {
+	if strings.Contains(id, "/") {
+		return id
+	}
+	name := id
+	matches := 0
+	for collector := range s.allocator.Collectors() {
+		if strings.HasSuffix(collector, "/"+id) {
+			name = collector
+			matches++
+		}
+	}
+	if matches != 1 {
+		return id
+	}
+	return name
+}
+
 func (s *Server) errorHandler(w http.ResponseWriter, err error) {
 	w.WriteHeader(http.StatusInternalServerError)
 	s.jsonHandler(w, err)
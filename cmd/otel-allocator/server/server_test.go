@@ -15,6 +15,7 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -31,10 +32,16 @@ import (
 	"github.com/prometheus/prometheus/model/relabel"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 	"gopkg.in/yaml.v2"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
+	allocatorconfig "github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
 )
 
@@ -156,8 +163,8 @@ func TestServer_TargetsHandler(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			listenAddr := ":8080"
 			s := NewServer(logger, tt.args.allocator, nil, &listenAddr)
-			tt.args.allocator.SetCollectors(map[string]*allocation.Collector{"test-collector": {Name: "test-collector"}})
-			tt.args.allocator.SetTargets(tt.args.cMap)
+			tt.args.allocator.SetCollectors(context.Background(), map[string]*allocation.Collector{"test-collector": {Name: "test-collector"}})
+			tt.args.allocator.SetTargets(context.Background(), tt.args.cMap)
 			request := httptest.NewRequest("GET", fmt.Sprintf("/jobs/%s/targets?collector_id=%s", tt.args.job, tt.args.collector), nil)
 			w := httptest.NewRecorder()
 
@@ -180,6 +187,47 @@ func TestServer_TargetsHandler(t *testing.T) {
 	}
 }
 
+func TestServer_TargetsHandlerWithPodName(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		collectors []string
+		want       int
+	}{
+		{
+			name:       "unique pod name",
+			collectors: []string{"test-ns/test-collector"},
+			want:       1,
+		},
+		{
+			name:       "same pod name in multiple namespaces",
+			collectors: []string{"test-ns/test-collector", "other-ns/test-collector"},
+			want:       0,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			allocator, err := allocation.New("least-weighted", logger)
+			require.NoError(t, err)
+			collectors := map[string]*allocation.Collector{}
+			for _, name := range tt.collectors {
+				collectors[name] = allocation.NewCollector(name)
+			}
+			allocator.SetCollectors(context.Background(), collectors)
+			allocator.SetTargets(context.Background(), map[string]*target.Item{baseTargetItem.Hash(): baseTargetItem})
+			listenAddr := ":8080"
+			s := NewServer(logger, allocator, nil, &listenAddr)
+			request := httptest.NewRequest("GET", "/jobs/test-job/targets?collector_id=test-collector", nil)
+			w := httptest.NewRecorder()
+
+			s.server.Handler.ServeHTTP(w, request)
+
+			assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+			var items []*target.Item
+			require.NoError(t, json.NewDecoder(w.Result().Body).Decode(&items))
+			assert.Len(t, items, tt.want)
+		})
+	}
+}
+
 func TestServer_ScrapeConfigsHandler(t *testing.T) {
 	tests := []struct {
 		description   string
@@ -727,6 +775,65 @@ func TestServer_JobHandler(t *testing.T) {
 	}
 }
 
+func TestServer_StatusHandler(t *testing.T) {
+	tests := []struct {
+		description  string
+		err          error
+		expectedCode int
+	}{
+		{
+			description:  "successful reload",
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "rejected reload",
+			err:          fmt.Errorf("bad config"),
+			expectedCode: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			listenAddr := ":8080"
+			reloadStatus := allocatorconfig.NewReloadStatus()
+			reloadStatus.Record("EventSourceConfigMap", tc.err)
+			s := NewServer(logger, nil, nil, &listenAddr, WithReloadStatus(reloadStatus))
+			request := httptest.NewRequest("GET", "/status", nil)
+			w := httptest.NewRecorder()
+
+			s.server.Handler.ServeHTTP(w, request)
+			result := w.Result()
+
+			assert.Equal(t, tc.expectedCode, result.StatusCode)
+			bodyBytes, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+			status := map[string]allocatorconfig.SourceStatus{}
+			err = json.Unmarshal(bodyBytes, &status)
+			require.NoError(t, err)
+			assert.Equal(t, tc.err == nil, status["EventSourceConfigMap"].Successful)
+		})
+	}
+}
+
+func TestServer_TracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	listenAddr := ":8080"
+	reloadStatus := allocatorconfig.NewReloadStatus()
+	reloadStatus.Record("EventSourceConfigMap", fmt.Errorf("bad config"))
+	s := NewServer(logger, nil, nil, &listenAddr, WithReloadStatus(reloadStatus))
+	request := httptest.NewRequest("GET", "/status", nil)
+	w := httptest.NewRecorder()
+
+	s.server.Handler.ServeHTTP(w, request)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "/status", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusServiceUnavailable))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
 func newLink(jobName string) target.LinkJSON {
 	return target.LinkJSON{Link: fmt.Sprintf("/jobs/%s/targets", url.QueryEscape(jobName))}
 }
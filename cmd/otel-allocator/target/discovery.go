@@ -15,6 +15,12 @@
 package target
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -22,6 +28,8 @@ import (
 	"github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/discovery"
 	"github.com/prometheus/prometheus/model/relabel"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 
 	allocatorWatcher "github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/watcher"
 )
@@ -31,14 +39,64 @@ var (
 		Name: "opentelemetry_allocator_targets",
 		Help: "Number of targets discovered.",
 	}, []string{"job_name"})
+	jobConflicts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "opentelemetry_allocator_job_conflicts",
+		Help: "Number of scrape jobs skipped because a job with the same name is owned by another source.",
+	}, []string{"source"})
+	tracer = otel.Tracer("github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target")
+)
+
+const (
+	// SourceLabel is added to every target, holding the config source the target's job was loaded from.
+	SourceLabel model.LabelName = model.MetaLabelPrefix + "opentelemetry_allocator_source"
+	// TenantLabel is added to the targets of jobs generated from ServiceMonitors and PodMonitors, holding the
+	// namespace of the custom resource. It can be used in relabel configs to keep the targets of tenants apart.
+	TenantLabel model.LabelName = model.MetaLabelPrefix + "opentelemetry_allocator_tenant"
 )
 
+// JobOwner identifies where a scrape job was loaded from. Jobs generated from ServiceMonitors and PodMonitors
+// are additionally owned by the namespace and name of their custom resource.
+type JobOwner struct {
+	Source    allocatorWatcher.EventSource
+	Namespace string
+	Name      string
+}
+
+func (o JobOwner) String() string {
+	if o.Namespace == "" {
+		return o.Source.String()
+	}
+	return fmt.Sprintf("%s/%s/%s", o.Source, o.Namespace, o.Name)
+}
+
+func (o JobOwner) labels() model.LabelSet {
+	labels := model.LabelSet{SourceLabel: model.LabelValue(o.Source.String())}
+	if o.Namespace != "" {
+		labels[TenantLabel] = model.LabelValue(o.Namespace)
+	}
+	return labels
+}
+
+// ownerOf returns the owner of a job loaded from the given source.
+func ownerOf(source allocatorWatcher.EventSource, jobName string) JobOwner {
+	owner := JobOwner{Source: source}
+	if source == allocatorWatcher.EventSourcePrometheusCR {
+		// the prometheus operator names the jobs <kind>/<namespace>/<name>/<endpoint index>
+		if parts := strings.Split(jobName, "/"); len(parts) == 4 {
+			owner.Namespace, owner.Name = parts[1], parts[2]
+		}
+	}
+	return owner
+}
+
 type Discoverer struct {
 	log               logr.Logger
 	manager           *discovery.Manager
 	close             chan struct{}
+	mtx               sync.RWMutex
 	configsMap        map[allocatorWatcher.EventSource]*config.Config
 	jobToScrapeConfig map[string]*config.ScrapeConfig
+	jobOwners         map[string]JobOwner
 	hook              discoveryHook
 }
 
@@ -53,55 +111,121 @@ func NewDiscoverer(log logr.Logger, manager *discovery.Manager, hook discoveryHo
 		close:             make(chan struct{}),
 		configsMap:        make(map[allocatorWatcher.EventSource]*config.Config),
 		jobToScrapeConfig: make(map[string]*config.ScrapeConfig),
+		jobOwners:         make(map[string]JobOwner),
 		hook:              hook,
 	}
 }
 
 func (m *Discoverer) GetScrapeConfigs() map[string]*config.ScrapeConfig {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
 	return m.jobToScrapeConfig
 }
 
+// GetJobOwners returns the owner of every scrape job.
+func (m *Discoverer) GetJobOwners() map[string]JobOwner {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.jobOwners
+}
+
+// ApplyConfig replaces the configuration of the given source and applies the combined configuration of all
+// sources to the discovery manager. If the discovery manager rejects the result, the previous configuration of
+// the source is kept, so that discovery continues with the last good configuration.
+//
+// Every job is owned by the source it was loaded from. If several sources define a job with the same name, the
+// source loaded first in the order of the event sources keeps it, the config file taking precedence over the
+// Prometheus CRs. Jobs which are no longer defined by any source are removed.
 func (m *Discoverer) ApplyConfig(source allocatorWatcher.EventSource, cfg *config.Config) error {
-	m.configsMap[source] = cfg
+	if cfg == nil {
+		return fmt.Errorf("no configuration provided by %s", source)
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	configsMap := make(map[allocatorWatcher.EventSource]*config.Config, len(m.configsMap)+1)
+	for k, v := range m.configsMap {
+		configsMap[k] = v
+	}
+	configsMap[source] = cfg
+
+	sources := make([]allocatorWatcher.EventSource, 0, len(configsMap))
+	for k := range configsMap {
+		sources = append(sources, k)
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i] < sources[j] })
 
+	jobToScrapeConfig := make(map[string]*config.ScrapeConfig)
+	jobOwners := make(map[string]JobOwner)
 	discoveryCfg := make(map[string]discovery.Configs)
 	relabelCfg := make(map[string][]*relabel.Config)
 
-	for _, value := range m.configsMap {
-		for _, scrapeConfig := range value.ScrapeConfigs {
-			m.jobToScrapeConfig[scrapeConfig.JobName] = scrapeConfig
+	for _, s := range sources {
+		for _, scrapeConfig := range configsMap[s].ScrapeConfigs {
+			if owner, ok := jobOwners[scrapeConfig.JobName]; ok {
+				jobConflicts.WithLabelValues(s.String()).Inc()
+				m.log.Info("Skipping scrape job, a job with the same name is owned by another source",
+					"job", scrapeConfig.JobName, "source", s.String(), "owner", owner.String())
+				continue
+			}
+			jobOwners[scrapeConfig.JobName] = ownerOf(s, scrapeConfig.JobName)
+			jobToScrapeConfig[scrapeConfig.JobName] = scrapeConfig
 			discoveryCfg[scrapeConfig.JobName] = scrapeConfig.ServiceDiscoveryConfigs
 			relabelCfg[scrapeConfig.JobName] = scrapeConfig.RelabelConfigs
 		}
 	}
 
+	if err := m.manager.ApplyConfig(discoveryCfg); err != nil {
+		return err
+	}
+	for jobName := range m.jobOwners {
+		if _, ok := jobOwners[jobName]; !ok {
+			targetsDiscovered.DeleteLabelValues(jobName)
+		}
+	}
+	m.configsMap = configsMap
+	m.jobToScrapeConfig = jobToScrapeConfig
+	m.jobOwners = jobOwners
+
 	if m.hook != nil {
 		m.hook.SetConfig(relabelCfg)
 	}
-	return m.manager.ApplyConfig(discoveryCfg)
+	return nil
 }
 
-func (m *Discoverer) Watch(fn func(targets map[string]*Item)) error {
+// Watch calls fn with all the discovered targets whenever the discovery manager syncs, until Close is called. fn is
+// called with a context holding the span of the sync, so that the allocation of the targets is part of its trace.
+func (m *Discoverer) Watch(ctx context.Context, fn func(ctx context.Context, targets map[string]*Item)) error {
 	for {
 		select {
 		case <-m.close:
 			m.log.Info("Service Discovery watch event stopped: discovery manager closed")
 			return nil
 		case tsets := <-m.manager.SyncCh():
+			// the span covers handing the targets over, so that slow allocations show up in the sync duration
+			syncCtx, span := tracer.Start(ctx, "DiscoverySync")
 			targets := map[string]*Item{}
+			jobOwners := m.GetJobOwners()
 
 			for jobName, tgs := range tsets {
 				var count float64 = 0
+				var ownerLabels model.LabelSet
+				if owner, ok := jobOwners[jobName]; ok {
+					ownerLabels = owner.labels()
+				}
 				for _, tg := range tgs {
 					for _, t := range tg.Targets {
 						count++
-						item := NewItem(jobName, string(t[model.AddressLabel]), t.Merge(tg.Labels), "")
+						// the owner isn't part of the hash, so that a target keeps its collector when its job changes owner
+						item := newItem(jobName, string(t[model.AddressLabel]), t.Merge(tg.Labels), ownerLabels, "")
 						targets[item.Hash()] = item
 					}
 				}
 				targetsDiscovered.WithLabelValues(jobName).Set(count)
 			}
-			fn(targets)
+			span.SetAttributes(attribute.Int("discovery.jobs", len(tsets)), attribute.Int("discovery.targets", len(targets)))
+			fn(syncCtx, targets)
+			span.End()
 		}
 	}
 }
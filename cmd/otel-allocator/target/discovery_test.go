@@ -18,8 +18,11 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	gokitlog "github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	promconfig "github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/discovery"
 	"github.com/stretchr/testify/assert"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -64,7 +67,7 @@ func TestDiscovery(t *testing.T) {
 		assert.NoError(t, err)
 	}()
 	go func() {
-		err := manager.Watch(func(targets map[string]*Item) {
+		err := manager.Watch(context.Background(), func(_ context.Context, targets map[string]*Item) {
 			var result []string
 			for _, t := range targets {
 				result = append(result, t.TargetURL[0])
@@ -88,3 +91,82 @@ func TestDiscovery(t *testing.T) {
 		})
 	}
 }
+
+func staticScrapeConfig(jobName string, address string) *promconfig.ScrapeConfig {
+	return &promconfig.ScrapeConfig{
+		JobName: jobName,
+		ServiceDiscoveryConfigs: discovery.Configs{
+			discovery.StaticConfig{
+				{Targets: []model.LabelSet{{model.AddressLabel: model.LabelValue(address)}}, Source: "0"},
+			},
+		},
+	}
+}
+
+func TestDiscovery_JobOwnership(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	d := discovery.NewManager(ctx, gokitlog.NewNopLogger())
+	manager := NewDiscoverer(ctrl.Log.WithName("test"), d, nil)
+	defer close(manager.close)
+	defer cancelFunc()
+
+	results := make(chan map[string]*Item)
+	go func() {
+		err := d.Run()
+		assert.NoError(t, err)
+	}()
+	go func() {
+		err := manager.Watch(context.Background(), func(_ context.Context, targets map[string]*Item) {
+			results <- targets
+		})
+		assert.NoError(t, err)
+	}()
+
+	fileConfig := &promconfig.Config{ScrapeConfigs: []*promconfig.ScrapeConfig{
+		staticScrapeConfig("shared", "file.domain:1001"),
+	}}
+	crConfig := &promconfig.Config{ScrapeConfigs: []*promconfig.ScrapeConfig{
+		staticScrapeConfig("shared", "cr.domain:2001"),
+		staticScrapeConfig("serviceMonitor/tenant-a/app/0", "cr.domain:2002"),
+	}}
+	assert.NoError(t, manager.ApplyConfig(allocatorWatcher.EventSourcePrometheusCR, crConfig))
+	assert.NoError(t, manager.ApplyConfig(allocatorWatcher.EventSourceConfigMap, fileConfig))
+
+	// the config file keeps the conflicting job
+	assert.Equal(t, map[string]JobOwner{
+		"shared":                        {Source: allocatorWatcher.EventSourceConfigMap},
+		"serviceMonitor/tenant-a/app/0": {Source: allocatorWatcher.EventSourcePrometheusCR, Namespace: "tenant-a", Name: "app"},
+	}, manager.GetJobOwners())
+	assert.Equal(t, fileConfig.ScrapeConfigs[0], manager.GetScrapeConfigs()["shared"])
+
+	// the first sync may happen before both configurations are applied
+	var targets map[string]*Item
+	timeout := time.After(10 * time.Second)
+	for len(targets) != 2 {
+		select {
+		case targets = <-results:
+		case <-timeout:
+			t.Fatal("timed out waiting for the targets of both sources")
+		}
+	}
+	labelsByAddress := map[string]model.LabelSet{}
+	for _, item := range targets {
+		labelsByAddress[item.TargetURL[0]] = item.Labels
+		// a target keeps its hash when its job changes owner
+		withoutOwner := item.Labels.Clone()
+		delete(withoutOwner, SourceLabel)
+		delete(withoutOwner, TenantLabel)
+		assert.Equal(t, NewItem(item.JobName, item.TargetURL[0], withoutOwner, "").Hash(), item.Hash())
+	}
+	assert.Equal(t, model.LabelValue("EventSourceConfigMap"), labelsByAddress["file.domain:1001"][SourceLabel])
+	assert.NotContains(t, labelsByAddress["file.domain:1001"], TenantLabel)
+	assert.Equal(t, model.LabelValue("EventSourcePrometheusCR"), labelsByAddress["cr.domain:2002"][SourceLabel])
+	assert.Equal(t, model.LabelValue("tenant-a"), labelsByAddress["cr.domain:2002"][TenantLabel])
+
+	// jobs which are no longer defined are removed
+	assert.NoError(t, manager.ApplyConfig(allocatorWatcher.EventSourcePrometheusCR, &promconfig.Config{}))
+	assert.Equal(t, map[string]JobOwner{
+		"shared": {Source: allocatorWatcher.EventSourceConfigMap},
+	}, manager.GetJobOwners())
+	assert.Len(t, manager.GetScrapeConfigs(), 1)
+}
@@ -44,12 +44,22 @@ func (t *Item) Hash() string {
 // * Item fields must not be modified after creation.
 // * Item should only be made via its constructor, never directly.
 func NewItem(jobName string, targetURL string, label model.LabelSet, collectorName string) *Item {
+	return newItem(jobName, targetURL, label, nil, collectorName)
+}
+
+// newItem creates a new target item with additional labels, which aren't part of its hash: they can change without
+// the target being allocated again.
+func newItem(jobName string, targetURL string, label model.LabelSet, additionalLabels model.LabelSet, collectorName string) *Item {
+	labels := label
+	if len(additionalLabels) > 0 {
+		labels = label.Merge(additionalLabels)
+	}
 	return &Item{
 		JobName:       jobName,
 		Link:          LinkJSON{Link: fmt.Sprintf("/jobs/%s/targets", url.QueryEscape(jobName))},
 		hash:          jobName + targetURL + label.Fingerprint().String(),
 		TargetURL:     []string{targetURL},
-		Labels:        label,
+		Labels:        labels,
 		CollectorName: collectorName,
 	}
 }
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"math"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// convertMetricFamilies converts the gathered Prometheus metrics into OTLP metrics. Counters become cumulative
+// monotonic sums, histograms become cumulative histograms and gauges stay gauges. Summaries are skipped, as they
+// have no OTLP equivalent the exporter supports.
+func convertMetricFamilies(families []*dto.MetricFamily, start, now time.Time) []metricdata.Metrics {
+	var metrics []metricdata.Metrics
+	for _, family := range families {
+		var data metricdata.Aggregation
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			sum := metricdata.Sum[float64]{Temporality: metricdata.CumulativeTemporality, IsMonotonic: true}
+			for _, m := range family.GetMetric() {
+				sum.DataPoints = append(sum.DataPoints, metricdata.DataPoint[float64]{
+					Attributes: attributesOf(m), StartTime: start, Time: now, Value: m.GetCounter().GetValue(),
+				})
+			}
+			data = sum
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			gauge := metricdata.Gauge[float64]{}
+			for _, m := range family.GetMetric() {
+				value := m.GetGauge().GetValue()
+				if family.GetType() == dto.MetricType_UNTYPED {
+					value = m.GetUntyped().GetValue()
+				}
+				gauge.DataPoints = append(gauge.DataPoints, metricdata.DataPoint[float64]{
+					Attributes: attributesOf(m), Time: now, Value: value,
+				})
+			}
+			data = gauge
+		case dto.MetricType_HISTOGRAM:
+			histogram := metricdata.Histogram{Temporality: metricdata.CumulativeTemporality}
+			for _, m := range family.GetMetric() {
+				histogram.DataPoints = append(histogram.DataPoints, convertHistogram(m, start, now))
+			}
+			data = histogram
+		default:
+			continue
+		}
+		metrics = append(metrics, metricdata.Metrics{
+			Name:        family.GetName(),
+			Description: family.GetHelp(),
+			Data:        data,
+		})
+	}
+	return metrics
+}
+
+// convertHistogram converts the cumulative Prometheus buckets into the per bucket counts used by OTLP.
+func convertHistogram(m *dto.Metric, start, now time.Time) metricdata.HistogramDataPoint {
+	h := m.GetHistogram()
+	point := metricdata.HistogramDataPoint{
+		Attributes: attributesOf(m),
+		StartTime:  start,
+		Time:       now,
+		Count:      h.GetSampleCount(),
+		Sum:        h.GetSampleSum(),
+	}
+	var previous uint64
+	for _, bucket := range h.GetBucket() {
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			continue
+		}
+		point.Bounds = append(point.Bounds, bucket.GetUpperBound())
+		point.BucketCounts = append(point.BucketCounts, bucket.GetCumulativeCount()-previous)
+		previous = bucket.GetCumulativeCount()
+	}
+	// the last bucket has no upper bound and holds the remaining observations
+	point.BucketCounts = append(point.BucketCounts, h.GetSampleCount()-previous)
+	return point
+}
+
+func attributesOf(m *dto.Metric) attribute.Set {
+	attrs := make([]attribute.KeyValue, 0, len(m.GetLabel()))
+	for _, label := range m.GetLabel() {
+		attrs = append(attrs, attribute.String(label.GetName(), label.GetValue()))
+	}
+	return attribute.NewSet(attrs...)
+}
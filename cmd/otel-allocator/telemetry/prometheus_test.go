@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestConvertMetricFamilies(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_counter", Help: "A counter."}, []string{"source"})
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_gauge", Help: "A gauge."})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_histogram", Help: "A histogram.", Buckets: []float64{1, 5}})
+	summary := prometheus.NewSummary(prometheus.SummaryOpts{Name: "test_summary", Help: "A summary."})
+	registry.MustRegister(counter, gauge, histogram, summary)
+
+	counter.WithLabelValues("configmap").Add(3)
+	gauge.Set(7)
+	for _, v := range []float64{0.5, 2, 3, 10} {
+		histogram.Observe(v)
+	}
+	summary.Observe(1)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	start := time.Unix(1000, 0)
+	now := time.Unix(2000, 0)
+	metrics := convertMetricFamilies(families, start, now)
+
+	// the summary is skipped
+	require.Len(t, metrics, 3)
+
+	assert.Equal(t, "test_counter", metrics[0].Name)
+	assert.Equal(t, "A counter.", metrics[0].Description)
+	assert.Equal(t, metricdata.Sum[float64]{
+		Temporality: metricdata.CumulativeTemporality,
+		IsMonotonic: true,
+		DataPoints: []metricdata.DataPoint[float64]{
+			{Attributes: attribute.NewSet(attribute.String("source", "configmap")), StartTime: start, Time: now, Value: 3},
+		},
+	}, metrics[0].Data)
+
+	assert.Equal(t, "test_gauge", metrics[1].Name)
+	assert.Equal(t, metricdata.Gauge[float64]{
+		DataPoints: []metricdata.DataPoint[float64]{
+			{Attributes: attribute.NewSet(), Time: now, Value: 7},
+		},
+	}, metrics[1].Data)
+
+	assert.Equal(t, "test_histogram", metrics[2].Name)
+	assert.Equal(t, metricdata.Histogram{
+		Temporality: metricdata.CumulativeTemporality,
+		DataPoints: []metricdata.HistogramDataPoint{
+			{
+				Attributes:   attribute.NewSet(),
+				StartTime:    start,
+				Time:         now,
+				Count:        4,
+				Bounds:       []float64{1, 5},
+				BucketCounts: []uint64{1, 2, 1},
+				Sum:          15.5,
+			},
+		},
+	}, metrics[2].Data)
+}
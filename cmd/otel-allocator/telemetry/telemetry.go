@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package telemetry exports the target allocator's own metrics and traces using OTLP/HTTP.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
+)
+
+const (
+	serviceName = "opentelemetry-targetallocator"
+	scopeName   = "github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator"
+)
+
+// Telemetry exports the traces of the target allocator, and periodically pushes the metrics of the
+// Prometheus registry, so that the allocator can be monitored without scraping it.
+type Telemetry struct {
+	log             logr.Logger
+	gatherer        prometheus.Gatherer
+	tracerProvider  *sdktrace.TracerProvider
+	metricsExporter sdkmetric.Exporter
+	resource        *resource.Resource
+	interval        time.Duration
+	start           time.Time
+	close           chan struct{}
+}
+
+// New creates the OTLP exporters and registers the global tracer provider and propagator.
+func New(ctx context.Context, log logr.Logger, cfg config.TelemetryConfig) (*Telemetry, error) {
+	res, err := newResource(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	traceOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+	metricOpts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		traceOpts = append(traceOpts, otlptracehttp.WithInsecure())
+		metricOpts = append(metricOpts, otlpmetrichttp.WithInsecure())
+	}
+	if len(cfg.Headers) > 0 {
+		traceOpts = append(traceOpts, otlptracehttp.WithHeaders(cfg.Headers))
+		metricOpts = append(metricOpts, otlpmetrichttp.WithHeaders(cfg.Headers))
+	}
+
+	traceExporter, err := otlptracehttp.New(ctx, traceOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize otlp trace http exporter: %w", err)
+	}
+	metricsExporter, err := otlpmetrichttp.New(ctx, metricOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize otlp metric http exporter: %w", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(traceExporter),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return &Telemetry{
+		log:             log,
+		gatherer:        prometheus.DefaultGatherer,
+		tracerProvider:  tracerProvider,
+		metricsExporter: metricsExporter,
+		resource:        res,
+		interval:        cfg.GetInterval(),
+		start:           time.Now(),
+		close:           make(chan struct{}),
+	}, nil
+}
+
+func newResource(ctx context.Context) (*resource.Resource, error) {
+	attrs := []attribute.KeyValue{semconv.ServiceNameKey.String(serviceName)}
+	// the hostname is the pod name when running in a cluster
+	if hostname, err := os.Hostname(); err == nil {
+		attrs = append(attrs, semconv.ServiceInstanceIDKey.String(hostname))
+	}
+	return resource.New(ctx, resource.WithAttributes(attrs...), resource.WithFromEnv())
+}
+
+// Start exports the metrics every interval until Close is called. The metrics are exported one last time
+// and the exporters are shut down before returning.
+func (t *Telemetry) Start(ctx context.Context) error {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.close:
+			t.export(ctx)
+			return t.shutdown(ctx)
+		case <-ticker.C:
+			t.export(ctx)
+		}
+	}
+}
+
+func (t *Telemetry) Close() {
+	close(t.close)
+}
+
+func (t *Telemetry) export(ctx context.Context) {
+	families, err := t.gatherer.Gather()
+	if err != nil {
+		// Gather returns the metrics it could collect along with the error
+		t.log.Error(err, "Unable to gather all metrics")
+	}
+	metrics := metricdata.ResourceMetrics{
+		Resource: t.resource,
+		ScopeMetrics: []metricdata.ScopeMetrics{
+			{
+				Scope:   instrumentation.Scope{Name: scopeName},
+				Metrics: convertMetricFamilies(families, t.start, time.Now()),
+			},
+		},
+	}
+	if err = t.metricsExporter.Export(ctx, metrics); err != nil {
+		t.log.Error(err, "Unable to export metrics")
+	}
+}
+
+func (t *Telemetry) shutdown(ctx context.Context) error {
+	traceErr := t.tracerProvider.Shutdown(ctx)
+	metricsErr := t.metricsExporter.Shutdown(ctx)
+	if traceErr != nil {
+		return traceErr
+	}
+	return metricsErr
+}
@@ -21,15 +21,23 @@ import (
 	"sync"
 
 	"github.com/go-logr/logr"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	vpav1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -50,11 +58,17 @@ type OpenTelemetryCollectorReconciler struct {
 	muTasks sync.RWMutex
 }
 
+// collectorFinalizer delays the deletion of an instance until the delete hooks of the tasks have run.
+const collectorFinalizer = "opentelemetrycollector.opentelemetry.io/finalizer"
+
 // Task represents a reconciliation task to be executed by the reconciler.
 type Task struct {
 	Do          func(context.Context, reconcile.Params) error
 	Name        string
 	BailOnError bool
+	// Delete is an optional hook called when the instance is deleted. Owned objects are removed by the garbage
+	// collector, the hook cleans up what it doesn't cover, like cluster-scoped objects or objects in other namespaces.
+	Delete func(context.Context, reconcile.Params) error
 }
 
 // Params is the set of options to build a new openTelemetryCollectorReconciler.
@@ -95,7 +109,7 @@ func (r *OpenTelemetryCollectorReconciler) addRouteTask(plt platform.Platform, r
 	defer r.muTasks.Unlock()
 	// if exists and platform is openshift
 	if routesIdx == -1 && plt == platform.OpenShift {
-		r.tasks = append([]Task{{reconcile.Routes, "routes", true}}, r.tasks...)
+		r.tasks = append([]Task{{Do: reconcile.Routes, Name: "routes", BailOnError: true}}, r.tasks...)
 	}
 	return nil
 }
@@ -127,49 +141,75 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 	if len(r.tasks) == 0 {
 		r.tasks = []Task{
 			{
-				reconcile.ConfigMaps,
-				"config maps",
-				true,
+				Do:          reconcile.ConfigMaps,
+				Name:        "config maps",
+				BailOnError: true,
+				Delete:      reconcile.DeleteTAAssignmentsConfigMap,
+			},
+			{
+				Do:          reconcile.ServiceAccounts,
+				Name:        "service accounts",
+				BailOnError: true,
+			},
+			{
+				Do:          reconcile.Roles,
+				Name:        "roles",
+				BailOnError: true,
+			},
+			{
+				Do:          reconcile.RoleBindings,
+				Name:        "role bindings",
+				BailOnError: true,
 			},
 			{
-				reconcile.ServiceAccounts,
-				"service accounts",
-				true,
+				Do:          reconcile.Services,
+				Name:        "services",
+				BailOnError: true,
 			},
 			{
-				reconcile.Services,
-				"services",
-				true,
+				Do:          reconcile.ServiceMonitors,
+				Name:        "service monitors",
+				BailOnError: true,
 			},
 			{
-				reconcile.Deployments,
-				"deployments",
-				true,
+				Do:          reconcile.Deployments,
+				Name:        "deployments",
+				BailOnError: true,
 			},
 			{
-				reconcile.HorizontalPodAutoscalers,
-				"horizontal pod autoscalers",
-				true,
+				Do:          reconcile.HorizontalPodAutoscalers,
+				Name:        "horizontal pod autoscalers",
+				BailOnError: true,
 			},
 			{
-				reconcile.DaemonSets,
-				"daemon sets",
-				true,
+				Do:          reconcile.VerticalPodAutoscalers,
+				Name:        "vertical pod autoscalers",
+				BailOnError: true,
 			},
 			{
-				reconcile.StatefulSets,
-				"stateful sets",
-				true,
+				Do:          reconcile.PodDisruptionBudgets,
+				Name:        "pod disruption budgets",
+				BailOnError: true,
 			},
 			{
-				reconcile.Ingresses,
-				"ingresses",
-				true,
+				Do:          reconcile.DaemonSets,
+				Name:        "daemon sets",
+				BailOnError: true,
 			},
 			{
-				reconcile.Self,
-				"opentelemetry",
-				true,
+				Do:          reconcile.StatefulSets,
+				Name:        "stateful sets",
+				BailOnError: true,
+			},
+			{
+				Do:          reconcile.Ingresses,
+				Name:        "ingresses",
+				BailOnError: true,
+			},
+			{
+				Do:          reconcile.GatewayRoutes,
+				Name:        "gateway routes",
+				BailOnError: true,
 			},
 		}
 		r.config.RegisterPlatformChangeCallback(r.onPlatformChange)
@@ -181,6 +221,7 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/finalizers,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
@@ -201,6 +242,23 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// The finalizer is only needed when a task has a delete hook, otherwise it would only delay the deletion, and block
+	// it when the operator isn't running.
+	if instance.GetDeletionTimestamp() == nil {
+		hasDeleteTasks := r.hasDeleteTasks()
+		var updated bool
+		if hasDeleteTasks {
+			updated = controllerutil.AddFinalizer(&instance, collectorFinalizer)
+		} else {
+			updated = controllerutil.RemoveFinalizer(&instance, collectorFinalizer)
+		}
+		if updated {
+			if err := r.Update(ctx, &instance); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
 	params := reconcile.Params{
 		Config:   r.config,
 		Client:   r.Client,
@@ -210,6 +268,10 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 		Recorder: r.recorder,
 	}
 
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, r.finalize(ctx, params)
+	}
+
 	if err := r.RunTasks(ctx, params); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -217,10 +279,16 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 	return ctrl.Result{}, nil
 }
 
-// RunTasks runs all the tasks associated with this reconciler.
+// RunTasks runs all the tasks associated with this reconciler, and updates the status of the instance once they have
+// run. The first failed task is recorded in the Reconciled condition of the instance.
 func (r *OpenTelemetryCollectorReconciler) RunTasks(ctx context.Context, params reconcile.Params) error {
 	r.muTasks.RLock()
 	defer r.muTasks.RUnlock()
+	var (
+		failedTask string
+		failedErr  error
+		bailErr    error
+	)
 	for _, task := range r.tasks {
 		if err := task.Do(ctx, params); err != nil {
 			// If we get an error that occurs because a pod is being terminated, then exit this loop
@@ -229,12 +297,79 @@ func (r *OpenTelemetryCollectorReconciler) RunTasks(ctx context.Context, params
 				return nil
 			}
 			r.log.Error(err, fmt.Sprintf("failed to reconcile %s", task.Name))
+			if failedErr == nil {
+				failedTask, failedErr = task.Name, err
+			}
 			if task.BailOnError {
-				return err
+				bailErr = err
+				break
 			}
 		}
 	}
 
+	statusErr := r.updateStatus(ctx, params, failedTask, failedErr)
+	if bailErr != nil {
+		return bailErr
+	}
+	return statusErr
+}
+
+// hasDeleteTasks returns whether a task associated with this reconciler has a delete hook.
+func (r *OpenTelemetryCollectorReconciler) hasDeleteTasks() bool {
+	r.muTasks.RLock()
+	defer r.muTasks.RUnlock()
+	for _, task := range r.tasks {
+		if task.Delete != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// finalize runs the delete hooks of the tasks and removes the finalizer once all of them succeeded, which lets the
+// deletion of the instance proceed.
+func (r *OpenTelemetryCollectorReconciler) finalize(ctx context.Context, params reconcile.Params) error {
+	if !controllerutil.ContainsFinalizer(&params.Instance, collectorFinalizer) {
+		return nil
+	}
+	if err := r.RunDeleteTasks(ctx, params); err != nil {
+		return err
+	}
+	controllerutil.RemoveFinalizer(&params.Instance, collectorFinalizer)
+	return r.Update(ctx, &params.Instance)
+}
+
+// RunDeleteTasks runs the delete hooks of the tasks associated with this reconciler, in reverse order. All hooks are
+// run even if one of them fails, the first error is returned.
+func (r *OpenTelemetryCollectorReconciler) RunDeleteTasks(ctx context.Context, params reconcile.Params) error {
+	r.muTasks.RLock()
+	defer r.muTasks.RUnlock()
+	var firstErr error
+	for i := len(r.tasks) - 1; i >= 0; i-- {
+		task := r.tasks[i]
+		if task.Delete == nil {
+			continue
+		}
+		if err := task.Delete(ctx, params); err != nil {
+			r.log.Error(err, fmt.Sprintf("failed to clean up %s", task.Name))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// updateStatus updates the status of the instance after the tasks have run.
+func (r *OpenTelemetryCollectorReconciler) updateStatus(ctx context.Context, params reconcile.Params, failedTask string, failedErr error) error {
+	// there's no instance to update when the tasks are run without a client
+	if params.Client == nil {
+		return nil
+	}
+	if err := reconcile.UpdateStatus(ctx, params, failedTask, failedErr); err != nil {
+		r.log.Error(err, "failed to update the status")
+		return err
+	}
 	return nil
 }
 
@@ -248,11 +383,20 @@ func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) er
 		For(&v1alpha1.OpenTelemetryCollector{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.ServiceAccount{}).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
 		Owns(&corev1.Service{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.DaemonSet{}).
 		Owns(&appsv1.StatefulSet{})
 
+	switch r.config.PolicyVersion() {
+	case autodetect.PolicyVersionV1:
+		builder = builder.Owns(&policyv1.PodDisruptionBudget{})
+	case autodetect.PolicyVersionV1Beta1:
+		builder = builder.Owns(&policyv1beta1.PodDisruptionBudget{})
+	}
+
 	autoscalingVersion := r.config.AutoscalingVersion()
 	if autoscalingVersion == autodetect.AutoscalingVersionV2 {
 		builder = builder.Owns(&autoscalingv2.HorizontalPodAutoscaler{})
@@ -260,5 +404,20 @@ func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) er
 		builder = builder.Owns(&autoscalingv2beta2.HorizontalPodAutoscaler{})
 	}
 
+	if r.config.VPAAvailability() == autodetect.VPAAvailable {
+		builder = builder.Owns(&vpav1.VerticalPodAutoscaler{})
+	}
+
+	if r.config.PrometheusCRsAvailability() == autodetect.PrometheusCRsAvailable {
+		builder = builder.Owns(&monitoringv1.ServiceMonitor{})
+	}
+
+	switch r.config.GatewayAPIAvailability() {
+	case autodetect.GatewayAPIWithGRPCRoutesAvailable:
+		builder = builder.Owns(&gatewayv1beta1.HTTPRoute{}).Owns(&gatewayv1alpha2.GRPCRoute{})
+	case autodetect.GatewayAPIAvailable:
+		builder = builder.Owns(&gatewayv1beta1.HTTPRoute{})
+	}
+
 	return builder.Complete(r)
 }
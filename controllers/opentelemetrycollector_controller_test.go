@@ -25,6 +25,8 @@ import (
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/kubectl/pkg/scheme"
@@ -38,12 +40,13 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/controllers"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect/autodetecttest"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/reconcile"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/platform"
 )
 
 var logger = logf.Log.WithName("unit-tests")
-var mockAutoDetector = &mockAutoDetect{
+var mockAutoDetector = &autodetecttest.Mock{
 	HPAVersionFunc: func() (autodetect.AutoscalingVersion, error) {
 		return autodetect.AutoscalingVersionV2Beta2, nil
 	},
@@ -158,6 +161,8 @@ func TestNewObjectsOnReconciliation(t *testing.T) {
 
 	// cleanup
 	require.NoError(t, k8sClient.Delete(context.Background(), created))
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
 
 }
 
@@ -244,9 +249,60 @@ func TestNewStatefulSetObjectsOnReconciliation(t *testing.T) {
 
 	// cleanup
 	require.NoError(t, k8sClient.Delete(context.Background(), created))
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
 
 }
 
+func TestDeleteTargetAllocatorAssignmentsOnDeletion(t *testing.T) {
+	// prepare
+	cfg := config.New(config.WithAutoDetect(mockAutoDetector))
+	nsn := types.NamespacedName{Name: "my-persisted-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: testScheme,
+		Config: cfg,
+	})
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), created))
+
+	// the target allocator creates the config map on its own, without an owner reference
+	assignments := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-persisted-instance-targetallocator-assignments",
+			Namespace: nsn.Namespace,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), assignments))
+
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	existing := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, existing))
+	require.Contains(t, existing.Finalizers, "opentelemetrycollector.opentelemetry.io/finalizer")
+
+	// test
+	require.NoError(t, k8sClient.Delete(context.Background(), existing))
+	_, err = reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(assignments), &corev1.ConfigMap{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = k8sClient.Get(context.Background(), nsn, existing)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
 func TestContinueOnRecoverableFailure(t *testing.T) {
 	// prepare
 	taskCalled := false
@@ -330,6 +386,191 @@ func TestBreakOnUnrecoverableError(t *testing.T) {
 	assert.NoError(t, k8sClient.Delete(context.Background(), created))
 }
 
+func TestRecordFailedTaskInStatus(t *testing.T) {
+	// prepare
+	cfg := config.New()
+	nsn := types.NamespacedName{Name: "my-failing-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: scheme.Scheme,
+		Config: cfg,
+		Tasks: []controllers.Task{
+			{
+				Name: "should-fail",
+				Do: func(context.Context, reconcile.Params) error {
+					return errors.New("should fail")
+				},
+				BailOnError: false,
+			},
+			{
+				Name: "should-be-called",
+				Do: func(context.Context, reconcile.Params) error {
+					return nil
+				},
+			},
+		},
+	})
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+	}
+	err := k8sClient.Create(context.Background(), created)
+	require.NoError(t, err)
+
+	// test
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+	_, err = reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	assert.NoError(t, err)
+	actual := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, actual))
+	condition := meta.FindStatusCondition(actual.Status.Conditions, v1alpha1.ConditionTypeReconciled)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "ShouldFailFailed", condition.Reason)
+	assert.Equal(t, "failed to reconcile should-fail: should fail", condition.Message)
+
+	// cleanup
+	assert.NoError(t, k8sClient.Delete(context.Background(), created))
+}
+
+func TestRunDeleteTasksOnDeletion(t *testing.T) {
+	// prepare
+	cfg := config.New()
+	deleteCalled := false
+	nsn := types.NamespacedName{Name: "my-deleted-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: scheme.Scheme,
+		Config: cfg,
+		Tasks: []controllers.Task{
+			{
+				Name: "with-delete-hook",
+				Do: func(context.Context, reconcile.Params) error {
+					return nil
+				},
+				Delete: func(_ context.Context, params reconcile.Params) error {
+					assert.Equal(t, nsn.Name, params.Instance.Name)
+					deleteCalled = true
+					return nil
+				},
+			},
+		},
+	})
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), created))
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	existing := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, existing))
+	require.Contains(t, existing.Finalizers, "opentelemetrycollector.opentelemetry.io/finalizer")
+	assert.False(t, deleteCalled)
+
+	// test
+	require.NoError(t, k8sClient.Delete(context.Background(), existing))
+	_, err = reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	assert.True(t, deleteCalled)
+	err = k8sClient.Get(context.Background(), nsn, existing)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestRunDeleteTasksInReverseOrder(t *testing.T) {
+	// prepare
+	var called []string
+	expectedErr := errors.New("should fail")
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Log: logger,
+		Tasks: []controllers.Task{
+			{
+				Name: "first",
+				Delete: func(context.Context, reconcile.Params) error {
+					called = append(called, "first")
+					return nil
+				},
+			},
+			{
+				Name: "without-delete-hook",
+			},
+			{
+				Name: "should-fail",
+				Delete: func(context.Context, reconcile.Params) error {
+					called = append(called, "should-fail")
+					return expectedErr
+				},
+			},
+		},
+	})
+
+	// test
+	err := reconciler.RunDeleteTasks(context.Background(), reconcile.Params{})
+
+	// verify
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, []string{"should-fail", "first"}, called)
+}
+
+func TestRemoveFinalizerWithoutDeleteTasks(t *testing.T) {
+	// prepare
+	cfg := config.New()
+	nsn := types.NamespacedName{Name: "my-instance-without-delete-tasks", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: scheme.Scheme,
+		Config: cfg,
+		Tasks: []controllers.Task{
+			{
+				Name: "without-delete-hook",
+				Do: func(context.Context, reconcile.Params) error {
+					return nil
+				},
+			},
+		},
+	})
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       nsn.Name,
+			Namespace:  nsn.Namespace,
+			Finalizers: []string{"opentelemetrycollector.opentelemetry.io/finalizer"},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), created))
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+
+	// test
+	_, err := reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	existing := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, existing))
+	assert.Empty(t, existing.Finalizers)
+
+	// cleanup
+	assert.NoError(t, k8sClient.Delete(context.Background(), existing))
+}
+
 func TestSkipWhenInstanceDoesNotExist(t *testing.T) {
 	// prepare
 	cfg := config.New()
@@ -375,21 +616,3 @@ func TestRegisterWithManager(t *testing.T) {
 	// verify
 	assert.NoError(t, err)
 }
-
-var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
-
-type mockAutoDetect struct {
-	PlatformFunc   func() (platform.Platform, error)
-	HPAVersionFunc func() (autodetect.AutoscalingVersion, error)
-}
-
-func (m *mockAutoDetect) HPAVersion() (autodetect.AutoscalingVersion, error) {
-	return m.HPAVersionFunc()
-}
-
-func (m *mockAutoDetect) Platform() (platform.Platform, error) {
-	if m.PlatformFunc != nil {
-		return m.PlatformFunc()
-	}
-	return platform.Unknown, nil
-}
@@ -50,6 +50,10 @@ type Config struct {
 	platform                       platformStore
 	autoDetectFrequency            time.Duration
 	autoscalingVersion             autodetect.AutoscalingVersion
+	policyVersion                  autodetect.PolicyVersion
+	prometheusCRsAvailability      autodetect.PrometheusCRsAvailability
+	gatewayAPIAvailability         autodetect.GatewayAPIAvailability
+	vpaAvailability                autodetect.VPAAvailability
 }
 
 // New constructs a new configuration based on the given options.
@@ -63,6 +67,7 @@ func New(opts ...Option) Config {
 		platform:                      newPlatformWrapper(),
 		version:                       version.Get(),
 		autoscalingVersion:            autodetect.DefaultAutoscalingVersion,
+		policyVersion:                 autodetect.DefaultPolicyVersion,
 		onPlatformChange:              newOnChange(),
 	}
 	for _, opt := range opts {
@@ -85,6 +90,7 @@ func New(opts ...Option) Config {
 		autoInstrumentationDotNetImage: o.autoInstrumentationDotNetImage,
 		labelsFilter:                   o.labelsFilter,
 		autoscalingVersion:             o.autoscalingVersion,
+		policyVersion:                  o.policyVersion,
 	}
 }
 
@@ -132,6 +138,39 @@ func (c *Config) AutoDetect() error {
 	c.autoscalingVersion = hpaVersion
 	c.logger.V(2).Info("autoscaling version detected", "autoscaling-version", c.autoscalingVersion.String())
 
+	policyVersion, err := c.autoDetect.PDBVersion()
+	if err != nil {
+		return err
+	}
+	c.policyVersion = policyVersion
+	c.logger.V(2).Info("pod disruption budget version detected", "policy-version", c.policyVersion.String())
+
+	// the following APIs are optional, so failing to detect them must not stop the detection of the required ones
+
+	prometheusCRsAvailability, err := c.autoDetect.PrometheusCRsAvailability()
+	if err != nil {
+		c.logger.Error(err, "failed to detect the prometheus-crs availability, assuming it's not available")
+		prometheusCRsAvailability = autodetect.PrometheusCRsNotAvailable
+	}
+	c.prometheusCRsAvailability = prometheusCRsAvailability
+	c.logger.V(2).Info("prometheus CRs availability detected", "prometheus-crs", c.prometheusCRsAvailability.String())
+
+	gatewayAPIAvailability, err := c.autoDetect.GatewayAPIAvailability()
+	if err != nil {
+		c.logger.Error(err, "failed to detect the gateway-api availability, assuming it's not available")
+		gatewayAPIAvailability = autodetect.GatewayAPINotAvailable
+	}
+	c.gatewayAPIAvailability = gatewayAPIAvailability
+	c.logger.V(2).Info("gateway API availability detected", "gateway-api", c.gatewayAPIAvailability.String())
+
+	vpaAvailability, err := c.autoDetect.VPAAvailability()
+	if err != nil {
+		c.logger.Error(err, "failed to detect the vpa availability, assuming it's not available")
+		vpaAvailability = autodetect.VPANotAvailable
+	}
+	c.vpaAvailability = vpaAvailability
+	c.logger.V(2).Info("vertical pod autoscaler availability detected", "vpa", c.vpaAvailability.String())
+
 	return nil
 }
 
@@ -165,6 +204,28 @@ func (c *Config) AutoscalingVersion() autodetect.AutoscalingVersion {
 	return c.autoscalingVersion
 }
 
+// PolicyVersion represents the version of the policy API group used for PodDisruptionBudgets. PolicyVersionUnknown
+// means that they aren't available in the cluster.
+func (c *Config) PolicyVersion() autodetect.PolicyVersion {
+	return c.policyVersion
+}
+
+// PrometheusCRsAvailability represents whether the custom resources of the Prometheus operator, like the
+// ServiceMonitor, are available in the cluster.
+func (c *Config) PrometheusCRsAvailability() autodetect.PrometheusCRsAvailability {
+	return c.prometheusCRsAvailability
+}
+
+// GatewayAPIAvailability represents whether the HTTPRoutes and GRPCRoutes of the Gateway API are available in the cluster.
+func (c *Config) GatewayAPIAvailability() autodetect.GatewayAPIAvailability {
+	return c.gatewayAPIAvailability
+}
+
+// VPAAvailability represents whether the VerticalPodAutoscaler CRD is available in the cluster.
+func (c *Config) VPAAvailability() autodetect.VPAAvailability {
+	return c.vpaAvailability
+}
+
 // AutoInstrumentationJavaImage returns OpenTelemetry Java auto-instrumentation container image.
 func (c *Config) AutoInstrumentationJavaImage() string {
 	return c.autoInstrumentationJavaImage
@@ -15,6 +15,7 @@
 package config_test
 
 import (
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -24,6 +25,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect/autodetecttest"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/platform"
 )
 
@@ -44,7 +46,7 @@ func TestNewConfig(t *testing.T) {
 func TestOnPlatformChangeCallback(t *testing.T) {
 	// prepare
 	calledBack := false
-	mock := &mockAutoDetect{
+	mock := &autodetecttest.Mock{
 		PlatformFunc: func() (platform.Platform, error) {
 			return platform.OpenShift, nil
 		},
@@ -69,11 +71,100 @@ func TestOnPlatformChangeCallback(t *testing.T) {
 	assert.True(t, calledBack)
 }
 
+func TestPrometheusCRsAvailabilityAutoDetect(t *testing.T) {
+	// prepare
+	mock := &autodetecttest.Mock{
+		PrometheusCRsAvailabilityFunc: func() (autodetect.PrometheusCRsAvailability, error) {
+			return autodetect.PrometheusCRsAvailable, nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+
+	// sanity check
+	require.Equal(t, autodetect.PrometheusCRsNotAvailable, cfg.PrometheusCRsAvailability())
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, autodetect.PrometheusCRsAvailable, cfg.PrometheusCRsAvailability())
+}
+
+func TestGatewayAPIAvailabilityAutoDetect(t *testing.T) {
+	// prepare
+	mock := &autodetecttest.Mock{
+		GatewayAPIAvailabilityFunc: func() (autodetect.GatewayAPIAvailability, error) {
+			return autodetect.GatewayAPIWithGRPCRoutesAvailable, nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+
+	// sanity check
+	require.Equal(t, autodetect.GatewayAPINotAvailable, cfg.GatewayAPIAvailability())
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, autodetect.GatewayAPIWithGRPCRoutesAvailable, cfg.GatewayAPIAvailability())
+}
+
+func TestVPAAvailabilityAutoDetect(t *testing.T) {
+	// prepare
+	mock := &autodetecttest.Mock{
+		VPAAvailabilityFunc: func() (autodetect.VPAAvailability, error) {
+			return autodetect.VPAAvailable, nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+
+	// sanity check
+	require.Equal(t, autodetect.VPANotAvailable, cfg.VPAAvailability())
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, autodetect.VPAAvailable, cfg.VPAAvailability())
+}
+
+func TestOptionalAPIsAutoDetectFailure(t *testing.T) {
+	// prepare
+	mock := &autodetecttest.Mock{
+		PrometheusCRsAvailabilityFunc: func() (autodetect.PrometheusCRsAvailability, error) {
+			return autodetect.PrometheusCRsAvailable, fmt.Errorf("forbidden")
+		},
+		GatewayAPIAvailabilityFunc: func() (autodetect.GatewayAPIAvailability, error) {
+			return autodetect.GatewayAPIWithGRPCRoutesAvailable, fmt.Errorf("forbidden")
+		},
+		VPAAvailabilityFunc: func() (autodetect.VPAAvailability, error) {
+			return autodetect.VPAAvailable, fmt.Errorf("forbidden")
+		},
+		PlatformFunc: func() (platform.Platform, error) {
+			return platform.OpenShift, nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, platform.OpenShift, cfg.Platform())
+	assert.Equal(t, autodetect.PrometheusCRsNotAvailable, cfg.PrometheusCRsAvailability())
+	assert.Equal(t, autodetect.GatewayAPINotAvailable, cfg.GatewayAPIAvailability())
+	assert.Equal(t, autodetect.VPANotAvailable, cfg.VPAAvailability())
+}
+
 func TestAutoDetectInBackground(t *testing.T) {
 	// prepare
 	wg := &sync.WaitGroup{}
 	wg.Add(2)
-	mock := &mockAutoDetect{
+	mock := &autodetecttest.Mock{
 		PlatformFunc: func() (platform.Platform, error) {
 			wg.Done()
 			// returning Unknown will cause the auto-detection to keep trying to detect the platform
@@ -95,20 +186,3 @@ func TestAutoDetectInBackground(t *testing.T) {
 	// verify
 	wg.Wait()
 }
-
-var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
-
-type mockAutoDetect struct {
-	PlatformFunc func() (platform.Platform, error)
-}
-
-func (m *mockAutoDetect) HPAVersion() (autodetect.AutoscalingVersion, error) {
-	return autodetect.DefaultAutoscalingVersion, nil
-}
-
-func (m *mockAutoDetect) Platform() (platform.Platform, error) {
-	if m.PlatformFunc != nil {
-		return m.PlatformFunc()
-	}
-	return platform.Unknown, nil
-}
@@ -46,6 +46,7 @@ type options struct {
 	platform                       platformStore
 	autoDetectFrequency            time.Duration
 	autoscalingVersion             autodetect.AutoscalingVersion
+	policyVersion                  autodetect.PolicyVersion
 }
 
 func WithAutoDetect(a autodetect.AutoDetect) Option {
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package autodetecttest provides an implementation of the auto-detection for tests.
+package autodetecttest
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/platform"
+)
+
+var _ autodetect.AutoDetect = (*Mock)(nil)
+
+// Mock returns the results of the functions which are set, and the defaults of a cluster without any optional API
+// otherwise.
+type Mock struct {
+	PlatformFunc                  func() (platform.Platform, error)
+	HPAVersionFunc                func() (autodetect.AutoscalingVersion, error)
+	PrometheusCRsAvailabilityFunc func() (autodetect.PrometheusCRsAvailability, error)
+	GatewayAPIAvailabilityFunc    func() (autodetect.GatewayAPIAvailability, error)
+	VPAAvailabilityFunc           func() (autodetect.VPAAvailability, error)
+	PDBVersionFunc                func() (autodetect.PolicyVersion, error)
+}
+
+func (m *Mock) HPAVersion() (autodetect.AutoscalingVersion, error) {
+	if m.HPAVersionFunc != nil {
+		return m.HPAVersionFunc()
+	}
+	return autodetect.DefaultAutoscalingVersion, nil
+}
+
+func (m *Mock) Platform() (platform.Platform, error) {
+	if m.PlatformFunc != nil {
+		return m.PlatformFunc()
+	}
+	return platform.Unknown, nil
+}
+
+func (m *Mock) PrometheusCRsAvailability() (autodetect.PrometheusCRsAvailability, error) {
+	if m.PrometheusCRsAvailabilityFunc != nil {
+		return m.PrometheusCRsAvailabilityFunc()
+	}
+	return autodetect.PrometheusCRsNotAvailable, nil
+}
+
+func (m *Mock) GatewayAPIAvailability() (autodetect.GatewayAPIAvailability, error) {
+	if m.GatewayAPIAvailabilityFunc != nil {
+		return m.GatewayAPIAvailabilityFunc()
+	}
+	return autodetect.GatewayAPINotAvailable, nil
+}
+
+func (m *Mock) VPAAvailability() (autodetect.VPAAvailability, error) {
+	if m.VPAAvailabilityFunc != nil {
+		return m.VPAAvailabilityFunc()
+	}
+	return autodetect.VPANotAvailable, nil
+}
+
+func (m *Mock) PDBVersion() (autodetect.PolicyVersion, error) {
+	if m.PDBVersionFunc != nil {
+		return m.PDBVersionFunc()
+	}
+	return autodetect.DefaultPolicyVersion, nil
+}
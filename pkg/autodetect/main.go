@@ -19,6 +19,7 @@ import (
 	"errors"
 	"sort"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 
@@ -31,6 +32,10 @@ var _ AutoDetect = (*autoDetect)(nil)
 type AutoDetect interface {
 	Platform() (platform.Platform, error)
 	HPAVersion() (AutoscalingVersion, error)
+	PrometheusCRsAvailability() (PrometheusCRsAvailability, error)
+	GatewayAPIAvailability() (GatewayAPIAvailability, error)
+	VPAAvailability() (VPAAvailability, error)
+	PDBVersion() (PolicyVersion, error)
 }
 
 type autoDetect struct {
@@ -47,6 +52,47 @@ const (
 
 const DefaultAutoscalingVersion = AutoscalingVersionV2
 
+// PolicyVersion represents the version of the policy API group in which PodDisruptionBudgets are served.
+type PolicyVersion int
+
+const (
+	PolicyVersionV1 PolicyVersion = iota
+	PolicyVersionV1Beta1
+	// PolicyVersionUnknown means that PodDisruptionBudgets aren't served at all.
+	PolicyVersionUnknown
+)
+
+const DefaultPolicyVersion = PolicyVersionV1
+
+const gatewayAPIGroup = "gateway.networking.k8s.io"
+
+// PrometheusCRsAvailability represents whether the custom resources of the Prometheus operator are available.
+type PrometheusCRsAvailability int
+
+const (
+	PrometheusCRsNotAvailable PrometheusCRsAvailability = iota
+	PrometheusCRsAvailable
+)
+
+// VPAAvailability represents whether the custom resources of the Vertical Pod Autoscaler are available.
+type VPAAvailability int
+
+const (
+	VPANotAvailable VPAAvailability = iota
+	VPAAvailable
+)
+
+// GatewayAPIAvailability represents which routes of the Gateway API are available.
+type GatewayAPIAvailability int
+
+const (
+	GatewayAPINotAvailable GatewayAPIAvailability = iota
+	// GatewayAPIAvailable means that HTTPRoutes are available, but GRPCRoutes aren't.
+	GatewayAPIAvailable
+	// GatewayAPIWithGRPCRoutesAvailable means that both HTTPRoutes and the experimental GRPCRoutes are available.
+	GatewayAPIWithGRPCRoutesAvailable
+)
+
 // New creates a new auto-detection worker, using the given client when talking to the current cluster.
 func New(restConfig *rest.Config) (AutoDetect, error) {
 	dcl, err := discovery.NewDiscoveryClientForConfig(restConfig)
@@ -105,6 +151,113 @@ func (a *autoDetect) HPAVersion() (AutoscalingVersion, error) {
 	return AutoscalingVersionUnknown, errors.New("Failed to find apiGroup autoscaling")
 }
 
+// PrometheusCRsAvailability checks whether the monitoring.coreos.com API group of the Prometheus operator is served.
+func (a *autoDetect) PrometheusCRsAvailability() (PrometheusCRsAvailability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return PrometheusCRsNotAvailable, err
+	}
+
+	for _, apiGroup := range apiList.Groups {
+		if apiGroup.Name == "monitoring.coreos.com" {
+			return PrometheusCRsAvailable, nil
+		}
+	}
+
+	return PrometheusCRsNotAvailable, nil
+}
+
+// GatewayAPIAvailability checks whether the HTTPRoutes and GRPCRoutes of the gateway.networking.k8s.io API group
+// are served. GRPCRoutes are only part of the experimental channel of the Gateway API.
+func (a *autoDetect) GatewayAPIAvailability() (GatewayAPIAvailability, error) {
+	httpRoutes, err := a.servesResource(gatewayAPIGroup+"/v1beta1", "httproutes")
+	if err != nil || !httpRoutes {
+		return GatewayAPINotAvailable, err
+	}
+
+	grpcRoutes, err := a.servesResource(gatewayAPIGroup+"/v1alpha2", "grpcroutes")
+	if err != nil {
+		return GatewayAPINotAvailable, err
+	}
+	if grpcRoutes {
+		return GatewayAPIWithGRPCRoutesAvailable, nil
+	}
+	return GatewayAPIAvailable, nil
+}
+
+func (a *autoDetect) servesResource(groupVersion, resource string) (bool, error) {
+	resources, err := a.dcl.ServerResourcesForGroupVersion(groupVersion)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (g GatewayAPIAvailability) String() string {
+	switch g {
+	case GatewayAPIAvailable:
+		return "available"
+	case GatewayAPIWithGRPCRoutesAvailable:
+		return "available with grpc routes"
+	}
+	return "not available"
+}
+
+// VPAAvailability checks whether the VerticalPodAutoscalers of the autoscaling.k8s.io API group are served.
+func (a *autoDetect) VPAAvailability() (VPAAvailability, error) {
+	vpas, err := a.servesResource("autoscaling.k8s.io/v1", "verticalpodautoscalers")
+	if err != nil || !vpas {
+		return VPANotAvailable, err
+	}
+	return VPAAvailable, nil
+}
+
+// PDBVersion returns the version of the policy API group serving PodDisruptionBudgets, preferring v1 over v1beta1.
+func (a *autoDetect) PDBVersion() (PolicyVersion, error) {
+	for _, version := range []PolicyVersion{PolicyVersionV1, PolicyVersionV1Beta1} {
+		served, err := a.servesResource("policy/"+version.String(), "poddisruptionbudgets")
+		if err != nil {
+			return PolicyVersionUnknown, err
+		}
+		if served {
+			return version, nil
+		}
+	}
+	return PolicyVersionUnknown, nil
+}
+
+func (v PolicyVersion) String() string {
+	switch v {
+	case PolicyVersionV1:
+		return "v1"
+	case PolicyVersionV1Beta1:
+		return "v1beta1"
+	}
+	return "unknown"
+}
+
+func (v VPAAvailability) String() string {
+	if v == VPAAvailable {
+		return "available"
+	}
+	return "not available"
+}
+
+func (p PrometheusCRsAvailability) String() string {
+	if p == PrometheusCRsAvailable {
+		return "available"
+	}
+	return "not available"
+}
+
 func (v AutoscalingVersion) String() string {
 	switch v {
 	case AutoscalingVersionV2:
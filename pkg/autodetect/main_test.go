@@ -89,6 +89,219 @@ func TestUnknownPlatformOnError(t *testing.T) {
 	assert.Equal(t, platform.Unknown, plt)
 }
 
+func TestDetectPrometheusCRsBasedOnAvailableAPIGroups(t *testing.T) {
+	for _, tt := range []struct {
+		apiGroupList *metav1.APIGroupList
+		expected     autodetect.PrometheusCRsAvailability
+	}{
+		{
+			&metav1.APIGroupList{},
+			autodetect.PrometheusCRsNotAvailable,
+		},
+		{
+			&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name: "monitoring.coreos.com",
+					},
+				},
+			},
+			autodetect.PrometheusCRsAvailable,
+		},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			output, err := json.Marshal(tt.apiGroupList)
+			require.NoError(t, err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(output)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		autoDetect, err := autodetect.New(&rest.Config{Host: server.URL})
+		require.NoError(t, err)
+
+		// test
+		availability, err := autoDetect.PrometheusCRsAvailability()
+
+		// verify
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, availability)
+	}
+}
+
+func TestDetectGatewayAPIBasedOnServedResources(t *testing.T) {
+	for _, tt := range []struct {
+		desc      string
+		resources map[string]*metav1.APIResourceList
+		expected  autodetect.GatewayAPIAvailability
+	}{
+		{
+			desc:      "no gateway api",
+			resources: map[string]*metav1.APIResourceList{},
+			expected:  autodetect.GatewayAPINotAvailable,
+		},
+		{
+			desc: "http routes only",
+			resources: map[string]*metav1.APIResourceList{
+				"/apis/gateway.networking.k8s.io/v1beta1": {
+					GroupVersion: "gateway.networking.k8s.io/v1beta1",
+					APIResources: []metav1.APIResource{{Name: "gateways"}, {Name: "httproutes"}},
+				},
+			},
+			expected: autodetect.GatewayAPIAvailable,
+		},
+		{
+			desc: "http and grpc routes",
+			resources: map[string]*metav1.APIResourceList{
+				"/apis/gateway.networking.k8s.io/v1beta1": {
+					GroupVersion: "gateway.networking.k8s.io/v1beta1",
+					APIResources: []metav1.APIResource{{Name: "gateways"}, {Name: "httproutes"}},
+				},
+				"/apis/gateway.networking.k8s.io/v1alpha2": {
+					GroupVersion: "gateway.networking.k8s.io/v1alpha2",
+					APIResources: []metav1.APIResource{{Name: "grpcroutes"}},
+				},
+			},
+			expected: autodetect.GatewayAPIWithGRPCRoutesAvailable,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				list, ok := tt.resources[req.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				output, err := json.Marshal(list)
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL})
+			require.NoError(t, err)
+
+			// test
+			availability, err := autoDetect.GatewayAPIAvailability()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, availability)
+		})
+	}
+}
+
+func TestDetectVPABasedOnServedResources(t *testing.T) {
+	for _, tt := range []struct {
+		desc      string
+		resources *metav1.APIResourceList
+		expected  autodetect.VPAAvailability
+	}{
+		{
+			desc:     "no vpa",
+			expected: autodetect.VPANotAvailable,
+		},
+		{
+			desc: "vpa",
+			resources: &metav1.APIResourceList{
+				GroupVersion: "autoscaling.k8s.io/v1",
+				APIResources: []metav1.APIResource{{Name: "verticalpodautoscalers"}},
+			},
+			expected: autodetect.VPAAvailable,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if tt.resources == nil || req.URL.Path != "/apis/autoscaling.k8s.io/v1" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				output, err := json.Marshal(tt.resources)
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL})
+			require.NoError(t, err)
+
+			// test
+			availability, err := autoDetect.VPAAvailability()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, availability)
+		})
+	}
+}
+
+func TestDetectPDBVersionBasedOnServedResources(t *testing.T) {
+	for _, tt := range []struct {
+		desc      string
+		resources *metav1.APIResourceList
+		expected  autodetect.PolicyVersion
+	}{
+		{
+			desc:     "no pdb",
+			expected: autodetect.PolicyVersionUnknown,
+		},
+		{
+			desc: "v1",
+			resources: &metav1.APIResourceList{
+				GroupVersion: "policy/v1",
+				APIResources: []metav1.APIResource{{Name: "poddisruptionbudgets"}},
+			},
+			expected: autodetect.PolicyVersionV1,
+		},
+		{
+			desc: "v1beta1",
+			resources: &metav1.APIResourceList{
+				GroupVersion: "policy/v1beta1",
+				APIResources: []metav1.APIResource{{Name: "poddisruptionbudgets"}},
+			},
+			expected: autodetect.PolicyVersionV1Beta1,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if tt.resources == nil || req.URL.Path != "/apis/"+tt.resources.GroupVersion {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				output, err := json.Marshal(tt.resources)
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL})
+			require.NoError(t, err)
+
+			// test
+			version, err := autoDetect.PDBVersion()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, version)
+		})
+	}
+}
+
 func TestAutoscalingVersionToString(t *testing.T) {
 	assert.Equal(t, "v2", autodetect.AutoscalingVersionV2.String())
 	assert.Equal(t, "v2beta2", autodetect.AutoscalingVersionV2Beta2.String())
@@ -30,6 +30,15 @@ var (
 
 	// ErrReceiversNotAMap indicates that the receivers property isn't a map of values.
 	ErrReceiversNotAMap = errors.New("receivers property in the configuration doesn't contain valid receivers")
+
+	// ErrExportersNotAMap indicates that the exporters property isn't a map of values.
+	ErrExportersNotAMap = errors.New("exporters property in the configuration doesn't contain valid exporters")
+
+	// ErrExtensionsNotAMap indicates that the extensions property isn't a map of values.
+	ErrExtensionsNotAMap = errors.New("extensions property in the configuration doesn't contain valid extensions")
+
+	// ErrConnectorsNotAMap indicates that the connectors property isn't a map of values.
+	ErrConnectorsNotAMap = errors.New("connectors property in the configuration doesn't contain valid connectors")
 )
 
 // ConfigToReceiverPorts converts the incoming configuration object into a set of service ports required by the receivers.
@@ -97,3 +106,98 @@ func ConfigToReceiverPorts(logger logr.Logger, config map[interface{}]interface{
 
 	return ports, nil
 }
+
+// ConfigToExporterPorts converts the incoming configuration object into a set of service ports required by the
+// exporters which are part of a pipeline, like the endpoint of the prometheus exporter. The ports are named after
+// the exporters, in the same way as for the receivers.
+func ConfigToExporterPorts(logger logr.Logger, config map[interface{}]interface{}) ([]corev1.ServicePort, error) {
+	exporters, err := componentsOf(config, "exporters", ErrExportersNotAMap)
+	if err != nil {
+		return nil, err
+	}
+	return componentPorts(logger, parser.ComponentKindExporter, exporters, GetEnabledExporters(logger, config)), nil
+}
+
+// ConfigToConnectorPorts converts the incoming configuration object into a set of service ports required by the
+// connectors which are part of a pipeline. Connectors are exposed along with the exporters.
+func ConfigToConnectorPorts(logger logr.Logger, config map[interface{}]interface{}) ([]corev1.ServicePort, error) {
+	connectors, err := componentsOf(config, "connectors", ErrConnectorsNotAMap)
+	if err != nil {
+		return nil, err
+	}
+	return componentPorts(logger, parser.ComponentKindConnector, connectors, GetEnabledConnectors(logger, config)), nil
+}
+
+// ConfigToExtensionPorts converts the incoming configuration object into a set of service ports required by the
+// extensions which are enabled in the service section, like the health_check or zpages extensions.
+func ConfigToExtensionPorts(logger logr.Logger, config map[interface{}]interface{}) ([]corev1.ServicePort, error) {
+	extensions, err := componentsOf(config, "extensions", ErrExtensionsNotAMap)
+	if err != nil {
+		return nil, err
+	}
+	return componentPorts(logger, parser.ComponentKindExtension, extensions, GetEnabledExtensions(logger, config)), nil
+}
+
+// AppendUniquePorts appends the additional ports to the given ones, skipping the ports whose number or name is already
+// taken, e.g. an exporter listening on the port of a receiver. The given ports have precedence.
+func AppendUniquePorts(logger logr.Logger, ports []corev1.ServicePort, additional ...corev1.ServicePort) []corev1.ServicePort {
+	numbers := map[int32]bool{}
+	names := map[string]bool{}
+	for _, p := range ports {
+		numbers[p.Port] = true
+		names[p.Name] = true
+	}
+	for _, p := range additional {
+		if numbers[p.Port] || names[p.Name] {
+			logger.Info("port conflicts with another port of the configuration, skipping", "port.name", p.Name, "port.num", p.Port)
+			continue
+		}
+		numbers[p.Port] = true
+		names[p.Name] = true
+		ports = append(ports, p)
+	}
+	return ports
+}
+
+// componentsOf returns the components configured in the given section, the section is optional.
+func componentsOf(config map[interface{}]interface{}, section string, errNotAMap error) (map[interface{}]interface{}, error) {
+	property, ok := config[section]
+	if !ok || property == nil {
+		return map[interface{}]interface{}{}, nil
+	}
+	components, ok := property.(map[interface{}]interface{})
+	if !ok {
+		return nil, errNotAMap
+	}
+	return components, nil
+}
+
+func componentPorts(logger logr.Logger, kind parser.ComponentKind, components map[interface{}]interface{}, enabled map[interface{}]bool) []corev1.ServicePort {
+	ports := []corev1.ServicePort{}
+	for key, val := range components {
+		if !enabled[key] {
+			continue
+		}
+		name, ok := key.(string)
+		if !ok {
+			continue
+		}
+		component, ok := val.(map[interface{}]interface{})
+		if !ok {
+			component = map[interface{}]interface{}{}
+		}
+
+		componentPorts, err := parser.ComponentFor(logger, kind, name, component).Ports()
+		if err != nil {
+			logger.Error(err, "parser has returned an error", "kind", kind, "component", name)
+			continue
+		}
+		ports = append(ports, componentPorts...)
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Name < ports[j].Name
+	})
+
+	return ports
+}
@@ -102,14 +102,135 @@ func TestExtractPortsFromConfig(t *testing.T) {
 		{Name: "jaeger-thrift-binary", Protocol: "UDP", Port: 6833},
 		{Name: "jaeger-thrift-compact", Protocol: "UDP", Port: 6831},
 		{Name: "otlp-2-grpc", AppProtocol: &grpcAppProtocol, Protocol: "TCP", Port: 55555},
-		{Name: "otlp-grpc", AppProtocol: &grpcAppProtocol, Port: 4317, TargetPort: targetPort4317},
-		{Name: "otlp-http", AppProtocol: &httpAppProtocol, Port: 4318, TargetPort: targetPort4318},
-		{Name: "otlp-http-legacy", AppProtocol: &httpAppProtocol, Port: 55681, TargetPort: targetPort4318},
+		{Name: "otlp-grpc", AppProtocol: &grpcAppProtocol, Protocol: "TCP", Port: 4317, TargetPort: targetPort4317},
+		{Name: "otlp-http", AppProtocol: &httpAppProtocol, Protocol: "TCP", Port: 4318, TargetPort: targetPort4318},
+		{Name: "otlp-http-legacy", AppProtocol: &httpAppProtocol, Protocol: "TCP", Port: 55681, TargetPort: targetPort4318},
 		{Name: "zipkin", AppProtocol: &httpAppProtocol, Protocol: "TCP", Port: 9411},
 	}
 	assert.ElementsMatch(t, expectedPorts, ports)
 }
 
+func TestExtractExporterAndExtensionPortsFromConfig(t *testing.T) {
+	// prepare
+	configStr := `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  otlp:
+    endpoint: "otel-gateway:4317"
+  prometheus:
+    endpoint: "0.0.0.0:8889"
+  prometheus/unused:
+    endpoint: "0.0.0.0:8890"
+extensions:
+  health_check:
+  zpages:
+    endpoint: "0.0.0.0:55679"
+  pprof:
+service:
+  extensions: [health_check, zpages, pprof]
+  pipelines:
+    metrics:
+      receivers: [otlp]
+      exporters: [otlp, prometheus]
+`
+	config, err := adapters.ConfigFromString(configStr)
+	require.NoError(t, err)
+
+	// test
+	exporterPorts, err := adapters.ConfigToExporterPorts(logger, config)
+	assert.NoError(t, err)
+	extensionPorts, err := adapters.ConfigToExtensionPorts(logger, config)
+	assert.NoError(t, err)
+
+	// verify
+	assert.Equal(t, []corev1.ServicePort{
+		{Name: "prometheus", Port: 8889, Protocol: corev1.ProtocolTCP},
+	}, exporterPorts)
+	assert.Equal(t, []corev1.ServicePort{
+		{Name: "health-check", Port: 13133, Protocol: corev1.ProtocolTCP},
+		{Name: "zpages", Port: 55679, Protocol: corev1.ProtocolTCP},
+	}, extensionPorts)
+}
+
+func TestExtractConnectorPortsFromConfig(t *testing.T) {
+	// prepare
+	configStr := `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  logging:
+connectors:
+  spanmetrics:
+  forward:
+  count/unused:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [spanmetrics, forward]
+    metrics:
+      receivers: [spanmetrics]
+      exporters: [logging]
+    traces/2:
+      receivers: [forward]
+      exporters: [logging]
+`
+	config, err := adapters.ConfigFromString(configStr)
+	require.NoError(t, err)
+
+	// test
+	enabled := adapters.GetEnabledConnectors(logger, config)
+	ports, err := adapters.ConfigToConnectorPorts(logger, config)
+
+	// verify
+	assert.Equal(t, map[interface{}]bool{"spanmetrics": true, "forward": true}, enabled)
+	assert.NoError(t, err)
+	assert.Empty(t, ports)
+
+	_, err = adapters.ConfigToConnectorPorts(logger, map[interface{}]interface{}{"connectors": "invalid"})
+	assert.ErrorIs(t, err, adapters.ErrConnectorsNotAMap)
+}
+
+func TestAppendUniquePorts(t *testing.T) {
+	receiverPorts := []corev1.ServicePort{
+		{Name: "otlp-grpc", Port: 4317},
+		{Name: "prometheus", Port: 9090},
+	}
+	additional := []corev1.ServicePort{
+		{Name: "exporter-same-port", Port: 4317},
+		{Name: "prometheus", Port: 8889},
+		{Name: "health-check", Port: 13133},
+		{Name: "health-check-2", Port: 13133},
+	}
+
+	ports := adapters.AppendUniquePorts(logger, receiverPorts, additional...)
+
+	assert.Equal(t, []corev1.ServicePort{
+		{Name: "otlp-grpc", Port: 4317},
+		{Name: "prometheus", Port: 9090},
+		{Name: "health-check", Port: 13133},
+	}, ports)
+}
+
+func TestNoExportersOrExtensions(t *testing.T) {
+	config, err := adapters.ConfigFromString(portConfigStr)
+	require.NoError(t, err)
+
+	exporterPorts, err := adapters.ConfigToExporterPorts(logger, config)
+	assert.NoError(t, err)
+	assert.Empty(t, exporterPorts)
+
+	extensionPorts, err := adapters.ConfigToExtensionPorts(logger, config)
+	assert.NoError(t, err)
+	assert.Empty(t, extensionPorts)
+
+	_, err = adapters.ConfigToExtensionPorts(logger, map[interface{}]interface{}{"extensions": "invalid"})
+	assert.ErrorIs(t, err, adapters.ErrExtensionsNotAMap)
+}
+
 func TestNoPortsParsed(t *testing.T) {
 	for _, tt := range []struct {
 		expected  error
@@ -109,3 +109,75 @@ func GetEnabledReceivers(_ logr.Logger, config map[interface{}]interface{}) map[
 	}
 	return availableReceivers
 }
+
+// GetEnabledExporters returns all exporters which are part of at least one pipeline as a true flag set. As connectors
+// are listed among the exporters of the pipelines, the set contains the enabled connectors too.
+func GetEnabledExporters(_ logr.Logger, config map[interface{}]interface{}) map[interface{}]bool {
+	return componentsInPipelines(config, "exporters")
+}
+
+// GetEnabledConnectors returns all connectors which are part of at least one pipeline as a true flag set. A connector
+// is used as an exporter in a pipeline and as a receiver in another one.
+func GetEnabledConnectors(_ logr.Logger, config map[interface{}]interface{}) map[interface{}]bool {
+	enabled := map[interface{}]bool{}
+	connectors, ok := config["connectors"].(map[interface{}]interface{})
+	if !ok {
+		return enabled
+	}
+	exporters := componentsInPipelines(config, "exporters")
+	receivers := componentsInPipelines(config, "receivers")
+	for connector := range connectors {
+		if exporters[connector] || receivers[connector] {
+			enabled[connector] = true
+		}
+	}
+	return enabled
+}
+
+// componentsInPipelines returns the components listed in the given section of the pipelines as a true flag set.
+func componentsInPipelines(config map[interface{}]interface{}, section string) map[interface{}]bool {
+	enabled := map[interface{}]bool{}
+	cfgService, withService := config["service"].(map[interface{}]interface{})
+	if !withService {
+		return enabled
+	}
+	pipelines, withPipelines := cfgService["pipelines"].(map[interface{}]interface{})
+	if !withPipelines {
+		return enabled
+	}
+	for _, pipelineCfg := range pipelines {
+		pipelineDesc, ok := pipelineCfg.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		components, ok := pipelineDesc[section].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, component := range components {
+			if componentKey, ok := component.(string); ok {
+				enabled[componentKey] = true
+			}
+		}
+	}
+	return enabled
+}
+
+// GetEnabledExtensions returns all extensions listed in the service section as a true flag set.
+func GetEnabledExtensions(_ logr.Logger, config map[interface{}]interface{}) map[interface{}]bool {
+	enabled := map[interface{}]bool{}
+	cfgService, withService := config["service"].(map[interface{}]interface{})
+	if !withService {
+		return enabled
+	}
+	extensions, withExtensions := cfgService["extensions"].([]interface{})
+	if !withExtensions {
+		return enabled
+	}
+	for _, extension := range extensions {
+		if extensionKey, ok := extension.(string); ok {
+			enabled[extensionKey] = true
+		}
+	}
+	return enabled
+}
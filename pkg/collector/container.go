@@ -138,6 +138,7 @@ func getConfigContainerPorts(logger logr.Logger, cfg string) map[string]corev1.C
 	if err != nil {
 		logger.Error(err, "couldn't build container ports from configuration")
 	} else {
+		ps = adapters.AppendUniquePorts(logger, ps, listenerPorts(logger, c)...)
 		for _, p := range ps {
 			truncName := naming.Truncate(p.Name, maxPortLen)
 			if p.Name != truncName {
@@ -172,6 +173,27 @@ func getConfigContainerPorts(logger logr.Logger, cfg string) map[string]corev1.C
 	return ports
 }
 
+// listenerPorts returns the ports of the exporters, connectors and extensions listening on an endpoint, like the
+// prometheus exporter or the health_check extension.
+func listenerPorts(logger logr.Logger, c map[interface{}]interface{}) []corev1.ServicePort {
+	var ports []corev1.ServicePort
+	exporterPorts, err := adapters.ConfigToExporterPorts(logger, c)
+	if err != nil {
+		logger.Error(err, "couldn't build container ports from the exporters")
+	}
+	ports = append(ports, exporterPorts...)
+	connectorPorts, err := adapters.ConfigToConnectorPorts(logger, c)
+	if err != nil {
+		logger.Error(err, "couldn't build container ports from the connectors")
+	}
+	ports = append(ports, connectorPorts...)
+	extensionPorts, err := adapters.ConfigToExtensionPorts(logger, c)
+	if err != nil {
+		logger.Error(err, "couldn't build container ports from the extensions")
+	}
+	return append(ports, extensionPorts...)
+}
+
 // getMetricsPort gets the port number for the metrics endpoint from the collector config if it has been set.
 func getMetricsPort(c map[interface{}]interface{}) (int32, error) {
 	// we don't need to unmarshal the whole config, just follow the keys down to
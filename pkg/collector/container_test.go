@@ -105,6 +105,65 @@ service:
 				metricContainerPort,
 			},
 		},
+		{
+			description: "ports of exporters and extensions in spec Config",
+			specConfig: `receivers:
+  examplereceiver:
+    endpoint: "0.0.0.0:12345"
+exporters:
+  prometheus:
+    endpoint: "0.0.0.0:8889"
+  prometheus/unused:
+    endpoint: "0.0.0.0:8890"
+extensions:
+  health_check:
+service:
+  extensions: [health_check]
+  pipelines:
+    metrics:
+      receivers: [examplereceiver]
+      exporters: [prometheus]
+`,
+			expectedPorts: []corev1.ContainerPort{
+				{
+					Name:          "examplereceiver",
+					ContainerPort: 12345,
+				},
+				{
+					Name:          "prometheus",
+					ContainerPort: 8889,
+					Protocol:      corev1.ProtocolTCP,
+				},
+				{
+					Name:          "health-check",
+					ContainerPort: 13133,
+					Protocol:      corev1.ProtocolTCP,
+				},
+				metricContainerPort,
+			},
+		},
+		{
+			description: "exporter port conflicting with a receiver port",
+			specConfig: `receivers:
+  examplereceiver:
+    endpoint: "0.0.0.0:8889"
+exporters:
+  prometheus:
+    endpoint: "0.0.0.0:8889"
+service:
+  pipelines:
+    metrics:
+      receivers: [examplereceiver]
+      exporters: [prometheus]
+`,
+			expectedPorts: []corev1.ContainerPort{
+				{
+					Name:          "examplereceiver",
+					ContainerPort: 8889,
+				},
+				metricContainerPort,
+			},
+		},
 		{
 			description: "ports in spec ContainerPorts",
 			specPorts: []corev1.ServicePort{
@@ -60,17 +60,23 @@ func HorizontalPodAutoscaler(cfg config.Config, logger logr.Logger, otelcol v1al
 			metrics = append(metrics, utilizationTarget)
 		}
 
-		targetCPUUtilization := autoscalingv2beta2.MetricSpec{
-			Type: autoscalingv2beta2.ResourceMetricSourceType,
-			Resource: &autoscalingv2beta2.ResourceMetricSource{
-				Name: corev1.ResourceCPU,
-				Target: autoscalingv2beta2.MetricTarget{
-					Type:               autoscalingv2beta2.UtilizationMetricType,
-					AverageUtilization: otelcol.Spec.Autoscaler.TargetCPUUtilization,
+		if otelcol.Spec.Autoscaler.TargetCPUUtilization != nil {
+			targetCPUUtilization := autoscalingv2beta2.MetricSpec{
+				Type: autoscalingv2beta2.ResourceMetricSourceType,
+				Resource: &autoscalingv2beta2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2beta2.MetricTarget{
+						Type:               autoscalingv2beta2.UtilizationMetricType,
+						AverageUtilization: otelcol.Spec.Autoscaler.TargetCPUUtilization,
+					},
 				},
-			},
+			}
+			metrics = append(metrics, targetCPUUtilization)
+		}
+
+		for _, metric := range otelcol.Spec.Autoscaler.Metrics {
+			metrics = append(metrics, convertToV2beta2MetricSpec(metric))
 		}
-		metrics = append(metrics, targetCPUUtilization)
 
 		autoscaler := autoscalingv2beta2.HorizontalPodAutoscaler{
 			ObjectMeta: objectMeta,
@@ -123,6 +129,8 @@ func HorizontalPodAutoscaler(cfg config.Config, logger logr.Logger, otelcol v1al
 			metrics = append(metrics, targetCPUUtilization)
 		}
 
+		metrics = append(metrics, otelcol.Spec.Autoscaler.Metrics...)
+
 		autoscaler := autoscalingv2.HorizontalPodAutoscaler{
 			ObjectMeta: objectMeta,
 			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
@@ -145,6 +153,70 @@ func HorizontalPodAutoscaler(cfg config.Config, logger logr.Logger, otelcol v1al
 	return result
 }
 
+// convertToV2beta2MetricSpec creates a v2beta2 MetricSpec from a v2 instance.
+func convertToV2beta2MetricSpec(v2metric autoscalingv2.MetricSpec) autoscalingv2beta2.MetricSpec {
+	metric := autoscalingv2beta2.MetricSpec{
+		Type: autoscalingv2beta2.MetricSourceType(v2metric.Type),
+	}
+
+	if v2metric.Object != nil {
+		metric.Object = &autoscalingv2beta2.ObjectMetricSource{
+			DescribedObject: autoscalingv2beta2.CrossVersionObjectReference{
+				Kind:       v2metric.Object.DescribedObject.Kind,
+				Name:       v2metric.Object.DescribedObject.Name,
+				APIVersion: v2metric.Object.DescribedObject.APIVersion,
+			},
+			Target: convertToV2beta2MetricTarget(v2metric.Object.Target),
+			Metric: convertToV2beta2MetricIdentifier(v2metric.Object.Metric),
+		}
+	}
+	if v2metric.Pods != nil {
+		metric.Pods = &autoscalingv2beta2.PodsMetricSource{
+			Metric: convertToV2beta2MetricIdentifier(v2metric.Pods.Metric),
+			Target: convertToV2beta2MetricTarget(v2metric.Pods.Target),
+		}
+	}
+	if v2metric.Resource != nil {
+		metric.Resource = &autoscalingv2beta2.ResourceMetricSource{
+			Name:   v2metric.Resource.Name,
+			Target: convertToV2beta2MetricTarget(v2metric.Resource.Target),
+		}
+	}
+	if v2metric.ContainerResource != nil {
+		metric.ContainerResource = &autoscalingv2beta2.ContainerResourceMetricSource{
+			Name:      v2metric.ContainerResource.Name,
+			Target:    convertToV2beta2MetricTarget(v2metric.ContainerResource.Target),
+			Container: v2metric.ContainerResource.Container,
+		}
+	}
+	if v2metric.External != nil {
+		metric.External = &autoscalingv2beta2.ExternalMetricSource{
+			Metric: convertToV2beta2MetricIdentifier(v2metric.External.Metric),
+			Target: convertToV2beta2MetricTarget(v2metric.External.Target),
+		}
+	}
+
+	return metric
+}
+
+// convertToV2beta2MetricIdentifier creates a v2beta2 MetricIdentifier from a v2 instance.
+func convertToV2beta2MetricIdentifier(v2identifier autoscalingv2.MetricIdentifier) autoscalingv2beta2.MetricIdentifier {
+	return autoscalingv2beta2.MetricIdentifier{
+		Name:     v2identifier.Name,
+		Selector: v2identifier.Selector,
+	}
+}
+
+// convertToV2beta2MetricTarget creates a v2beta2 MetricTarget from a v2 instance.
+func convertToV2beta2MetricTarget(v2target autoscalingv2.MetricTarget) autoscalingv2beta2.MetricTarget {
+	return autoscalingv2beta2.MetricTarget{
+		Type:               autoscalingv2beta2.MetricTargetType(v2target.Type),
+		Value:              v2target.Value,
+		AverageValue:       v2target.AverageValue,
+		AverageUtilization: v2target.AverageUtilization,
+	}
+}
+
 // Create a v2beta2 HorizontalPodAutoscalerBehavior from a v2 instance.
 func ConvertToV2beta2Behavior(v2behavior autoscalingv2.HorizontalPodAutoscalerBehavior) autoscalingv2beta2.HorizontalPodAutoscalerBehavior {
 	behavior := &autoscalingv2beta2.HorizontalPodAutoscalerBehavior{}
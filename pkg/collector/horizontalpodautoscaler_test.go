@@ -18,16 +18,18 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect/autodetecttest"
 	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
-	"github.com/open-telemetry/opentelemetry-operator/pkg/platform"
 )
 
 func TestHPA(t *testing.T) {
@@ -60,7 +62,7 @@ func TestHPA(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			mockAutoDetector := &mockAutoDetect{
+			mockAutoDetector := &autodetecttest.Mock{
 				HPAVersionFunc: func() (autodetect.AutoscalingVersion, error) {
 					return test.autoscalingVersion, nil
 				},
@@ -107,6 +109,98 @@ func TestHPA(t *testing.T) {
 	}
 }
 
+func TestHPAWithCustomMetrics(t *testing.T) {
+	var maxReplicas int32 = 5
+	queueSize := resource.MustParse("100")
+	droppedSpans := resource.MustParse("10")
+
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Autoscaler: &v1alpha1.AutoscalerSpec{
+				MaxReplicas: &maxReplicas,
+				Metrics: []autoscalingv2.MetricSpec{
+					{
+						Type: autoscalingv2.PodsMetricSourceType,
+						Pods: &autoscalingv2.PodsMetricSource{
+							Metric: autoscalingv2.MetricIdentifier{Name: "otelcol_exporter_queue_size"},
+							Target: autoscalingv2.MetricTarget{
+								Type:         autoscalingv2.AverageValueMetricType,
+								AverageValue: &queueSize,
+							},
+						},
+					},
+					{
+						Type: autoscalingv2.ExternalMetricSourceType,
+						External: &autoscalingv2.ExternalMetricSource{
+							Metric: autoscalingv2.MetricIdentifier{
+								Name:     "otelcol_processor_dropped_spans",
+								Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"collector": "my-instance"}},
+							},
+							Target: autoscalingv2.MetricTarget{
+								Type:  autoscalingv2.ValueMetricType,
+								Value: &droppedSpans,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("v2", func(t *testing.T) {
+		configuration := config.New(config.WithAutoDetect(&autodetecttest.Mock{
+			HPAVersionFunc: func() (autodetect.AutoscalingVersion, error) {
+				return autodetect.AutoscalingVersionV2, nil
+			},
+		}))
+		require.NoError(t, configuration.AutoDetect())
+
+		hpa := HorizontalPodAutoscaler(configuration, logger, otelcol).(*autoscalingv2.HorizontalPodAutoscaler)
+
+		assert.Equal(t, otelcol.Spec.Autoscaler.Metrics, hpa.Spec.Metrics)
+	})
+
+	t.Run("v2beta2", func(t *testing.T) {
+		configuration := config.New(config.WithAutoDetect(&autodetecttest.Mock{
+			HPAVersionFunc: func() (autodetect.AutoscalingVersion, error) {
+				return autodetect.AutoscalingVersionV2Beta2, nil
+			},
+		}))
+		require.NoError(t, configuration.AutoDetect())
+
+		hpa := HorizontalPodAutoscaler(configuration, logger, otelcol).(*autoscalingv2beta2.HorizontalPodAutoscaler)
+
+		assert.Equal(t, []autoscalingv2beta2.MetricSpec{
+			{
+				Type: autoscalingv2beta2.PodsMetricSourceType,
+				Pods: &autoscalingv2beta2.PodsMetricSource{
+					Metric: autoscalingv2beta2.MetricIdentifier{Name: "otelcol_exporter_queue_size"},
+					Target: autoscalingv2beta2.MetricTarget{
+						Type:         autoscalingv2beta2.AverageValueMetricType,
+						AverageValue: &queueSize,
+					},
+				},
+			},
+			{
+				Type: autoscalingv2beta2.ExternalMetricSourceType,
+				External: &autoscalingv2beta2.ExternalMetricSource{
+					Metric: autoscalingv2beta2.MetricIdentifier{
+						Name:     "otelcol_processor_dropped_spans",
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"collector": "my-instance"}},
+					},
+					Target: autoscalingv2beta2.MetricTarget{
+						Type:  autoscalingv2beta2.ValueMetricType,
+						Value: &droppedSpans,
+					},
+				},
+			},
+		}, hpa.Spec.Metrics)
+	})
+}
+
 func TestConvertToV2beta2Behavior(t *testing.T) {
 	ten := int32(10)
 	thirty := int32(30)
@@ -159,21 +253,3 @@ func TestConvertToV2Beta2SelectPolicy(t *testing.T) {
 	assert.Equal(t, autoscalingv2beta2.MaxPolicySelect, ConvertToV2Beta2SelectPolicy(max))
 	assert.Equal(t, autoscalingv2beta2.DisabledPolicySelect, ConvertToV2Beta2SelectPolicy(disabled))
 }
-
-var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
-
-type mockAutoDetect struct {
-	PlatformFunc   func() (platform.Platform, error)
-	HPAVersionFunc func() (autodetect.AutoscalingVersion, error)
-}
-
-func (m *mockAutoDetect) HPAVersion() (autodetect.AutoscalingVersion, error) {
-	return m.HPAVersionFunc()
-}
-
-func (m *mockAutoDetect) Platform() (platform.Platform, error) {
-	if m.PlatformFunc != nil {
-		return m.PlatformFunc()
-	}
-	return platform.Unknown, nil
-}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import "github.com/go-logr/logr"
+
+const parserNamePProf = "__pprof"
+
+// NewPProfExtensionParser builds a new parser for the pprof extension. The extension listens on localhost unless
+// an endpoint is configured, so there is no default port.
+func NewPProfExtensionParser(logger logr.Logger, name string, config map[interface{}]interface{}) ComponentParser {
+	return &ListenerParser{
+		logger:     logger,
+		name:       name,
+		config:     config,
+		parserName: parserNamePProf,
+	}
+}
+
+func init() {
+	RegisterComponent(ComponentKindExtension, "pprof", NewPProfExtensionParser)
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"net"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ ComponentParser = &ListenerParser{}
+
+// ListenerParser is a parser for exporters, extensions and connectors which listen on the address given in their
+// `endpoint` property, like the prometheus exporter or the health_check extension. Endpoints bound to the loopback
+// interface are skipped, as they can't be reached from outside the pod.
+type ListenerParser struct {
+	config      map[interface{}]interface{}
+	logger      logr.Logger
+	name        string
+	parserName  string
+	defaultPort int32
+	disabled    bool
+}
+
+// Ports returns the service port of the endpoint, or of the default port if no endpoint is configured.
+func (l *ListenerParser) Ports() ([]corev1.ServicePort, error) {
+	if l.disabled {
+		return []corev1.ServicePort{}, nil
+	}
+
+	port := l.defaultPort
+	if endpoint, ok := l.config[endpointKey]; ok {
+		e, isString := endpoint.(string)
+		if !isString {
+			l.logger.Info("endpoint isn't a string", "component", l.name)
+			return []corev1.ServicePort{}, nil
+		}
+		if isLoopbackEndpoint(e) {
+			l.logger.V(2).Info("endpoint is bound to the loopback interface, skipping", "component", l.name, endpointKey, e)
+			return []corev1.ServicePort{}, nil
+		}
+		p, err := portFromEndpoint(e)
+		if err != nil {
+			l.logger.WithValues(endpointKey, e).Info("couldn't parse the endpoint's port")
+			return []corev1.ServicePort{}, nil
+		}
+		port = p
+	}
+
+	if port == 0 {
+		return []corev1.ServicePort{}, nil
+	}
+
+	return []corev1.ServicePort{{
+		Name:     portName(l.name, port),
+		Port:     port,
+		Protocol: corev1.ProtocolTCP,
+	}}, nil
+}
+
+// ParserName returns the name of this parser.
+func (l *ListenerParser) ParserName() string {
+	return l.parserName
+}
+
+func isLoopbackEndpoint(endpoint string) bool {
+	host := endpoint
+	if i := strings.LastIndex(endpoint, ":"); i >= 0 {
+		host = endpoint[:i]
+	}
+	host = strings.Trim(host, "[]")
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestExporterPorts(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		name     string
+		config   map[interface{}]interface{}
+		expected []corev1.ServicePort
+	}{
+		{
+			"prometheus exporter",
+			"prometheus",
+			map[interface{}]interface{}{"endpoint": "0.0.0.0:8889"},
+			[]corev1.ServicePort{{Name: "prometheus", Port: 8889, Protocol: corev1.ProtocolTCP}},
+		},
+		{
+			"named prometheus exporter",
+			"prometheus/custom",
+			map[interface{}]interface{}{"endpoint": ":9090"},
+			[]corev1.ServicePort{{Name: "prometheus-custom", Port: 9090, Protocol: corev1.ProtocolTCP}},
+		},
+		{
+			"prometheus exporter on localhost",
+			"prometheus",
+			map[interface{}]interface{}{"endpoint": "localhost:8889"},
+			[]corev1.ServicePort{},
+		},
+		{
+			"prometheus exporter without endpoint",
+			"prometheus",
+			map[interface{}]interface{}{},
+			[]corev1.ServicePort{},
+		},
+		{
+			"exporter sending to an endpoint",
+			"otlp",
+			map[interface{}]interface{}{"endpoint": "otel-gateway:4317"},
+			[]corev1.ServicePort{},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			ports, err := ComponentFor(logger, ComponentKindExporter, tt.name, tt.config).Ports()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ports)
+		})
+	}
+}
+
+func TestExtensionPorts(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		name     string
+		config   map[interface{}]interface{}
+		expected []corev1.ServicePort
+	}{
+		{
+			"health_check with default port",
+			"health_check",
+			map[interface{}]interface{}{},
+			[]corev1.ServicePort{{Name: "health-check", Port: 13133, Protocol: corev1.ProtocolTCP}},
+		},
+		{
+			"health_check with endpoint",
+			"health_check",
+			map[interface{}]interface{}{"endpoint": "0.0.0.0:13134"},
+			[]corev1.ServicePort{{Name: "health-check", Port: 13134, Protocol: corev1.ProtocolTCP}},
+		},
+		{
+			"zpages without endpoint",
+			"zpages",
+			map[interface{}]interface{}{},
+			[]corev1.ServicePort{},
+		},
+		{
+			"zpages with endpoint",
+			"zpages",
+			map[interface{}]interface{}{"endpoint": "0.0.0.0:55679"},
+			[]corev1.ServicePort{{Name: "zpages", Port: 55679, Protocol: corev1.ProtocolTCP}},
+		},
+		{
+			"pprof on loopback",
+			"pprof",
+			map[interface{}]interface{}{"endpoint": "127.0.0.1:1777"},
+			[]corev1.ServicePort{},
+		},
+		{
+			"extension without listener",
+			"bearertokenauth",
+			map[interface{}]interface{}{"token": "secret"},
+			[]corev1.ServicePort{},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			ports, err := ComponentFor(logger, ComponentKindExtension, tt.name, tt.config).Ports()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ports)
+		})
+	}
+}
+
+func TestIsLoopbackEndpoint(t *testing.T) {
+	assert.True(t, isLoopbackEndpoint("localhost:1777"))
+	assert.True(t, isLoopbackEndpoint("127.0.0.1:1777"))
+	assert.True(t, isLoopbackEndpoint("[::1]:1777"))
+	assert.False(t, isLoopbackEndpoint("0.0.0.0:1777"))
+	assert.False(t, isLoopbackEndpoint(":1777"))
+	assert.False(t, isLoopbackEndpoint("${MY_POD_IP}:1777"))
+}
@@ -33,28 +33,14 @@ var (
 )
 
 // ReceiverParser is an interface that should be implemented by all receiver parsers.
-type ReceiverParser interface {
-	// Ports returns the service ports parsed based on the receiver's configuration
-	Ports() ([]corev1.ServicePort, error)
-
-	// ParserName returns the name of this parser
-	ParserName() string
-}
+type ReceiverParser = ComponentParser
 
 // Builder specifies the signature required for parser builders.
 type Builder func(logr.Logger, string, map[interface{}]interface{}) ReceiverParser
 
-// registry holds a record of all known parsers.
-var registry = make(map[string]Builder)
-
 // BuilderFor returns a parser builder for the given receiver name.
 func BuilderFor(name string) Builder {
-	builder := registry[receiverType(name)]
-	if builder == nil {
-		builder = NewGenericReceiverParser
-	}
-
-	return builder
+	return Builder(ComponentBuilderFor(ComponentKindReceiver, name))
 }
 
 // For returns a new parser for the given receiver name + config.
@@ -65,28 +51,29 @@ func For(logger logr.Logger, name string, config map[interface{}]interface{}) Re
 
 // Register adds a new parser builder to the list of known builders.
 func Register(name string, builder Builder) {
-	registry[name] = builder
+	RegisterComponent(ComponentKindReceiver, name, ComponentBuilder(builder))
 }
 
 // IsRegistered checks whether a parser is registered with the given name.
 func IsRegistered(name string) bool {
-	_, ok := registry[name]
-	return ok
+	return IsComponentRegistered(ComponentKindReceiver, name)
 }
 
 var (
 	endpointKey      = "endpoint"
 	listenAddressKey = "listen_address"
+	transportKey     = "transport"
 )
 
 func singlePortFromConfigEndpoint(logger logr.Logger, name string, config map[interface{}]interface{}) *v1.ServicePort {
 	var endpoint interface{}
-	switch {
+	// named instances like syslog/foo are configured like the receiver they're an instance of
+	switch receiverType(name) {
 	// syslog receiver contains the endpoint
 	// that needs to be exposed one level down inside config
 	// i.e. either in tcp or udp section with field key
 	// as `listen_address`
-	case name == "syslog":
+	case "syslog":
 		var c map[interface{}]interface{}
 		if udp, isUDP := config["udp"]; isUDP && udp != nil {
 			c = udp.(map[interface{}]interface{})
@@ -98,17 +85,17 @@ func singlePortFromConfigEndpoint(logger logr.Logger, name string, config map[in
 
 	// tcplog and udplog receivers hold the endpoint
 	// value in `listen_address` field
-	case name == "tcplog" || name == "udplog":
+	case "tcplog", "udplog":
 		endpoint = getAddressFromConfig(logger, name, listenAddressKey, config)
 
 	// ignore kubeletstats receiver as it holds the field key endpoint, and it
 	// is a scraper, we only expose endpoint through k8s service objects for
 	// receivers that aren't scrapers.
-	case name == "kubeletstats":
+	case "kubeletstats":
 		return nil
 
 	// ignore prometheus receiver as it has no listening endpoint
-	case name == "prometheus":
+	case "prometheus":
 		return nil
 
 	default:
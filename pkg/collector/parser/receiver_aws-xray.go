@@ -14,18 +14,22 @@
 
 package parser
 
-import "github.com/go-logr/logr"
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
 
 const parserNameAWSXRAY = "__awsxray"
 
 // NewAWSXrayReceiverParser builds a new parser for AWS xray receivers, from the contrib repository.
 func NewAWSXrayReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 2000,
-		parserName:  parserNameAWSXRAY,
+		logger:          logger,
+		name:            name,
+		config:          config,
+		defaultPort:     2000,
+		defaultProtocol: corev1.ProtocolUDP,
+		parserName:      parserNameAWSXRAY,
 	}
 }
 
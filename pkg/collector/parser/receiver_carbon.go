@@ -14,18 +14,22 @@
 
 package parser
 
-import "github.com/go-logr/logr"
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
 
 const parserNameCarbon = "__carbon"
 
 // NewCarbonReceiverParser builds a new parser for Carbon receivers, from the contrib repository.
 func NewCarbonReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 2003,
-		parserName:  parserNameCarbon,
+		logger:          logger,
+		name:            name,
+		config:          config,
+		defaultPort:     2003,
+		defaultProtocol: corev1.ProtocolTCP,
+		parserName:      parserNameCarbon,
 	}
 }
 
@@ -14,18 +14,23 @@
 
 package parser
 
-import "github.com/go-logr/logr"
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
 
 const parserNameCollectd = "__collectd"
 
 // NewCollectdReceiverParser builds a new parser for Collectd receivers, from the contrib repository.
 func NewCollectdReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 8081,
-		parserName:  parserNameCollectd,
+		logger:             logger,
+		name:               name,
+		config:             config,
+		defaultPort:        8081,
+		defaultProtocol:    corev1.ProtocolTCP,
+		defaultAppProtocol: &http,
+		parserName:         parserNameCollectd,
 	}
 }
 
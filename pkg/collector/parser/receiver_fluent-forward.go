@@ -14,18 +14,22 @@
 
 package parser
 
-import "github.com/go-logr/logr"
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
 
 const parserNameFluentForward = "__fluentforward"
 
 // NewFluentForwardReceiverParser builds a new parser for FluentForward receivers, from the contrib repository.
 func NewFluentForwardReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 8006,
-		parserName:  parserNameFluentForward,
+		logger:          logger,
+		name:            name,
+		config:          config,
+		defaultPort:     8006,
+		defaultProtocol: corev1.ProtocolTCP,
+		parserName:      parserNameFluentForward,
 	}
 }
 
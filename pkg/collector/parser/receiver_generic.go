@@ -15,6 +15,8 @@
 package parser
 
 import (
+	"strings"
+
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -49,9 +51,10 @@ func NewGenericReceiverParser(logger logr.Logger, name string, config map[interf
 
 // Ports returns all the service ports for all protocols in this parser.
 func (g *GenericReceiver) Ports() ([]corev1.ServicePort, error) {
+	protocol := g.transportProtocol()
 	port := singlePortFromConfigEndpoint(g.logger, g.name, g.config)
 	if port != nil {
-		port.Protocol = g.defaultProtocol
+		port.Protocol = protocol
 		port.AppProtocol = g.defaultAppProtocol
 		return []corev1.ServicePort{*port}, nil
 	}
@@ -60,7 +63,7 @@ func (g *GenericReceiver) Ports() ([]corev1.ServicePort, error) {
 		return []corev1.ServicePort{{
 			Port:        g.defaultPort,
 			Name:        portName(g.name, g.defaultPort),
-			Protocol:    g.defaultProtocol,
+			Protocol:    protocol,
 			AppProtocol: g.defaultAppProtocol,
 		}}, nil
 	}
@@ -72,3 +75,17 @@ func (g *GenericReceiver) Ports() ([]corev1.ServicePort, error) {
 func (g *GenericReceiver) ParserName() string {
 	return g.parserName
 }
+
+// transportProtocol returns the transport protocol of the receiver. Receivers supporting both TCP and UDP, like
+// carbon or statsd, select it with the `transport` property.
+func (g *GenericReceiver) transportProtocol() corev1.Protocol {
+	if transport, ok := g.config[transportKey].(string); ok {
+		switch strings.ToLower(transport) {
+		case "tcp", "tcp4", "tcp6":
+			return corev1.ProtocolTCP
+		case "udp", "udp4", "udp6":
+			return corev1.ProtocolUDP
+		}
+	}
+	return g.defaultProtocol
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/parser"
@@ -64,22 +65,24 @@ func TestDownstreamParsers(t *testing.T) {
 		receiverName string
 		parserName   string
 		defaultPort  int
+		protocol     corev1.Protocol
+		appProtocol  string
 	}{
-		{parser.NewZipkinReceiverParser, "zipkin", "zipkin", "__zipkin", 9411},
-		{parser.NewOpenCensusReceiverParser, "opencensus", "opencensus", "__opencensus", 55678},
+		{parser.NewZipkinReceiverParser, "zipkin", "zipkin", "__zipkin", 9411, corev1.ProtocolTCP, "http"},
+		{parser.NewOpenCensusReceiverParser, "opencensus", "opencensus", "__opencensus", 55678, corev1.ProtocolTCP, "grpc"},
 
 		// contrib receivers
-		{parser.NewCarbonReceiverParser, "carbon", "carbon", "__carbon", 2003},
-		{parser.NewCollectdReceiverParser, "collectd", "collectd", "__collectd", 8081},
-		{parser.NewSAPMReceiverParser, "sapm", "sapm", "__sapm", 7276},
-		{parser.NewSignalFxReceiverParser, "signalfx", "signalfx", "__signalfx", 9943},
-		{parser.NewWavefrontReceiverParser, "wavefront", "wavefront", "__wavefront", 2003},
-		{parser.NewZipkinScribeReceiverParser, "zipkin-scribe", "zipkin-scribe", "__zipkinscribe", 9410},
-		{parser.NewFluentForwardReceiverParser, "fluentforward", "fluentforward", "__fluentforward", 8006},
-		{parser.NewStatsdReceiverParser, "statsd", "statsd", "__statsd", 8125},
-		{parser.NewInfluxdbReceiverParser, "influxdb", "influxdb", "__influxdb", 8086},
-		{parser.NewSplunkHecReceiverParser, "splunk-hec", "splunk-hec", "__splunk_hec", 8088},
-		{parser.NewAWSXrayReceiverParser, "awsxray", "awsxray", "__awsxray", 2000},
+		{parser.NewCarbonReceiverParser, "carbon", "carbon", "__carbon", 2003, corev1.ProtocolTCP, ""},
+		{parser.NewCollectdReceiverParser, "collectd", "collectd", "__collectd", 8081, corev1.ProtocolTCP, "http"},
+		{parser.NewSAPMReceiverParser, "sapm", "sapm", "__sapm", 7276, corev1.ProtocolTCP, "http"},
+		{parser.NewSignalFxReceiverParser, "signalfx", "signalfx", "__signalfx", 9943, corev1.ProtocolTCP, "http"},
+		{parser.NewWavefrontReceiverParser, "wavefront", "wavefront", "__wavefront", 2003, corev1.ProtocolTCP, ""},
+		{parser.NewZipkinScribeReceiverParser, "zipkin-scribe", "zipkin-scribe", "__zipkinscribe", 9410, corev1.ProtocolTCP, ""},
+		{parser.NewFluentForwardReceiverParser, "fluentforward", "fluentforward", "__fluentforward", 8006, corev1.ProtocolTCP, ""},
+		{parser.NewStatsdReceiverParser, "statsd", "statsd", "__statsd", 8125, corev1.ProtocolUDP, ""},
+		{parser.NewInfluxdbReceiverParser, "influxdb", "influxdb", "__influxdb", 8086, corev1.ProtocolTCP, "http"},
+		{parser.NewSplunkHecReceiverParser, "splunk-hec", "splunk-hec", "__splunk_hec", 8088, corev1.ProtocolTCP, "http"},
+		{parser.NewAWSXrayReceiverParser, "awsxray", "awsxray", "__awsxray", 2000, corev1.ProtocolUDP, ""},
 	} {
 		t.Run(tt.receiverName, func(t *testing.T) {
 			t.Run("builds successfully", func(t *testing.T) {
@@ -102,6 +105,12 @@ func TestDownstreamParsers(t *testing.T) {
 				assert.Len(t, ports, 1)
 				assert.EqualValues(t, tt.defaultPort, ports[0].Port)
 				assert.Equal(t, tt.receiverName, ports[0].Name)
+				assert.Equal(t, tt.protocol, ports[0].Protocol)
+				if tt.appProtocol == "" {
+					assert.Nil(t, ports[0].AppProtocol)
+				} else if assert.NotNil(t, ports[0].AppProtocol) {
+					assert.Equal(t, tt.appProtocol, *ports[0].AppProtocol)
+				}
 			})
 
 			t.Run("allows port to be overridden", func(t *testing.T) {
@@ -122,3 +131,62 @@ func TestDownstreamParsers(t *testing.T) {
 		})
 	}
 }
+
+func TestTransportOverride(t *testing.T) {
+	for _, tt := range []struct {
+		desc         string
+		config       map[interface{}]interface{}
+		protocol     corev1.Protocol
+		builder      func(logr.Logger, string, map[interface{}]interface{}) parser.ReceiverParser
+		receiverName string
+	}{
+		{"statsd over tcp", map[interface{}]interface{}{"endpoint": "0.0.0.0:8125", "transport": "tcp"}, corev1.ProtocolTCP, parser.NewStatsdReceiverParser, "statsd"},
+		{"carbon over udp", map[interface{}]interface{}{"transport": "udp"}, corev1.ProtocolUDP, parser.NewCarbonReceiverParser, "carbon"},
+		{"unknown transport", map[interface{}]interface{}{"transport": "unix"}, corev1.ProtocolUDP, parser.NewStatsdReceiverParser, "statsd"},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			builder := tt.builder(logger, tt.receiverName, tt.config)
+
+			// test
+			ports, err := builder.Ports()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Len(t, ports, 1)
+			assert.Equal(t, tt.protocol, ports[0].Protocol)
+		})
+	}
+}
+
+func TestLogReceiverParsers(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		name     string
+		config   map[interface{}]interface{}
+		protocol corev1.Protocol
+	}{
+		{"udplog", "udplog", map[interface{}]interface{}{"listen_address": "0.0.0.0:54525"}, corev1.ProtocolUDP},
+		{"tcplog", "tcplog", map[interface{}]interface{}{"listen_address": "0.0.0.0:54525"}, corev1.ProtocolTCP},
+		{"syslog over udp", "syslog", map[interface{}]interface{}{
+			"udp": map[interface{}]interface{}{"listen_address": "0.0.0.0:54525"},
+		}, corev1.ProtocolUDP},
+		{"syslog over tcp", "syslog", map[interface{}]interface{}{
+			"tcp": map[interface{}]interface{}{"listen_address": "0.0.0.0:54525"},
+		}, corev1.ProtocolTCP},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			builder := parser.For(logger, tt.name, tt.config)
+
+			// test
+			ports, err := builder.Ports()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Len(t, ports, 1)
+			assert.EqualValues(t, 54525, ports[0].Port)
+			assert.Equal(t, tt.protocol, ports[0].Protocol)
+		})
+	}
+}
@@ -14,18 +14,23 @@
 
 package parser
 
-import "github.com/go-logr/logr"
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
 
 const parserNameInfluxdb = "__influxdb"
 
 // NewInfluxdbReceiverParser builds a new parser for Influxdb receivers, from the contrib repository.
 func NewInfluxdbReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 8086,
-		parserName:  parserNameInfluxdb,
+		logger:             logger,
+		name:               name,
+		config:             config,
+		defaultPort:        8086,
+		defaultProtocol:    corev1.ProtocolTCP,
+		defaultAppProtocol: &http,
+		parserName:         parserNameInfluxdb,
 	}
 }
 
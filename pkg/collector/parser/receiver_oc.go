@@ -14,18 +14,23 @@
 
 package parser
 
-import "github.com/go-logr/logr"
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
 
 const parserNameOpenCensus = "__opencensus"
 
 // NewOpenCensusReceiverParser builds a new parser for OpenCensus receivers.
 func NewOpenCensusReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 55678,
-		parserName:  parserNameOpenCensus,
+		logger:             logger,
+		name:               name,
+		config:             config,
+		defaultPort:        55678,
+		defaultProtocol:    corev1.ProtocolTCP,
+		defaultAppProtocol: &grpc,
+		parserName:         parserNameOpenCensus,
 	}
 }
 
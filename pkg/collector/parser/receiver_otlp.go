@@ -74,6 +74,7 @@ func (o *OTLPReceiverParser) Ports() ([]corev1.ServicePort, error) {
 				{
 					Name:        portName(fmt.Sprintf("%s-grpc", o.name), defaultOTLPGRPCPort),
 					Port:        defaultOTLPGRPCPort,
+					Protocol:    corev1.ProtocolTCP,
 					TargetPort:  intstr.FromInt(int(defaultOTLPGRPCPort)),
 					AppProtocol: &grpc,
 				},
@@ -85,12 +86,14 @@ func (o *OTLPReceiverParser) Ports() ([]corev1.ServicePort, error) {
 				{
 					Name:        portName(fmt.Sprintf("%s-http", o.name), defaultOTLPHTTPPort),
 					Port:        defaultOTLPHTTPPort,
+					Protocol:    corev1.ProtocolTCP,
 					TargetPort:  intstr.FromInt(int(defaultOTLPHTTPPort)),
 					AppProtocol: &http,
 				},
 				{
 					Name:        portName(fmt.Sprintf("%s-http-legacy", o.name), defaultOTLPHTTPLegacyPort),
 					Port:        defaultOTLPHTTPLegacyPort,
+					Protocol:    corev1.ProtocolTCP,
 					TargetPort:  intstr.FromInt(int(defaultOTLPHTTPPort)), // we target the official port, not the legacy
 					AppProtocol: &http,
 				},
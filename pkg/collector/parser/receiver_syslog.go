@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package parser
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const parserNameSyslog = "__syslog"
+
+// NewSyslogReceiverParser builds a new parser for Syslog receivers, from the contrib repository. The receiver listens
+// either on UDP or on TCP, depending on which of the `udp` and `tcp` sections is configured.
+func NewSyslogReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+	protocol := corev1.ProtocolTCP
+	if udp, ok := config["udp"]; ok && udp != nil {
+		protocol = corev1.ProtocolUDP
+	}
+	return &GenericReceiver{
+		logger:          logger,
+		name:            name,
+		config:          config,
+		defaultProtocol: protocol,
+		parserName:      parserNameSyslog,
+	}
+}
+
+func init() {
+	Register("syslog", NewSyslogReceiverParser)
+}
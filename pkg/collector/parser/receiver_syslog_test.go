@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/parser"
+)
+
+func TestSyslogReceiverPorts(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		name     string
+		config   map[interface{}]interface{}
+		port     corev1.ServicePort
+		hasPorts bool
+	}{
+		{
+			desc: "udp",
+			name: "syslog",
+			config: map[interface{}]interface{}{
+				"udp": map[interface{}]interface{}{"listen_address": "0.0.0.0:5140"},
+			},
+			port:     corev1.ServicePort{Name: "syslog", Port: 5140, Protocol: corev1.ProtocolUDP},
+			hasPorts: true,
+		},
+		{
+			desc: "tcp",
+			name: "syslog",
+			config: map[interface{}]interface{}{
+				"tcp": map[interface{}]interface{}{"listen_address": "0.0.0.0:5141"},
+			},
+			port:     corev1.ServicePort{Name: "syslog", Port: 5141, Protocol: corev1.ProtocolTCP},
+			hasPorts: true,
+		},
+		{
+			desc: "named instance",
+			name: "syslog/foo",
+			config: map[interface{}]interface{}{
+				"udp": map[interface{}]interface{}{"listen_address": "0.0.0.0:5142"},
+			},
+			port:     corev1.ServicePort{Name: "syslog-foo", Port: 5142, Protocol: corev1.ProtocolUDP},
+			hasPorts: true,
+		},
+		{
+			desc: "no listen address",
+			name: "syslog",
+			config: map[interface{}]interface{}{
+				"tcp": map[interface{}]interface{}{},
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			builder := parser.For(logger, tt.name, tt.config)
+
+			// test
+			ports, err := builder.Ports()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, "__syslog", builder.ParserName())
+			if !tt.hasPorts {
+				assert.Empty(t, ports)
+				return
+			}
+			assert.Equal(t, []corev1.ServicePort{tt.port}, ports)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/parser"
+)
+
+func TestTCPLogReceiverPorts(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		name     string
+		config   map[interface{}]interface{}
+		port     corev1.ServicePort
+		hasPorts bool
+	}{
+		{
+			desc:     "listen address",
+			name:     "tcplog",
+			config:   map[interface{}]interface{}{"listen_address": "0.0.0.0:54525"},
+			port:     corev1.ServicePort{Name: "tcplog", Port: 54525, Protocol: corev1.ProtocolTCP},
+			hasPorts: true,
+		},
+		{
+			desc:     "named instance",
+			name:     "tcplog/foo",
+			config:   map[interface{}]interface{}{"listen_address": "0.0.0.0:54525"},
+			port:     corev1.ServicePort{Name: "tcplog-foo", Port: 54525, Protocol: corev1.ProtocolTCP},
+			hasPorts: true,
+		},
+		{
+			desc:   "no listen address",
+			name:   "tcplog",
+			config: map[interface{}]interface{}{},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			builder := parser.For(logger, tt.name, tt.config)
+
+			// test
+			ports, err := builder.Ports()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, "__tcplog", builder.ParserName())
+			if !tt.hasPorts {
+				assert.Empty(t, ports)
+				return
+			}
+			assert.Equal(t, []corev1.ServicePort{tt.port}, ports)
+		})
+	}
+}
@@ -14,18 +14,22 @@
 
 package parser
 
-import "github.com/go-logr/logr"
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
 
 const parserNameWavefront = "__wavefront"
 
 // NewWavefrontReceiverParser builds a new parser for Wavefront receivers, from the contrib repository.
 func NewWavefrontReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 2003,
-		parserName:  parserNameWavefront,
+		logger:          logger,
+		name:            name,
+		config:          config,
+		defaultPort:     2003,
+		defaultProtocol: corev1.ProtocolTCP,
+		parserName:      parserNameWavefront,
 	}
 }
 
@@ -14,18 +14,22 @@
 
 package parser
 
-import "github.com/go-logr/logr"
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
 
 const parserNameZipkinScribe = "__zipkinscribe"
 
 // NewZipkinScribeReceiverParser builds a new parser for ZipkinScribe receivers.
 func NewZipkinScribeReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 9410,
-		parserName:  parserNameZipkinScribe,
+		logger:          logger,
+		name:            name,
+		config:          config,
+		defaultPort:     9410,
+		defaultProtocol: corev1.ProtocolTCP,
+		parserName:      parserNameZipkinScribe,
 	}
 }
 
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ComponentKind is the kind of a component of the collector configuration, which has its own set of parsers.
+type ComponentKind string
+
+const (
+	ComponentKindReceiver  ComponentKind = "receiver"
+	ComponentKindExporter  ComponentKind = "exporter"
+	ComponentKindExtension ComponentKind = "extension"
+	ComponentKindConnector ComponentKind = "connector"
+)
+
+// ComponentParser is an interface that should be implemented by the parsers of all kinds of components.
+type ComponentParser interface {
+	// Ports returns the service ports parsed based on the component's configuration
+	Ports() ([]corev1.ServicePort, error)
+
+	// ParserName returns the name of this parser
+	ParserName() string
+}
+
+// ComponentBuilder specifies the signature required for component parser builders.
+type ComponentBuilder func(logr.Logger, string, map[interface{}]interface{}) ComponentParser
+
+// componentRegistry holds a record of all known parsers of a kind of component.
+type componentRegistry struct {
+	builders map[string]ComponentBuilder
+	// generic builds the parsers of the components without a specific parser.
+	generic ComponentBuilder
+}
+
+// registries holds the parser registry of each kind of component. Unlike receivers, exporters, extensions and
+// connectors only open ports when they have a specific parser.
+var registries = map[ComponentKind]*componentRegistry{
+	ComponentKindReceiver:  {builders: map[string]ComponentBuilder{}, generic: NewGenericReceiverParser},
+	ComponentKindExporter:  {builders: map[string]ComponentBuilder{}, generic: NewGenericComponentParser},
+	ComponentKindExtension: {builders: map[string]ComponentBuilder{}, generic: NewGenericComponentParser},
+	ComponentKindConnector: {builders: map[string]ComponentBuilder{}, generic: NewGenericComponentParser},
+}
+
+// ComponentBuilderFor returns a parser builder for the given component kind and name.
+func ComponentBuilderFor(kind ComponentKind, name string) ComponentBuilder {
+	r := registries[kind]
+	builder := r.builders[receiverType(name)]
+	if builder == nil {
+		builder = r.generic
+	}
+
+	return builder
+}
+
+// ComponentFor returns a new parser for the given component kind, name + config.
+func ComponentFor(logger logr.Logger, kind ComponentKind, name string, config map[interface{}]interface{}) ComponentParser {
+	builder := ComponentBuilderFor(kind, name)
+	return builder(logger, name, config)
+}
+
+// RegisterComponent adds a new parser builder to the list of known builders of the given component kind.
+func RegisterComponent(kind ComponentKind, name string, builder ComponentBuilder) {
+	registries[kind].builders[name] = builder
+}
+
+// IsComponentRegistered checks whether a parser is registered with the given component kind and name.
+func IsComponentRegistered(kind ComponentKind, name string) bool {
+	_, ok := registries[kind].builders[name]
+	return ok
+}
+
+// NewGenericComponentParser builds a new parser for the exporters, extensions and connectors without a specific
+// parser. Most of them don't listen on any port, e.g. exporters send data to their endpoint instead of listening on
+// it, and authenticators or connectors have no endpoint, so no ports are opened for them.
+func NewGenericComponentParser(logger logr.Logger, name string, config map[interface{}]interface{}) ComponentParser {
+	return &ListenerParser{
+		logger:     logger,
+		name:       name,
+		config:     config,
+		parserName: parserNameGeneric,
+		disabled:   true,
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentFallbackWhenNotRegistered(t *testing.T) {
+	for _, kind := range []ComponentKind{ComponentKindExporter, ComponentKindExtension, ComponentKindConnector} {
+		t.Run(string(kind), func(t *testing.T) {
+			// test
+			p := ComponentFor(logger, kind, "mycomponent", map[interface{}]interface{}{"endpoint": "0.0.0.0:1234"})
+			ports, err := p.Ports()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Empty(t, ports)
+			assert.Equal(t, "__generic", p.ParserName())
+		})
+	}
+}
+
+func TestComponentShouldFindRegisteredParserOfItsKind(t *testing.T) {
+	// prepare
+	builderCalled := false
+	RegisterComponent(ComponentKindConnector, "mockconnector", func(logger logr.Logger, name string, config map[interface{}]interface{}) ComponentParser {
+		builderCalled = true
+		return &mockParser{}
+	})
+
+	// test
+	p := ComponentFor(logger, ComponentKindConnector, "mockconnector/custom", map[interface{}]interface{}{})
+
+	// verify
+	assert.True(t, builderCalled)
+	assert.Equal(t, "__mock", p.ParserName())
+	assert.True(t, IsComponentRegistered(ComponentKindConnector, "mockconnector"))
+	assert.False(t, IsComponentRegistered(ComponentKindExporter, "mockconnector"))
+	assert.False(t, IsRegistered("mockconnector"))
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/go-logr/logr"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// PodDisruptionBudget builds the pod disruption budget for the given instance.
+func PodDisruptionBudget(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) policyv1.PodDisruptionBudget {
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = naming.Collector(otelcol)
+
+	return policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.PodDisruptionBudget(otelcol),
+			Namespace:   otelcol.Namespace,
+			Labels:      labels,
+			Annotations: Annotations(otelcol),
+		},
+		Spec: PodDisruptionBudgetSpec(otelcol.Spec.PodDisruptionBudget, SelectorLabels(otelcol)),
+	}
+}
+
+// PodDisruptionBudgetSpec builds the spec of a pod disruption budget for the pods matching the given labels.
+// When neither minAvailable nor maxUnavailable is set, at most one pod can be unavailable.
+func PodDisruptionBudgetSpec(spec *v1alpha1.PodDisruptionBudgetSpec, matchLabels map[string]string) policyv1.PodDisruptionBudgetSpec {
+	result := policyv1.PodDisruptionBudgetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: matchLabels,
+		},
+	}
+	if spec != nil {
+		result.MinAvailable = spec.MinAvailable
+		result.MaxUnavailable = spec.MaxUnavailable
+	}
+	if result.MinAvailable == nil && result.MaxUnavailable == nil {
+		maxUnavailable := intstr.FromInt(1)
+		result.MaxUnavailable = &maxUnavailable
+	}
+	return result
+}
+
+// NeedsPodDisruptionBudget returns whether a pod disruption budget should be created for a workload with the given
+// replicas, which is the case if a budget is configured explicitly or if more than one replica is requested. The
+// maximum replicas of an autoscaler aren't taken into account, as a budget would block the eviction of a single pod.
+func NeedsPodDisruptionBudget(spec *v1alpha1.PodDisruptionBudgetSpec, replicas *int32) bool {
+	if spec != nil {
+		return true
+	}
+	return replicas != nil && *replicas > 1
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestPodDisruptionBudget(t *testing.T) {
+	minAvailable := intstr.FromString("50%")
+	one := intstr.FromInt(1)
+
+	tests := []struct {
+		name                   string
+		spec                   *v1alpha1.PodDisruptionBudgetSpec
+		expectedMinAvailable   *intstr.IntOrString
+		expectedMaxUnavailable *intstr.IntOrString
+	}{
+		{
+			name:                   "defaults to maxUnavailable 1",
+			expectedMaxUnavailable: &one,
+		},
+		{
+			name:                   "empty spec defaults to maxUnavailable 1",
+			spec:                   &v1alpha1.PodDisruptionBudgetSpec{},
+			expectedMaxUnavailable: &one,
+		},
+		{
+			name:                 "minAvailable",
+			spec:                 &v1alpha1.PodDisruptionBudgetSpec{MinAvailable: &minAvailable},
+			expectedMinAvailable: &minAvailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-instance",
+					Namespace: "my-ns",
+				},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					PodDisruptionBudget: test.spec,
+				},
+			}
+
+			// test
+			pdb := PodDisruptionBudget(config.New(), logger, otelcol)
+
+			// verify
+			assert.Equal(t, "my-instance-collector", pdb.Name)
+			assert.Equal(t, "my-ns", pdb.Namespace)
+			assert.Equal(t, "my-instance-collector", pdb.Labels["app.kubernetes.io/name"])
+			assert.Equal(t, SelectorLabels(otelcol), pdb.Spec.Selector.MatchLabels)
+			assert.Equal(t, test.expectedMinAvailable, pdb.Spec.MinAvailable)
+			assert.Equal(t, test.expectedMaxUnavailable, pdb.Spec.MaxUnavailable)
+		})
+	}
+}
+
+func TestNeedsPodDisruptionBudget(t *testing.T) {
+	one := int32(1)
+	three := int32(3)
+
+	assert.False(t, NeedsPodDisruptionBudget(nil, nil))
+	assert.False(t, NeedsPodDisruptionBudget(nil, &one))
+	assert.True(t, NeedsPodDisruptionBudget(nil, &three))
+	assert.True(t, NeedsPodDisruptionBudget(&v1alpha1.PodDisruptionBudgetSpec{}, &one))
+}
@@ -44,22 +44,9 @@ func ReplaceConfig(instance v1alpha1.OpenTelemetryCollector) (string, error) {
 		return "", getStringErr
 	}
 
-	promCfgMap, getCfgPromErr := ta.ConfigToPromConfig(instance.Spec.Config)
-	if getCfgPromErr != nil {
-		return "", getCfgPromErr
-	}
-
-	// yaml marshaling/unsmarshaling is preferred because of the problems associated with the conversion of map to a struct using mapstructure
-	promCfg, marshalErr := yaml.Marshal(map[string]interface{}{
-		"config": promCfgMap,
-	})
-	if marshalErr != nil {
-		return "", marshalErr
-	}
-
-	var cfg Config
-	if marshalErr = yaml.UnmarshalStrict(promCfg, &cfg); marshalErr != nil {
-		return "", fmt.Errorf("error unmarshaling YAML: %w", marshalErr)
+	cfg, err := targetAllocatorPromConfig(instance)
+	if err != nil {
+		return "", err
 	}
 
 	for i := range cfg.PromConfig.ScrapeConfigs {
@@ -72,7 +59,7 @@ func ReplaceConfig(instance v1alpha1.OpenTelemetryCollector) (string, error) {
 	}
 
 	updPromCfgMap := make(map[string]interface{})
-	if err := mapstructure.Decode(cfg, &updPromCfgMap); err != nil {
+	if err = mapstructure.Decode(cfg, &updPromCfgMap); err != nil {
 		return "", err
 	}
 
@@ -85,3 +72,26 @@ func ReplaceConfig(instance v1alpha1.OpenTelemetryCollector) (string, error) {
 	}
 	return string(out), nil
 }
+
+// targetAllocatorPromConfig parses the prometheus receiver configuration the same way the target allocator does,
+// which means that an error returned here would also cause the target allocator to reject the configuration.
+func targetAllocatorPromConfig(instance v1alpha1.OpenTelemetryCollector) (Config, error) {
+	promCfgMap, err := ta.ConfigToPromConfig(instance.Spec.Config)
+	if err != nil {
+		return Config{}, err
+	}
+
+	// yaml marshaling/unsmarshaling is preferred because of the problems associated with the conversion of map to a struct using mapstructure
+	promCfg, err := yaml.Marshal(map[string]interface{}{
+		"config": promCfgMap,
+	})
+	if err != nil {
+		return Config{}, err
+	}
+
+	var cfg Config
+	if err = yaml.UnmarshalStrict(promCfg, &cfg); err != nil {
+		return Config{}, fmt.Errorf("error unmarshaling YAML: %w", err)
+	}
+	return cfg, nil
+}
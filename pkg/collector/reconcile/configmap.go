@@ -133,6 +133,31 @@ func desiredTAConfigMap(params Params) (corev1.ConfigMap, error) {
 		taConfig["pod_monitor_selector"] = &params.Instance.Spec.TargetAllocator.PrometheusCR.PodMonitorSelector
 	}
 
+	if params.Instance.Spec.TargetAllocator.Persistence.Enabled {
+		persistence := map[string]interface{}{
+			"type":           "configmap",
+			"configmap_name": naming.TAAssignmentsConfigMap(params.Instance),
+			"namespace":      params.Instance.Namespace,
+		}
+		if params.Instance.Spec.TargetAllocator.Persistence.Interval != nil {
+			persistence["interval"] = params.Instance.Spec.TargetAllocator.Persistence.Interval.Duration.String()
+		}
+		taConfig["persistence"] = persistence
+	}
+
+	if params.Instance.Spec.TargetAllocator.Telemetry.Endpoint != "" {
+		telemetry := map[string]interface{}{
+			"endpoint": params.Instance.Spec.TargetAllocator.Telemetry.Endpoint,
+		}
+		if params.Instance.Spec.TargetAllocator.Telemetry.Insecure {
+			telemetry["insecure"] = true
+		}
+		if params.Instance.Spec.TargetAllocator.Telemetry.Interval != nil {
+			telemetry["interval"] = params.Instance.Spec.TargetAllocator.Telemetry.Interval.Duration.String()
+		}
+		taConfig["telemetry"] = telemetry
+	}
+
 	taConfigYAML, err := yaml.Marshal(taConfig)
 	if err != nil {
 		return corev1.ConfigMap{}, err
@@ -251,6 +276,25 @@ func deleteConfigMaps(ctx context.Context, params Params, expected []corev1.Conf
 	return nil
 }
 
+// DeleteTAAssignmentsConfigMap deletes the config map the TargetAllocator persists its target assignments in. The
+// TargetAllocator creates it on its own, so it isn't owned by the instance and isn't removed by the garbage collector.
+func DeleteTAAssignmentsConfigMap(ctx context.Context, params Params) error {
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.TAAssignmentsConfigMap(params.Instance),
+			Namespace: params.Instance.Namespace,
+		},
+	}
+	if err := params.Client.Delete(ctx, existing); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	params.Log.V(2).Info("deleted", "configmap.name", existing.Name, "configmap.namespace", existing.Namespace)
+	return nil
+}
+
 func configMapChanged(desired *corev1.ConfigMap, actual *corev1.ConfigMap) bool {
 	return !reflect.DeepEqual(desired.Data, actual.Data)
 
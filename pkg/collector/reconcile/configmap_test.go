@@ -17,6 +17,7 @@ package reconcile
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
@@ -248,6 +249,66 @@ service_monitor_selector:
 		assert.Equal(t, expectedData, actual.Data)
 
 	})
+	t.Run("should return expected target allocator config map with persistence", func(t *testing.T) {
+		expectedData := map[string]string{
+			"targetallocator.yaml": `allocation_strategy: least-weighted
+config:
+  scrape_configs:
+  - job_name: otel-collector
+    scrape_interval: 10s
+    static_configs:
+    - targets:
+      - 0.0.0.0:8888
+      - 0.0.0.0:9999
+label_selector:
+  app.kubernetes.io/component: opentelemetry-collector
+  app.kubernetes.io/instance: default.test
+  app.kubernetes.io/managed-by: opentelemetry-operator
+persistence:
+  configmap_name: test-targetallocator-assignments
+  interval: 1m0s
+  namespace: default
+  type: configmap
+`,
+		}
+		p := params()
+		p.Instance.Spec.TargetAllocator.Persistence = v1alpha1.OpenTelemetryTargetAllocatorPersistence{
+			Enabled:  true,
+			Interval: &metav1.Duration{Duration: time.Minute},
+		}
+		actual, err := desiredTAConfigMap(p)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedData, actual.Data)
+	})
+	t.Run("should return expected target allocator config map with telemetry", func(t *testing.T) {
+		expectedData := map[string]string{
+			"targetallocator.yaml": `allocation_strategy: least-weighted
+config:
+  scrape_configs:
+  - job_name: otel-collector
+    scrape_interval: 10s
+    static_configs:
+    - targets:
+      - 0.0.0.0:8888
+      - 0.0.0.0:9999
+label_selector:
+  app.kubernetes.io/component: opentelemetry-collector
+  app.kubernetes.io/instance: default.test
+  app.kubernetes.io/managed-by: opentelemetry-operator
+telemetry:
+  endpoint: otel-collector:4318
+  insecure: true
+`,
+		}
+		p := params()
+		p.Instance.Spec.TargetAllocator.Telemetry = v1alpha1.OpenTelemetryTargetAllocatorTelemetry{
+			Endpoint: "otel-collector:4318",
+			Insecure: true,
+		}
+		actual, err := desiredTAConfigMap(p)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedData, actual.Data)
+	})
 
 }
 
@@ -394,3 +455,29 @@ func TestExpectedConfigMap(t *testing.T) {
 		assert.False(t, exists)
 	})
 }
+
+func TestDeleteTAAssignmentsConfigMap(t *testing.T) {
+	t.Run("should delete the target allocator assignments config map", func(t *testing.T) {
+		assignments := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-targetallocator-assignments",
+				Namespace: "default",
+				Labels: map[string]string{
+					"app.kubernetes.io/component": "opentelemetry-targetallocator",
+				},
+			},
+		}
+		createObjectIfNotExists(t, "test-targetallocator-assignments", &assignments)
+
+		err := DeleteTAAssignmentsConfigMap(context.Background(), params())
+		assert.NoError(t, err)
+
+		exists, _ := populateObjectIfExists(t, &v1.ConfigMap{}, types.NamespacedName{Namespace: "default", Name: "test-targetallocator-assignments"})
+		assert.False(t, exists)
+	})
+
+	t.Run("should ignore a missing target allocator assignments config map", func(t *testing.T) {
+		err := DeleteTAAssignmentsConfigMap(context.Background(), params())
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;grpcroutes,verbs=get;list;watch;create;update;patch;delete
+
+// desiredGatewayRoutes returns one route per receiver port, attached to the referenced Gateway and matching the
+// hostname prefixed with the port name. Ports using gRPC get a GRPCRoute if the cluster serves them, all other
+// TCP ports get an HTTPRoute.
+func desiredGatewayRoutes(_ context.Context, params Params) ([]gatewayv1beta1.HTTPRoute, []gatewayv1alpha2.GRPCRoute) {
+	ports := servicePortsFromCfg(params)
+
+	// if we have no ports, we don't need any routes
+	if len(ports) == 0 {
+		params.Log.V(1).Info(
+			"the instance's configuration didn't yield any ports to open, skipping gateway routes",
+			"instance.name", params.Instance.Name,
+			"instance.namespace", params.Instance.Namespace,
+		)
+		return nil, nil
+	}
+
+	gateway := params.Instance.Spec.Ingress.Gateway
+	parentRef := gatewayv1beta1.ParentReference{
+		Name: gatewayv1beta1.ObjectName(gateway.Name),
+	}
+	if gateway.Namespace != "" {
+		namespace := gatewayv1beta1.Namespace(gateway.Namespace)
+		parentRef.Namespace = &namespace
+	}
+	if gateway.SectionName != "" {
+		sectionName := gatewayv1beta1.SectionName(gateway.SectionName)
+		parentRef.SectionName = &sectionName
+	}
+	grpcRoutesAvailable := params.Config.GatewayAPIAvailability() == autodetect.GatewayAPIWithGRPCRoutesAvailable
+
+	var httpRoutes []gatewayv1beta1.HTTPRoute
+	var grpcRoutes []gatewayv1alpha2.GRPCRoute
+	for _, p := range ports {
+		// routes carry HTTP traffic, which can't reach receivers listening on UDP
+		if p.Protocol != "" && p.Protocol != corev1.ProtocolTCP {
+			params.Log.V(1).Info("skipping non-TCP port for the gateway routes", "port.name", p.Name, "port.protocol", p.Protocol)
+			continue
+		}
+
+		port := gatewayv1beta1.PortNumber(p.Port)
+		backendRef := gatewayv1beta1.BackendRef{
+			BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+				Name: gatewayv1beta1.ObjectName(naming.Service(params.Instance)),
+				Port: &port,
+			},
+		}
+		commonSpec := gatewayv1beta1.CommonRouteSpec{
+			ParentRefs: []gatewayv1beta1.ParentReference{parentRef},
+		}
+		hostnames := []gatewayv1beta1.Hostname{
+			gatewayv1beta1.Hostname(p.Name + "." + params.Instance.Spec.Ingress.Hostname),
+		}
+
+		if grpcRoutesAvailable && p.AppProtocol != nil && *p.AppProtocol == "grpc" {
+			grpcRoutes = append(grpcRoutes, gatewayv1alpha2.GRPCRoute{
+				ObjectMeta: gatewayRouteObjectMeta(params, naming.GRPCRoute(params.Instance, p.Name)),
+				Spec: gatewayv1alpha2.GRPCRouteSpec{
+					CommonRouteSpec: commonSpec,
+					Hostnames:       hostnames,
+					Rules: []gatewayv1alpha2.GRPCRouteRule{{
+						BackendRefs: []gatewayv1alpha2.GRPCBackendRef{{BackendRef: backendRef}},
+					}},
+				},
+			})
+			continue
+		}
+
+		httpRoutes = append(httpRoutes, gatewayv1beta1.HTTPRoute{
+			ObjectMeta: gatewayRouteObjectMeta(params, naming.HTTPRoute(params.Instance, p.Name)),
+			Spec: gatewayv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: commonSpec,
+				Hostnames:       hostnames,
+				Rules: []gatewayv1beta1.HTTPRouteRule{{
+					BackendRefs: []gatewayv1beta1.HTTPBackendRef{{BackendRef: backendRef}},
+				}},
+			},
+		})
+	}
+	return httpRoutes, grpcRoutes
+}
+
+func gatewayRouteObjectMeta(params Params, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   params.Instance.Namespace,
+		Annotations: params.Instance.Spec.Ingress.Annotations,
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       name,
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		},
+	}
+}
+
+// GatewayRoutes reconciles the Gateway API route(s) required for the instance in the current context.
+func GatewayRoutes(ctx context.Context, params Params) error {
+	// the Gateway API CRDs aren't installed, there is nothing to create or delete
+	availability := params.Config.GatewayAPIAvailability()
+	if availability == autodetect.GatewayAPINotAvailable {
+		return nil
+	}
+
+	var httpRoutes []gatewayv1beta1.HTTPRoute
+	var grpcRoutes []gatewayv1alpha2.GRPCRoute
+	if params.Instance.Spec.Ingress.Type == v1alpha1.IngressTypeGateway {
+		if params.Instance.Spec.Mode == v1alpha1.ModeSidecar {
+			params.Log.V(3).Info("ingress settings are not supported in sidecar mode")
+		} else {
+			httpRoutes, grpcRoutes = desiredGatewayRoutes(ctx, params)
+		}
+	}
+
+	// first, handle the create/update parts
+	if err := expectedGatewayRoutes(ctx, params, "httproute", httpRoutes, setHTTPRouteSpec); err != nil {
+		return fmt.Errorf("failed to reconcile the expected http routes: %w", err)
+	}
+	if availability == autodetect.GatewayAPIWithGRPCRoutesAvailable {
+		if err := expectedGatewayRoutes(ctx, params, "grpcroute", grpcRoutes, setGRPCRouteSpec); err != nil {
+			return fmt.Errorf("failed to reconcile the expected grpc routes: %w", err)
+		}
+	}
+
+	// then, delete the extra objects
+	if err := deleteGatewayRoutes(ctx, params, "httproute", &gatewayv1beta1.HTTPRouteList{}, httpRoutes); err != nil {
+		return fmt.Errorf("failed to reconcile the http routes to be deleted: %w", err)
+	}
+	if availability == autodetect.GatewayAPIWithGRPCRoutesAvailable {
+		if err := deleteGatewayRoutes(ctx, params, "grpcroute", &gatewayv1alpha2.GRPCRouteList{}, grpcRoutes); err != nil {
+			return fmt.Errorf("failed to reconcile the grpc routes to be deleted: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// gatewayRoute is a pointer to one of the route kinds of the Gateway API.
+type gatewayRoute[T any] interface {
+	*T
+	client.Object
+}
+
+func setHTTPRouteSpec(updated, desired *gatewayv1beta1.HTTPRoute) {
+	updated.Spec = desired.Spec
+}
+
+func setGRPCRouteSpec(updated, desired *gatewayv1alpha2.GRPCRoute) {
+	updated.Spec = desired.Spec
+}
+
+// expectedGatewayRoutes creates the expected routes of one kind, or merges them into the existing ones, copying the
+// spec with setSpec.
+func expectedGatewayRoutes[T any, PT gatewayRoute[T]](ctx context.Context, params Params, kind string, expected []T, setSpec func(updated, desired PT)) error {
+	for _, obj := range expected {
+		desired := PT(&obj)
+
+		if err := controllerutil.SetControllerReference(&params.Instance, desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		existing := PT(new(T))
+		nns := types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}
+		err := params.Client.Get(ctx, nns, existing)
+		if err != nil && k8serrors.IsNotFound(err) {
+			if err = params.Client.Create(ctx, desired); err != nil {
+				return fmt.Errorf("failed to create: %w", err)
+			}
+			params.Log.V(2).Info("created", kind+".name", desired.GetName(), kind+".namespace", desired.GetNamespace())
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get: %w", err)
+		}
+
+		// it exists already, merge the two if the end result isn't identical to the existing one
+		updated := existing.DeepCopyObject().(PT)
+		annotations := updated.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		labels := updated.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		updated.SetOwnerReferences(desired.GetOwnerReferences())
+		setSpec(updated, desired)
+
+		for k, v := range desired.GetAnnotations() {
+			annotations[k] = v
+		}
+		for k, v := range desired.GetLabels() {
+			labels[k] = v
+		}
+		updated.SetAnnotations(annotations)
+		updated.SetLabels(labels)
+
+		patch := client.MergeFrom(existing)
+
+		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", kind+".name", desired.GetName(), kind+".namespace", desired.GetNamespace())
+	}
+	return nil
+}
+
+// deleteGatewayRoutes deletes the routes of one kind in list which aren't expected.
+func deleteGatewayRoutes[T any, PT gatewayRoute[T]](ctx context.Context, params Params, kind string, list client.ObjectList, expected []T) error {
+	if err := params.Client.List(ctx, list, gatewayRouteListOptions(params)...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for _, item := range items {
+		existing := item.(PT)
+		del := true
+		for i := range expected {
+			keep := PT(&expected[i])
+			if keep.GetName() == existing.GetName() && keep.GetNamespace() == existing.GetNamespace() {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", kind+".name", existing.GetName(), kind+".namespace", existing.GetNamespace())
+		}
+	}
+
+	return nil
+}
+
+func gatewayRouteListOptions(params Params) []client.ListOption {
+	return []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect/autodetecttest"
+)
+
+func TestDesiredGatewayRoutes(t *testing.T) {
+	for _, tt := range []struct {
+		desc         string
+		availability autodetect.GatewayAPIAvailability
+		httpRoutes   []string
+		grpcRoutes   []string
+	}{
+		{
+			desc:         "grpc routes available",
+			availability: autodetect.GatewayAPIWithGRPCRoutesAvailable,
+			httpRoutes:   []string{"web-test-httproute"},
+			grpcRoutes:   []string{"jaeger-grpc-test-grpcroute"},
+		},
+		{
+			desc:         "http routes only",
+			availability: autodetect.GatewayAPIAvailable,
+			httpRoutes:   []string{"web-test-httproute", "jaeger-grpc-test-httproute"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			param := params()
+			cfg := config.New(config.WithAutoDetect(&autodetecttest.Mock{
+				HPAVersionFunc: func() (autodetect.AutoscalingVersion, error) {
+					return autodetect.AutoscalingVersionV2, nil
+				},
+				GatewayAPIAvailabilityFunc: func() (autodetect.GatewayAPIAvailability, error) {
+					return tt.availability, nil
+				},
+			}))
+			require.NoError(t, cfg.AutoDetect())
+			param.Config = cfg
+			param.Instance.Spec.Ingress = v1alpha1.Ingress{
+				Type:     v1alpha1.IngressTypeGateway,
+				Hostname: "example.com",
+				Gateway: v1alpha1.GatewayReference{
+					Name:        "gateway",
+					Namespace:   "infra",
+					SectionName: "https",
+				},
+			}
+
+			httpRoutes, grpcRoutes := desiredGatewayRoutes(context.Background(), param)
+
+			var httpRouteNames, grpcRouteNames []string
+			for _, r := range httpRoutes {
+				httpRouteNames = append(httpRouteNames, r.Name)
+			}
+			for _, r := range grpcRoutes {
+				grpcRouteNames = append(grpcRouteNames, r.Name)
+			}
+			assert.Equal(t, tt.httpRoutes, httpRouteNames)
+			assert.Equal(t, tt.grpcRoutes, grpcRouteNames)
+
+			route := httpRoutes[0]
+			namespace := gatewayv1beta1.Namespace("infra")
+			sectionName := gatewayv1beta1.SectionName("https")
+			port := gatewayv1beta1.PortNumber(80)
+			assert.Equal(t, []gatewayv1beta1.ParentReference{{Name: "gateway", Namespace: &namespace, SectionName: &sectionName}}, route.Spec.ParentRefs)
+			assert.Equal(t, []gatewayv1beta1.Hostname{"web.example.com"}, route.Spec.Hostnames)
+			assert.Equal(t, gatewayv1beta1.BackendObjectReference{Name: "test-collector", Port: &port}, route.Spec.Rules[0].BackendRefs[0].BackendObjectReference)
+			assert.Equal(t, "default.test", route.Labels["app.kubernetes.io/instance"])
+		})
+	}
+}
+
+func TestExpectedGatewayRoutes(t *testing.T) {
+	t.Run("should create and update gateway routes", func(t *testing.T) {
+		ctx := context.Background()
+		param := gatewayRouteParams(t)
+
+		httpRoutes, grpcRoutes := desiredGatewayRoutes(ctx, param)
+		err := expectedGatewayRoutes(ctx, param, "httproute", httpRoutes, setHTTPRouteSpec)
+		assert.NoError(t, err)
+		err = expectedGatewayRoutes(ctx, param, "grpcroute", grpcRoutes, setGRPCRouteSpec)
+		assert.NoError(t, err)
+
+		httpNNS := types.NamespacedName{Namespace: "default", Name: "web-test-httproute"}
+		exists, err := populateObjectIfExists(t, &gatewayv1beta1.HTTPRoute{}, httpNNS)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		grpcNNS := types.NamespacedName{Namespace: "default", Name: "jaeger-grpc-test-grpcroute"}
+		exists, err = populateObjectIfExists(t, &gatewayv1alpha2.GRPCRoute{}, grpcNNS)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		// update fields
+		param.Instance.Spec.Ingress.Annotations = map[string]string{"blub": "blob"}
+		param.Instance.Spec.Ingress.Hostname = "something-else.com"
+
+		httpRoutes, grpcRoutes = desiredGatewayRoutes(ctx, param)
+		err = expectedGatewayRoutes(ctx, param, "httproute", httpRoutes, setHTTPRouteSpec)
+		assert.NoError(t, err)
+		err = expectedGatewayRoutes(ctx, param, "grpcroute", grpcRoutes, setGRPCRouteSpec)
+		assert.NoError(t, err)
+
+		gotHTTP := &gatewayv1beta1.HTTPRoute{}
+		err = param.Client.Get(ctx, httpNNS, gotHTTP)
+		assert.NoError(t, err)
+		assert.Equal(t, []gatewayv1beta1.Hostname{"web.something-else.com"}, gotHTTP.Spec.Hostnames)
+		assert.Equal(t, "blob", gotHTTP.Annotations["blub"])
+
+		gotGRPC := &gatewayv1alpha2.GRPCRoute{}
+		err = param.Client.Get(ctx, grpcNNS, gotGRPC)
+		assert.NoError(t, err)
+		assert.Equal(t, []gatewayv1alpha2.Hostname{"jaeger-grpc.something-else.com"}, gotGRPC.Spec.Hostnames)
+		assert.Equal(t, "blob", gotGRPC.Annotations["blub"])
+	})
+}
+
+func TestDeleteGatewayRoutes(t *testing.T) {
+	t.Run("should delete excess gateway routes", func(t *testing.T) {
+		// create
+		ctx := context.Background()
+		param := gatewayRouteParams(t)
+
+		httpRoutes, grpcRoutes := desiredGatewayRoutes(ctx, param)
+		err := expectedGatewayRoutes(ctx, param, "httproute", httpRoutes, setHTTPRouteSpec)
+		assert.NoError(t, err)
+		err = expectedGatewayRoutes(ctx, param, "grpcroute", grpcRoutes, setGRPCRouteSpec)
+		assert.NoError(t, err)
+
+		httpNNS := types.NamespacedName{Namespace: "default", Name: "web-test-httproute"}
+		exists, err := populateObjectIfExists(t, &gatewayv1beta1.HTTPRoute{}, httpNNS)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		// delete
+		err = deleteGatewayRoutes(ctx, param, "httproute", &gatewayv1beta1.HTTPRouteList{}, []gatewayv1beta1.HTTPRoute{})
+		assert.NoError(t, err)
+		err = deleteGatewayRoutes(ctx, param, "grpcroute", &gatewayv1alpha2.GRPCRouteList{}, grpcRoutes)
+		assert.NoError(t, err)
+
+		// check
+		exists, err = populateObjectIfExists(t, &gatewayv1beta1.HTTPRoute{}, httpNNS)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		grpcNNS := types.NamespacedName{Namespace: "default", Name: "jaeger-grpc-test-grpcroute"}
+		exists, err = populateObjectIfExists(t, &gatewayv1alpha2.GRPCRoute{}, grpcNNS)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
+func gatewayRouteParams(t *testing.T) Params {
+	param := params()
+	cfg := config.New(config.WithAutoDetect(&autodetecttest.Mock{
+		GatewayAPIAvailabilityFunc: func() (autodetect.GatewayAPIAvailability, error) {
+			return autodetect.GatewayAPIWithGRPCRoutesAvailable, nil
+		},
+	}))
+	require.NoError(t, cfg.AutoDetect())
+	param.Config = cfg
+	param.Instance.Spec.Ingress = v1alpha1.Ingress{
+		Type:     v1alpha1.IngressTypeGateway,
+		Hostname: "example.com",
+		Gateway:  v1alpha1.GatewayReference{Name: "gateway"},
+	}
+	return param
+}
+
+func TestGatewayRoutesWithoutGatewayAPI(t *testing.T) {
+	param := params()
+	param.Instance.Spec.Ingress = v1alpha1.Ingress{
+		Type:     v1alpha1.IngressTypeGateway,
+		Hostname: "example.com",
+		Gateway:  v1alpha1.GatewayReference{Name: "gateway"},
+	}
+	// without a client, any call to the API would panic
+	param.Client = nil
+
+	err := GatewayRoutes(context.Background(), param)
+	assert.NoError(t, err)
+}
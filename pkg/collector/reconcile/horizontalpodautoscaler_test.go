@@ -34,8 +34,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect/autodetecttest"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
-	"github.com/open-telemetry/opentelemetry-operator/pkg/platform"
 )
 
 var hpaUpdateErr error
@@ -114,7 +114,7 @@ func paramsWithHPA(autoscalingVersion autodetect.AutoscalingVersion) Params {
 	maxReplicas := int32(5)
 	cpuUtilization := int32(90)
 
-	mockAutoDetector := &mockAutoDetect{
+	mockAutoDetector := &autodetecttest.Mock{
 		HPAVersionFunc: func() (autodetect.AutoscalingVersion, error) {
 			return autoscalingVersion, nil
 		},
@@ -161,21 +161,3 @@ func paramsWithHPA(autoscalingVersion autodetect.AutoscalingVersion) Params {
 		Recorder: record.NewFakeRecorder(10),
 	}
 }
-
-var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
-
-type mockAutoDetect struct {
-	PlatformFunc   func() (platform.Platform, error)
-	HPAVersionFunc func() (autodetect.AutoscalingVersion, error)
-}
-
-func (m *mockAutoDetect) HPAVersion() (autodetect.AutoscalingVersion, error) {
-	return m.HPAVersionFunc()
-}
-
-func (m *mockAutoDetect) Platform() (platform.Platform, error) {
-	if m.PlatformFunc != nil {
-		return m.PlatformFunc()
-	}
-	return platform.Unknown, nil
-}
@@ -16,7 +16,16 @@ package reconcile
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"reflect"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -48,29 +57,46 @@ func desiredIngresses(_ context.Context, params Params) *networkingv1.Ingress {
 		return nil
 	}
 
-	pathType := networkingv1.PathTypePrefix
-	paths := make([]networkingv1.HTTPIngressPath, len(ports))
-	for i, p := range ports {
-		paths[i] = networkingv1.HTTPIngressPath{
-			Path:     "/" + p.Name,
-			PathType: &pathType,
-			Backend: networkingv1.IngressBackend{
-				Service: &networkingv1.IngressServiceBackend{
-					Name: naming.Service(params.Instance),
-					Port: networkingv1.ServiceBackendPort{
-						// Valid names must be non-empty and no more than 15 characters long.
-						Name: naming.Truncate(p.Name, 15),
-					},
-				},
-			},
+	var tcpPorts []corev1.ServicePort
+	for _, p := range ports {
+		// an ingress only routes HTTP traffic, which can't reach receivers listening on UDP
+		if p.Protocol != "" && p.Protocol != corev1.ProtocolTCP {
+			params.Log.V(1).Info("skipping non-TCP port for the ingress", "port.name", p.Name, "port.protocol", p.Protocol)
+			continue
 		}
+		tcpPorts = append(tcpPorts, p)
+	}
+	if len(tcpPorts) == 0 {
+		params.Log.V(1).Info(
+			"the instance's configuration didn't yield any TCP ports to route, skipping ingress",
+			"instance.name", params.Instance.Name,
+			"instance.namespace", params.Instance.Namespace,
+		)
+		return nil
+	}
+
+	var rules []networkingv1.IngressRule
+	switch params.Instance.Spec.Ingress.RuleType {
+	case v1alpha1.IngressRuleTypeSubdomain:
+		rules = createSubdomainIngressRules(params.Instance, tcpPorts)
+	default:
+		rules = []networkingv1.IngressRule{createPathIngressRule(params.Instance, tcpPorts)}
+	}
+
+	annotations := map[string]string{}
+	if params.Instance.Spec.Ingress.TLSPassthrough {
+		// the ingress controller routes the connections by their SNI, without terminating TLS
+		annotations["nginx.ingress.kubernetes.io/ssl-passthrough"] = "true"
+	}
+	for k, v := range params.Instance.Spec.Ingress.Annotations {
+		annotations[k] = v
 	}
 
 	return &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        naming.Ingress(params.Instance),
 			Namespace:   params.Instance.Namespace,
-			Annotations: params.Instance.Spec.Ingress.Annotations,
+			Annotations: annotations,
 			Labels: map[string]string{
 				"app.kubernetes.io/name":       naming.Ingress(params.Instance),
 				"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
@@ -78,22 +104,99 @@ func desiredIngresses(_ context.Context, params Params) *networkingv1.Ingress {
 			},
 		},
 		Spec: networkingv1.IngressSpec{
-			TLS: params.Instance.Spec.Ingress.TLS,
-			Rules: []networkingv1.IngressRule{
-				{
-					Host: params.Instance.Spec.Ingress.Hostname,
-					IngressRuleValue: networkingv1.IngressRuleValue{
-						HTTP: &networkingv1.HTTPIngressRuleValue{
-							Paths: paths,
-						},
-					},
+			TLS:              ingressTLS(params.Instance, rules),
+			Rules:            rules,
+			IngressClassName: params.Instance.Spec.Ingress.IngressClassName,
+		},
+	}
+}
+
+// createPathIngressRule exposes every port on the path `/<port name>` of the hostname.
+func createPathIngressRule(otelcol v1alpha1.OpenTelemetryCollector, ports []corev1.ServicePort) networkingv1.IngressRule {
+	pathType := networkingv1.PathTypePrefix
+	paths := make([]networkingv1.HTTPIngressPath, len(ports))
+	for i, p := range ports {
+		paths[i] = networkingv1.HTTPIngressPath{
+			Path:     "/" + p.Name,
+			PathType: &pathType,
+			Backend:  ingressBackend(otelcol, p),
+		}
+	}
+	return networkingv1.IngressRule{
+		Host: otelcol.Spec.Ingress.Hostname,
+		IngressRuleValue: networkingv1.IngressRuleValue{
+			HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: paths,
+			},
+		},
+	}
+}
+
+// createSubdomainIngressRules exposes every port on its own host `<port name>.<hostname>`, so that clients
+// which can't prefix the paths, like gRPC clients, can reach the receivers.
+func createSubdomainIngressRules(otelcol v1alpha1.OpenTelemetryCollector, ports []corev1.ServicePort) []networkingv1.IngressRule {
+	pathType := networkingv1.PathTypePrefix
+	rules := make([]networkingv1.IngressRule, len(ports))
+	for i, p := range ports {
+		rules[i] = networkingv1.IngressRule{
+			Host: p.Name + "." + otelcol.Spec.Ingress.Hostname,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: &pathType,
+						Backend:  ingressBackend(otelcol, p),
+					}},
 				},
 			},
-			IngressClassName: params.Instance.Spec.Ingress.IngressClassName,
+		}
+	}
+	return rules
+}
+
+func ingressBackend(otelcol v1alpha1.OpenTelemetryCollector, port corev1.ServicePort) networkingv1.IngressBackend {
+	return networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: naming.Service(otelcol),
+			Port: networkingv1.ServiceBackendPort{
+				// Valid names must be non-empty and no more than 15 characters long.
+				Name: naming.Truncate(port.Name, 15),
+			},
 		},
 	}
 }
 
+// ingressTLS returns the TLS configuration of the ingress. With the subdomain rule type, entries without hosts
+// cover the hosts of all rules. A generated certificate covers the hosts of all rules.
+func ingressTLS(otelcol v1alpha1.OpenTelemetryCollector, rules []networkingv1.IngressRule) []networkingv1.IngressTLS {
+	if otelcol.Spec.Ingress.GenerateTLSSecret {
+		hosts := make([]string, len(rules))
+		for i, rule := range rules {
+			hosts[i] = rule.Host
+		}
+		return []networkingv1.IngressTLS{{
+			Hosts:      hosts,
+			SecretName: naming.IngressTLSSecret(otelcol),
+		}}
+	}
+
+	if otelcol.Spec.Ingress.RuleType != v1alpha1.IngressRuleTypeSubdomain {
+		return otelcol.Spec.Ingress.TLS
+	}
+
+	var tls []networkingv1.IngressTLS
+	for _, t := range otelcol.Spec.Ingress.TLS {
+		entry := *t.DeepCopy()
+		if len(entry.Hosts) == 0 {
+			for _, rule := range rules {
+				entry.Hosts = append(entry.Hosts, rule.Host)
+			}
+		}
+		tls = append(tls, entry)
+	}
+	return tls
+}
+
 // Ingresses reconciles the ingress(s) required for the instance in the current context.
 func Ingresses(ctx context.Context, params Params) error {
 	isSupportedMode := true
@@ -113,6 +216,11 @@ func Ingresses(ctx context.Context, params Params) error {
 		}
 	}
 
+	// the certificate has to be there before the ingress references it
+	if err := expectedIngressTLSSecret(ctx, params, desired, time.Now()); err != nil {
+		return fmt.Errorf("failed to reconcile the ingress TLS secret: %w", err)
+	}
+
 	// first, handle the create/update parts
 	if err := expectedIngresses(ctx, params, desired); err != nil {
 		return fmt.Errorf("failed to reconcile the expected ingresses: %w", err)
@@ -213,6 +321,148 @@ func deleteIngresses(ctx context.Context, params Params, expected []networkingv1
 	return nil
 }
 
+const (
+	// ingressCertificateValidity is how long a generated certificate of the ingress is valid.
+	ingressCertificateValidity = 365 * 24 * time.Hour
+	// ingressCertificateRenewal is how long before its expiry a generated certificate of the ingress is renewed.
+	ingressCertificateRenewal = 30 * 24 * time.Hour
+)
+
+// expectedIngressTLSSecret keeps the generated certificate of the ingress valid for its hosts. The secret isn't
+// changed as long as the certificate is valid, and it's deleted once the certificate isn't generated anymore.
+func expectedIngressTLSSecret(ctx context.Context, params Params, expected []networkingv1.Ingress, now time.Time) error {
+	name := naming.IngressTLSSecret(params.Instance)
+	var hosts []string
+	for _, ingress := range expected {
+		for _, tls := range ingress.Spec.TLS {
+			if tls.SecretName == name {
+				hosts = tls.Hosts
+			}
+		}
+	}
+
+	existing := &corev1.Secret{}
+	nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: name}
+	err := params.Client.Get(ctx, nns, existing)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get: %w", err)
+	}
+	exists := err == nil
+
+	if len(hosts) == 0 {
+		// only delete the secret when it was generated for this instance
+		if exists && metav1.IsControlledBy(existing, &params.Instance) {
+			if err := params.Client.Delete(ctx, existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "secret.name", existing.Name, "secret.namespace", existing.Namespace)
+		}
+		return nil
+	}
+
+	if exists && isCertificateValid(existing.Data[corev1.TLSCertKey], hosts, now) {
+		return nil
+	}
+
+	certPEM, keyPEM, err := selfSignedCertificate(hosts, now)
+	if err != nil {
+		return fmt.Errorf("failed to generate the certificate: %w", err)
+	}
+	desired := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: params.Instance.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       name,
+				"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+				"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+	if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+
+	if !exists {
+		if err := params.Client.Create(ctx, &desired); err != nil {
+			return fmt.Errorf("failed to create: %w", err)
+		}
+		params.Log.V(2).Info("created", "secret.name", desired.Name, "secret.namespace", desired.Namespace)
+		return nil
+	}
+
+	updated := existing.DeepCopy()
+	if updated.Labels == nil {
+		updated.Labels = map[string]string{}
+	}
+	updated.ObjectMeta.OwnerReferences = desired.ObjectMeta.OwnerReferences
+	updated.Data = desired.Data
+	for k, v := range desired.ObjectMeta.Labels {
+		updated.ObjectMeta.Labels[k] = v
+	}
+
+	patch := client.MergeFrom(existing)
+
+	if err := params.Client.Patch(ctx, updated, patch); err != nil {
+		return fmt.Errorf("failed to apply changes: %w", err)
+	}
+
+	params.Log.V(2).Info("applied", "secret.name", desired.Name, "secret.namespace", desired.Namespace)
+	return nil
+}
+
+// isCertificateValid returns whether the PEM encoded certificate covers exactly the given hosts, and doesn't have to
+// be renewed yet.
+func isCertificateValid(certPEM []byte, hosts []string, now time.Time) bool {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(cert.DNSNames, hosts) && now.Add(ingressCertificateRenewal).Before(cert.NotAfter)
+}
+
+// selfSignedCertificate returns a PEM encoded self-signed certificate for the given hosts, and its private key.
+func selfSignedCertificate(hosts []string, now time.Time) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: hosts[0]},
+		DNSNames:              hosts,
+		NotBefore:             now,
+		NotAfter:              now.Add(ingressCertificateValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
 func servicePortsFromCfg(params Params) []corev1.ServicePort {
 	config, err := adapters.ConfigFromString(params.Instance.Spec.Config)
 	if err != nil {
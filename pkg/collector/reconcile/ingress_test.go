@@ -16,11 +16,15 @@ package reconcile
 
 import (
 	"context"
+	"crypto/x509"
 	_ "embed"
+	"encoding/pem"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -177,6 +181,147 @@ func TestDesiredIngresses(t *testing.T) {
 		}, got)
 	})
 
+	t.Run("should skip UDP ports", func(t *testing.T) {
+		params := Params{
+			Config: config.Config{},
+			Client: k8sClient,
+			Log:    logger,
+			Instance: v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+				},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Config: `receivers:
+  statsd:
+  zipkin:
+exporters:
+  logging:
+service:
+  pipelines:
+    metrics:
+      receivers: [statsd]
+      exporters: [logging]
+    traces:
+      receivers: [zipkin]
+      exporters: [logging]
+`,
+					Ingress: v1alpha1.Ingress{
+						Type: v1alpha1.IngressTypeNginx,
+					},
+				},
+			},
+		}
+
+		actual := desiredIngresses(context.Background(), params)
+		if assert.NotNil(t, actual) {
+			paths := actual.Spec.Rules[0].HTTP.Paths
+			assert.Len(t, paths, 1)
+			assert.Equal(t, "/zipkin", paths[0].Path)
+		}
+	})
+
+	t.Run("should create one rule per port with the subdomain rule type", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		params.Instance.Namespace = "test"
+		params.Instance.Spec.Ingress = v1alpha1.Ingress{
+			Type:           v1alpha1.IngressTypeNginx,
+			RuleType:       v1alpha1.IngressRuleTypeSubdomain,
+			Hostname:       "example.com",
+			TLSPassthrough: true,
+			TLS:            []networkingv1.IngressTLS{{SecretName: "collector-tls"}},
+			Annotations:    map[string]string{"some.key": "some.value"},
+		}
+
+		got := desiredIngresses(context.Background(), params)
+		pathType := networkingv1.PathTypePrefix
+		rule := func(port string) networkingv1.IngressRule {
+			return networkingv1.IngressRule{
+				Host: port + ".example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: "test-collector",
+									Port: networkingv1.ServiceBackendPort{
+										Name: port,
+									},
+								},
+							},
+						}},
+					},
+				},
+			}
+		}
+
+		assert.Equal(t, map[string]string{
+			"some.key": "some.value",
+			"nginx.ingress.kubernetes.io/ssl-passthrough": "true",
+		}, got.Annotations)
+		assert.Equal(t, []networkingv1.IngressRule{
+			rule("web"),
+			rule("otlp-grpc"),
+			rule("otlp-test-grpc"),
+		}, got.Spec.Rules)
+		assert.Equal(t, []networkingv1.IngressTLS{{
+			Hosts:      []string{"web.example.com", "otlp-grpc.example.com", "otlp-test-grpc.example.com"},
+			SecretName: "collector-tls",
+		}}, got.Spec.TLS)
+	})
+
+	t.Run("should reference the generated certificate", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		params.Instance.Spec.Ingress = v1alpha1.Ingress{
+			Type:              v1alpha1.IngressTypeNginx,
+			Hostname:          "example.com",
+			GenerateTLSSecret: true,
+		}
+
+		got := desiredIngresses(context.Background(), params)
+		assert.Equal(t, []networkingv1.IngressTLS{{
+			Hosts:      []string{"example.com"},
+			SecretName: "test-ingress-tls",
+		}}, got.Spec.TLS)
+	})
+
+	t.Run("should return nil with UDP ports only", func(t *testing.T) {
+		params := Params{
+			Config: config.Config{},
+			Client: k8sClient,
+			Log:    logger,
+			Instance: v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Config: `receivers:
+  statsd:
+exporters:
+  logging:
+service:
+  pipelines:
+    metrics:
+      receivers: [statsd]
+      exporters: [logging]
+`,
+					Ingress: v1alpha1.Ingress{
+						Type: v1alpha1.IngressTypeNginx,
+					},
+				},
+			},
+		}
+
+		actual := desiredIngresses(context.Background(), params)
+		assert.Nil(t, actual)
+	})
 }
 
 func TestExpectedIngresses(t *testing.T) {
@@ -251,6 +396,74 @@ func TestDeleteIngresses(t *testing.T) {
 	})
 }
 
+func TestExpectedIngressTLSSecret(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	nns := types.NamespacedName{Namespace: "default", Name: "test-ingress-tls"}
+	ingress := func(hosts ...string) []networkingv1.Ingress {
+		return []networkingv1.Ingress{{
+			Spec: networkingv1.IngressSpec{
+				TLS: []networkingv1.IngressTLS{{Hosts: hosts, SecretName: "test-ingress-tls"}},
+			},
+		}}
+	}
+	certificate := func(t *testing.T) *x509.Certificate {
+		actual := corev1.Secret{}
+		exists, err := populateObjectIfExists(t, &actual, nns)
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, corev1.SecretTypeTLS, actual.Type)
+		assert.Equal(t, instanceUID, actual.OwnerReferences[0].UID)
+		block, _ := pem.Decode(actual.Data[corev1.TLSCertKey])
+		require.NotNil(t, block)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+		return cert
+	}
+
+	t.Run("should generate the certificate", func(t *testing.T) {
+		err := expectedIngressTLSSecret(ctx, params(), ingress("web.example.com", "otlp-grpc.example.com"), now)
+		assert.NoError(t, err)
+
+		cert := certificate(t)
+		assert.Equal(t, []string{"web.example.com", "otlp-grpc.example.com"}, cert.DNSNames)
+	})
+
+	t.Run("should keep the valid certificate", func(t *testing.T) {
+		previous := certificate(t)
+
+		err := expectedIngressTLSSecret(ctx, params(), ingress("web.example.com", "otlp-grpc.example.com"), now.Add(24*time.Hour))
+		assert.NoError(t, err)
+
+		assert.Equal(t, previous.SerialNumber, certificate(t).SerialNumber)
+	})
+
+	t.Run("should renew the certificate before it expires", func(t *testing.T) {
+		previous := certificate(t)
+
+		err := expectedIngressTLSSecret(ctx, params(), ingress("web.example.com", "otlp-grpc.example.com"), previous.NotAfter.Add(-24*time.Hour))
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, previous.SerialNumber, certificate(t).SerialNumber)
+	})
+
+	t.Run("should regenerate the certificate when the hosts change", func(t *testing.T) {
+		err := expectedIngressTLSSecret(ctx, params(), ingress("web.example.com"), now)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{"web.example.com"}, certificate(t).DNSNames)
+	})
+
+	t.Run("should delete the certificate when it isn't generated anymore", func(t *testing.T) {
+		err := expectedIngressTLSSecret(ctx, params(), nil, now)
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &corev1.Secret{}, nns)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
 func TestIngresses(t *testing.T) {
 	t.Run("wrong mode", func(t *testing.T) {
 		ctx := context.Background()
@@ -18,22 +18,29 @@ package reconcile
 import (
 	"context"
 	"fmt"
+	"strings"
+	"unicode"
 
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
-// Self updates this instance's self data. This should be the last item in the reconciliation, as it causes changes
-// making params.Instance obsolete. Default values should be set in the Defaulter webhook, this should only be used
-// for the Status, which can't be set by the defaulter.
-func Self(ctx context.Context, params Params) error {
-	changed := params.Instance
+const taConfigRejectedMessagePrefix = "TargetAllocator configuration rejected: "
+
+// UpdateStatus updates this instance's status once all the other tasks have run, as it makes params.Instance
+// obsolete. The first failed task, if any, is recorded in the Reconciled condition, with the task's name as reason,
+// so that the failing task is visible without looking at the operator's logs. Default values should be set in the
+// Defaulter webhook, this should only be used for the Status, which can't be set by the defaulter.
+func UpdateStatus(ctx context.Context, params Params, failedTask string, taskErr error) error {
+	changed := params.Instance.DeepCopy()
 
 	// this field is only changed for new instances: on existing instances this
 	// field is reconciled when the operator is first started, i.e. during
@@ -43,22 +50,262 @@ func Self(ctx context.Context, params Params) error {
 		changed.Status.Version = version.OpenTelemetryCollector()
 	}
 
-	if err := updateScaleSubResourceStatus(ctx, params.Client, &changed); err != nil {
+	updateTargetAllocatorConfigStatus(changed)
+
+	collectorStatus, err := collectorReplicaStatus(ctx, params.Client, *changed)
+	if err != nil {
+		return fmt.Errorf("failed to get the workload status for the OpenTelemetry CR: %w", err)
+	}
+	if err := updateScaleSubResourceStatus(changed, collectorStatus); err != nil {
 		return fmt.Errorf("failed to update the scale subresource status for the OpenTelemetry CR: %w", err)
 	}
 
+	var taStatus *replicaStatus
+	if changed.Spec.TargetAllocator.Enabled {
+		taStatus, err = deploymentReplicaStatus(ctx, params.Client, client.ObjectKey{
+			Namespace: changed.Namespace,
+			Name:      naming.TargetAllocator(*changed),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get the target allocator status for the OpenTelemetry CR: %w", err)
+		}
+	}
+
+	changed.Status.ObservedGeneration = changed.Generation
+	updateReconciledCondition(changed, failedTask, taskErr)
+	updateConfigCondition(changed)
+	updateWorkloadConditions(changed, collectorStatus)
+	updateTargetAllocatorCondition(changed, taStatus)
+
 	statusPatch := client.MergeFrom(&params.Instance)
-	if err := params.Client.Status().Patch(ctx, &changed, statusPatch); err != nil {
+	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
 		return fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
 	}
 
 	return nil
 }
 
-func updateScaleSubResourceStatus(ctx context.Context, cli client.Client, changed *v1alpha1.OpenTelemetryCollector) error {
+// updateReconciledCondition sets the Reconciled condition, which is false if a task failed.
+func updateReconciledCondition(changed *v1alpha1.OpenTelemetryCollector, failedTask string, taskErr error) {
+	condition := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeReconciled,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1alpha1.ReasonReconcileSucceeded,
+		Message:            "All resources have been reconciled",
+		ObservedGeneration: changed.Generation,
+	}
+	if taskErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = taskFailedReason(failedTask)
+		condition.Message = fmt.Sprintf("failed to reconcile %s: %s", failedTask, taskErr)
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, condition)
+}
+
+// taskFailedReason converts a task name like "config maps" into a condition reason like "ConfigMapsFailed".
+func taskFailedReason(task string) string {
+	var reason strings.Builder
+	for _, word := range strings.FieldsFunc(task, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		reason.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	if reason.Len() == 0 || !unicode.IsLetter(rune(reason.String()[0])) {
+		return "TaskFailed"
+	}
+	return reason.String() + "Failed"
+}
+
+func updateConfigCondition(changed *v1alpha1.OpenTelemetryCollector) {
+	condition := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeConfigValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1alpha1.ReasonConfigValid,
+		Message:            "The collector configuration is valid",
+		ObservedGeneration: changed.Generation,
+	}
+	if _, err := adapters.ConfigFromString(changed.Spec.Config); err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1alpha1.ReasonConfigInvalid
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, condition)
+}
+
+// updateWorkloadConditions sets the Available and Progressing conditions based on the collector's workload,
+// which is nil if the workload doesn't exist.
+func updateWorkloadConditions(changed *v1alpha1.OpenTelemetryCollector, status *replicaStatus) {
+	available := metav1.Condition{Type: v1alpha1.ConditionTypeAvailable, ObservedGeneration: changed.Generation}
+	progressing := metav1.Condition{Type: v1alpha1.ConditionTypeProgressing, ObservedGeneration: changed.Generation}
+	switch {
+	case changed.Spec.Mode == v1alpha1.ModeSidecar:
+		available.Status, available.Reason = metav1.ConditionTrue, v1alpha1.ReasonSidecar
+		available.Message = "The collector is injected as a sidecar into the pods requesting it"
+		progressing.Status, progressing.Reason = metav1.ConditionFalse, v1alpha1.ReasonSidecar
+		progressing.Message = available.Message
+	case status == nil:
+		available.Status, available.Reason = metav1.ConditionFalse, v1alpha1.ReasonWorkloadNotFound
+		available.Message = fmt.Sprintf("The collector %s doesn't exist yet", changed.Spec.Mode)
+		progressing.Status, progressing.Reason = metav1.ConditionTrue, v1alpha1.ReasonRollingOut
+		progressing.Message = available.Message
+	default:
+		available.Reason, available.Message = replicasCondition(status)
+		available.Status = metav1.ConditionFalse
+		if status.ready() {
+			available.Status = metav1.ConditionTrue
+		}
+		progressing.Status, progressing.Reason = metav1.ConditionFalse, v1alpha1.ReasonRolloutComplete
+		progressing.Message = fmt.Sprintf("%d/%d replicas updated", status.updated, status.desired)
+		if status.rollingOut() {
+			progressing.Status, progressing.Reason = metav1.ConditionTrue, v1alpha1.ReasonRollingOut
+		}
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, available)
+	meta.SetStatusCondition(&changed.Status.Conditions, progressing)
+}
+
+// updateTargetAllocatorCondition sets the TargetAllocatorReady condition based on the target allocator's
+// deployment, which is nil if the deployment doesn't exist. The condition is removed when the target allocator
+// is disabled.
+func updateTargetAllocatorCondition(changed *v1alpha1.OpenTelemetryCollector, status *replicaStatus) {
+	if !changed.Spec.TargetAllocator.Enabled {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1alpha1.ConditionTypeTargetAllocatorReady)
+		return
+	}
+	condition := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeTargetAllocatorReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: changed.Generation,
+	}
+	if _, err := targetAllocatorPromConfig(*changed); err != nil {
+		condition.Reason = v1alpha1.ReasonTargetAllocatorConfigRejected
+		condition.Message = err.Error()
+	} else if status == nil {
+		condition.Reason = v1alpha1.ReasonWorkloadNotFound
+		condition.Message = "The target allocator deployment doesn't exist yet"
+	} else {
+		condition.Reason, condition.Message = replicasCondition(status)
+		if status.ready() {
+			condition.Status = metav1.ConditionTrue
+		}
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, condition)
+}
+
+func replicasCondition(status *replicaStatus) (string, string) {
+	message := fmt.Sprintf("%d/%d replicas ready", status.readyReplicas, status.desired)
+	if status.ready() {
+		return v1alpha1.ReasonReplicasReady, message
+	}
+	return v1alpha1.ReasonReplicasNotReady, message
+}
+
+// updateTargetAllocatorConfigStatus reports a target allocator configuration that would be rejected by the
+// target allocator. The target allocator keeps running with its last good configuration in that case, so
+// without this message the problem would go unnoticed.
+func updateTargetAllocatorConfigStatus(changed *v1alpha1.OpenTelemetryCollector) {
+	var messages []string
+	for _, msg := range changed.Status.Messages {
+		if !strings.HasPrefix(msg, taConfigRejectedMessagePrefix) {
+			messages = append(messages, msg)
+		}
+	}
+
+	if changed.Spec.TargetAllocator.Enabled {
+		if _, err := targetAllocatorPromConfig(*changed); err != nil {
+			messages = append(messages, taConfigRejectedMessagePrefix+err.Error())
+		}
+	}
+	changed.Status.Messages = messages
+}
+
+// replicaStatus is the part of a workload's status the status of the OpenTelemetryCollector is derived from.
+type replicaStatus struct {
+	desired       int32
+	current       int32
+	readyReplicas int32
+	updated       int32
+	// observed is false until the workload's controller has seen the latest generation of the workload
+	observed bool
+}
+
+func (r *replicaStatus) ready() bool {
+	return r.observed && r.readyReplicas >= r.desired
+}
+
+func (r *replicaStatus) rollingOut() bool {
+	return !r.observed || r.updated < r.desired || r.current > r.desired
+}
+
+// collectorReplicaStatus returns the status of the collector's workload. It returns nil for collectors running as
+// sidecars and if the workload doesn't exist.
+func collectorReplicaStatus(ctx context.Context, cli client.Client, otelcol v1alpha1.OpenTelemetryCollector) (*replicaStatus, error) {
+	objKey := client.ObjectKey{
+		Namespace: otelcol.GetNamespace(),
+		Name:      naming.Collector(otelcol),
+	}
+
+	switch otelcol.Spec.Mode { // nolint:exhaustive
+	case v1alpha1.ModeDeployment:
+		return deploymentReplicaStatus(ctx, cli, objKey)
+
+	case v1alpha1.ModeStatefulSet:
+		obj := &appsv1.StatefulSet{}
+		if err := cli.Get(ctx, objKey, obj); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		return &replicaStatus{
+			desired:       desiredReplicas(obj.Spec.Replicas),
+			current:       obj.Status.Replicas,
+			readyReplicas: obj.Status.ReadyReplicas,
+			updated:       obj.Status.UpdatedReplicas,
+			observed:      obj.Status.ObservedGeneration >= obj.Generation,
+		}, nil
+
+	case v1alpha1.ModeDaemonSet:
+		obj := &appsv1.DaemonSet{}
+		if err := cli.Get(ctx, objKey, obj); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		return &replicaStatus{
+			desired:       obj.Status.DesiredNumberScheduled,
+			current:       obj.Status.CurrentNumberScheduled,
+			readyReplicas: obj.Status.NumberReady,
+			updated:       obj.Status.UpdatedNumberScheduled,
+			observed:      obj.Status.ObservedGeneration >= obj.Generation,
+		}, nil
+	}
+	return nil, nil
+}
+
+// deploymentReplicaStatus returns the status of the given deployment, or nil if it doesn't exist.
+func deploymentReplicaStatus(ctx context.Context, cli client.Client, objKey client.ObjectKey) (*replicaStatus, error) {
+	obj := &appsv1.Deployment{}
+	if err := cli.Get(ctx, objKey, obj); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return &replicaStatus{
+		desired:       desiredReplicas(obj.Spec.Replicas),
+		current:       obj.Status.Replicas,
+		readyReplicas: obj.Status.ReadyReplicas,
+		updated:       obj.Status.UpdatedReplicas,
+		observed:      obj.Status.ObservedGeneration >= obj.Generation,
+	}, nil
+}
+
+// desiredReplicas returns the replicas of a workload spec, which default to 1.
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func updateScaleSubResourceStatus(changed *v1alpha1.OpenTelemetryCollector, status *replicaStatus) error {
 	mode := changed.Spec.Mode
 	if mode != v1alpha1.ModeDeployment && mode != v1alpha1.ModeStatefulSet {
 		changed.Status.Scale.Replicas = 0
+		changed.Status.Scale.ReadyReplicas = 0
 		changed.Status.Scale.Selector = ""
 
 		return nil
@@ -75,29 +322,13 @@ func updateScaleSubResourceStatus(ctx context.Context, cli client.Client, change
 	}
 	changed.Status.Scale.Selector = selector.String()
 
-	// Set the scale replicas
-	objKey := client.ObjectKey{
-		Namespace: changed.GetNamespace(),
-		Name:      naming.Collector(*changed),
-	}
-
-	var replicas int32
-	switch mode { // nolint:exhaustive
-	case v1alpha1.ModeDeployment:
-		obj := &appsv1.Deployment{}
-		if err := cli.Get(ctx, objKey, obj); err != nil {
-			return fmt.Errorf("failed to get deployment status.replicas: %w", err)
-		}
-		replicas = obj.Status.Replicas
-
-	case v1alpha1.ModeStatefulSet:
-		obj := &appsv1.StatefulSet{}
-		if err := cli.Get(ctx, objKey, obj); err != nil {
-			return fmt.Errorf("failed to get statefulSet status.replicas: %w", err)
-		}
-		replicas = obj.Status.Replicas
+	// Set the scale replicas from the workload, which doesn't exist if it couldn't be created yet
+	changed.Status.Scale.Replicas = 0
+	changed.Status.Scale.ReadyReplicas = 0
+	if status != nil {
+		changed.Status.Scale.Replicas = status.current
+		changed.Status.Scale.ReadyReplicas = status.readyReplicas
 	}
-	changed.Status.Scale.Replicas = replicas
 
 	return nil
 }
@@ -16,19 +16,22 @@ package reconcile
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 )
 
-func TestSelf(t *testing.T) {
+func TestUpdateStatus(t *testing.T) {
 	t.Run("should add version to the status", func(t *testing.T) {
 		instance := params().Instance
 		createObjectIfNotExists(t, "test", &instance)
-		err := Self(context.Background(), params())
+		err := UpdateStatus(context.Background(), params(), "", nil)
 		assert.NoError(t, err)
 
 		actual := v1alpha1.OpenTelemetryCollector{}
@@ -37,6 +40,192 @@ func TestSelf(t *testing.T) {
 		assert.True(t, exists)
 
 		assert.Equal(t, actual.Status.Version, "0.0.0")
+		assert.Equal(t, actual.Generation, actual.Status.ObservedGeneration)
+		assert.True(t, meta.IsStatusConditionTrue(actual.Status.Conditions, v1alpha1.ConditionTypeReconciled))
 
 	})
 }
+
+func TestUpdateReconciledCondition(t *testing.T) {
+	instance := v1alpha1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+
+	updateReconciledCondition(&instance, "config maps", errors.New("forbidden"))
+	condition := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionTypeReconciled)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "ConfigMapsFailed", condition.Reason)
+	assert.Equal(t, "failed to reconcile config maps: forbidden", condition.Message)
+	assert.Equal(t, int64(2), condition.ObservedGeneration)
+
+	updateReconciledCondition(&instance, "", nil)
+	condition = meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionTypeReconciled)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1alpha1.ReasonReconcileSucceeded, condition.Reason)
+}
+
+func TestTaskFailedReason(t *testing.T) {
+	assert.Equal(t, "ConfigMapsFailed", taskFailedReason("config maps"))
+	assert.Equal(t, "HorizontalPodAutoscalersFailed", taskFailedReason("horizontal pod autoscalers"))
+	assert.Equal(t, "ShouldFailFailed", taskFailedReason("should-fail"))
+	assert.Equal(t, "TaskFailed", taskFailedReason(""))
+}
+
+func TestUpdateWorkloadConditions(t *testing.T) {
+	tests := []struct {
+		name                string
+		mode                v1alpha1.Mode
+		status              *replicaStatus
+		expectedAvailable   metav1.ConditionStatus
+		expectedReason      string
+		expectedProgressing metav1.ConditionStatus
+	}{
+		{
+			name:                "all replicas ready",
+			mode:                v1alpha1.ModeDeployment,
+			status:              &replicaStatus{desired: 2, current: 2, readyReplicas: 2, updated: 2, observed: true},
+			expectedAvailable:   metav1.ConditionTrue,
+			expectedReason:      v1alpha1.ReasonReplicasReady,
+			expectedProgressing: metav1.ConditionFalse,
+		},
+		{
+			name:                "rolling out",
+			mode:                v1alpha1.ModeStatefulSet,
+			status:              &replicaStatus{desired: 2, current: 2, readyReplicas: 1, updated: 1, observed: true},
+			expectedAvailable:   metav1.ConditionFalse,
+			expectedReason:      v1alpha1.ReasonReplicasNotReady,
+			expectedProgressing: metav1.ConditionTrue,
+		},
+		{
+			name:                "generation not observed yet",
+			mode:                v1alpha1.ModeDaemonSet,
+			status:              &replicaStatus{desired: 3, current: 3, readyReplicas: 3, updated: 3, observed: false},
+			expectedAvailable:   metav1.ConditionFalse,
+			expectedReason:      v1alpha1.ReasonReplicasNotReady,
+			expectedProgressing: metav1.ConditionTrue,
+		},
+		{
+			name:                "workload not found",
+			mode:                v1alpha1.ModeDeployment,
+			expectedAvailable:   metav1.ConditionFalse,
+			expectedReason:      v1alpha1.ReasonWorkloadNotFound,
+			expectedProgressing: metav1.ConditionTrue,
+		},
+		{
+			name:                "sidecar",
+			mode:                v1alpha1.ModeSidecar,
+			expectedAvailable:   metav1.ConditionTrue,
+			expectedReason:      v1alpha1.ReasonSidecar,
+			expectedProgressing: metav1.ConditionFalse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       v1alpha1.OpenTelemetryCollectorSpec{Mode: tt.mode},
+			}
+			updateWorkloadConditions(&instance, tt.status)
+
+			available := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionTypeAvailable)
+			assert.NotNil(t, available)
+			assert.Equal(t, tt.expectedAvailable, available.Status)
+			assert.Equal(t, tt.expectedReason, available.Reason)
+			assert.Equal(t, int64(3), available.ObservedGeneration)
+			progressing := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionTypeProgressing)
+			assert.NotNil(t, progressing)
+			assert.Equal(t, tt.expectedProgressing, progressing.Status)
+		})
+	}
+}
+
+func TestUpdateTargetAllocatorCondition(t *testing.T) {
+	instance := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled: true,
+			},
+			Config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: duplicated
+      - job_name: duplicated
+`,
+		},
+	}
+	ready := &replicaStatus{desired: 1, current: 1, readyReplicas: 1, updated: 1, observed: true}
+
+	updateTargetAllocatorCondition(&instance, ready)
+	condition := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionTypeTargetAllocatorReady)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1alpha1.ReasonTargetAllocatorConfigRejected, condition.Reason)
+
+	instance.Spec.Config = `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: unique
+`
+	updateTargetAllocatorCondition(&instance, nil)
+	condition = meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionTypeTargetAllocatorReady)
+	assert.Equal(t, v1alpha1.ReasonWorkloadNotFound, condition.Reason)
+
+	updateTargetAllocatorCondition(&instance, ready)
+	assert.True(t, meta.IsStatusConditionTrue(instance.Status.Conditions, v1alpha1.ConditionTypeTargetAllocatorReady))
+
+	instance.Spec.TargetAllocator.Enabled = false
+	updateTargetAllocatorCondition(&instance, ready)
+	assert.Nil(t, meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionTypeTargetAllocatorReady))
+}
+
+func TestUpdateConfigCondition(t *testing.T) {
+	instance := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: "receivers: [",
+		},
+	}
+	updateConfigCondition(&instance)
+	condition := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionTypeConfigValid)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1alpha1.ReasonConfigInvalid, condition.Reason)
+
+	instance.Spec.Config = "receivers: {}"
+	updateConfigCondition(&instance)
+	assert.True(t, meta.IsStatusConditionTrue(instance.Status.Conditions, v1alpha1.ConditionTypeConfigValid))
+}
+
+func TestUpdateTargetAllocatorConfigStatus(t *testing.T) {
+	instance := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled: true,
+			},
+			Config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: duplicated
+      - job_name: duplicated
+`,
+		},
+		Status: v1alpha1.OpenTelemetryCollectorStatus{
+			Messages: []string{"unrelated message"},
+		},
+	}
+
+	updateTargetAllocatorConfigStatus(&instance)
+	assert.Len(t, instance.Status.Messages, 2)
+	assert.Equal(t, "unrelated message", instance.Status.Messages[0])
+	assert.Contains(t, instance.Status.Messages[1], taConfigRejectedMessagePrefix)
+
+	instance.Spec.Config = `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: duplicated
+`
+	updateTargetAllocatorConfigStatus(&instance)
+	assert.Equal(t, []string{"unrelated message"}, instance.Status.Messages)
+}
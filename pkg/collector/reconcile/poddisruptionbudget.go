@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
+)
+
+// +kubebuilder:rbac:groups="policy",resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+
+// PodDisruptionBudgets reconciles the pod disruption budget(s) required for the instance in the current context.
+func PodDisruptionBudgets(ctx context.Context, params Params) error {
+	if params.Config.PolicyVersion() == autodetect.PolicyVersionUnknown {
+		params.Log.V(2).Info("pod disruption budgets aren't available in the cluster, skipping")
+		return nil
+	}
+
+	desired := []policyv1.PodDisruptionBudget{}
+	spec := params.Instance.Spec
+	if spec.Mode == v1alpha1.ModeDeployment || spec.Mode == v1alpha1.ModeStatefulSet {
+		if collector.NeedsPodDisruptionBudget(spec.PodDisruptionBudget, spec.Replicas) {
+			desired = append(desired, collector.PodDisruptionBudget(params.Config, params.Log, params.Instance))
+		}
+	}
+
+	if spec.TargetAllocator.Enabled && collector.NeedsPodDisruptionBudget(spec.TargetAllocator.PodDisruptionBudget, spec.TargetAllocator.Replicas) {
+		desired = append(desired, targetallocator.PodDisruptionBudget(params.Config, params.Log, params.Instance))
+	}
+
+	// first, handle the create/update parts
+	if err := expectedPodDisruptionBudgets(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected pod disruption budgets: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deletePodDisruptionBudgets(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the pod disruption budgets to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func expectedPodDisruptionBudgets(ctx context.Context, params Params, expected []policyv1.PodDisruptionBudget) error {
+	policyVersion := params.Config.PolicyVersion()
+	for _, obj := range expected {
+		desired := podDisruptionBudget(policyVersion, obj)
+
+		if err := controllerutil.SetControllerReference(&params.Instance, desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		existing := podDisruptionBudget(policyVersion, policyv1.PodDisruptionBudget{})
+		nns := types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}
+		err := params.Client.Get(ctx, nns, existing)
+		if err != nil && k8serrors.IsNotFound(err) {
+			if clientErr := params.Client.Create(ctx, desired); clientErr != nil {
+				return fmt.Errorf("failed to create: %w", clientErr)
+			}
+			params.Log.V(2).Info("created", "pdb.name", desired.GetName(), "pdb.namespace", desired.GetNamespace())
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get: %w", err)
+		}
+
+		// it exists already, merge the two if the end result isn't identical to the existing one
+		updated := existing.DeepCopyObject().(client.Object)
+		setPodDisruptionBudgetSpec(updated, obj.Spec)
+		updated.SetOwnerReferences(desired.GetOwnerReferences())
+
+		annotations := updated.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		for k, v := range desired.GetAnnotations() {
+			annotations[k] = v
+		}
+		updated.SetAnnotations(annotations)
+		labels := updated.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		for k, v := range desired.GetLabels() {
+			labels[k] = v
+		}
+		updated.SetLabels(labels)
+
+		patch := client.MergeFrom(existing)
+
+		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "pdb.name", desired.GetName(), "pdb.namespace", desired.GetNamespace())
+	}
+
+	return nil
+}
+
+func deletePodDisruptionBudgets(ctx context.Context, params Params, expected []policyv1.PodDisruptionBudget) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+
+	var items []client.Object
+	if params.Config.PolicyVersion() == autodetect.PolicyVersionV1Beta1 {
+		list := &policyv1beta1.PodDisruptionBudgetList{}
+		if err := params.Client.List(ctx, list, opts...); err != nil {
+			return fmt.Errorf("failed to list: %w", err)
+		}
+		for i := range list.Items {
+			items = append(items, &list.Items[i])
+		}
+	} else {
+		list := &policyv1.PodDisruptionBudgetList{}
+		if err := params.Client.List(ctx, list, opts...); err != nil {
+			return fmt.Errorf("failed to list: %w", err)
+		}
+		for i := range list.Items {
+			items = append(items, &list.Items[i])
+		}
+	}
+
+	for _, existing := range items {
+		del := true
+		for _, keep := range expected {
+			if keep.Name == existing.GetName() && keep.Namespace == existing.GetNamespace() {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "pdb.name", existing.GetName(), "pdb.namespace", existing.GetNamespace())
+		}
+	}
+
+	return nil
+}
+
+// podDisruptionBudget converts the given budget to the version of the policy API group served by the cluster.
+func podDisruptionBudget(policyVersion autodetect.PolicyVersion, pdb policyv1.PodDisruptionBudget) client.Object {
+	if policyVersion == autodetect.PolicyVersionV1Beta1 {
+		converted := &policyv1beta1.PodDisruptionBudget{ObjectMeta: pdb.ObjectMeta}
+		setPodDisruptionBudgetSpec(converted, pdb.Spec)
+		return converted
+	}
+	return &pdb
+}
+
+func setPodDisruptionBudgetSpec(obj client.Object, spec policyv1.PodDisruptionBudgetSpec) {
+	switch pdb := obj.(type) {
+	case *policyv1beta1.PodDisruptionBudget:
+		pdb.Spec = policyv1beta1.PodDisruptionBudgetSpec{
+			MinAvailable:   spec.MinAvailable,
+			Selector:       spec.Selector,
+			MaxUnavailable: spec.MaxUnavailable,
+		}
+	case *policyv1.PodDisruptionBudget:
+		pdb.Spec = spec
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect/autodetecttest"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
+)
+
+func TestExpectedPodDisruptionBudgets(t *testing.T) {
+	param := params()
+	expectedPDB := collector.PodDisruptionBudget(param.Config, logger, param.Instance)
+	expectedTAPDB := targetallocator.PodDisruptionBudget(param.Config, logger, param.Instance)
+
+	t.Run("should create pod disruption budgets", func(t *testing.T) {
+		err := expectedPodDisruptionBudgets(context.Background(), param, []policyv1.PodDisruptionBudget{expectedPDB, expectedTAPDB})
+		assert.NoError(t, err)
+
+		actual := policyv1.PodDisruptionBudget{}
+		exists, err := populateObjectIfExists(t, &actual, types.NamespacedName{Namespace: "default", Name: "test-collector"})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, intstr.FromInt(1), *actual.Spec.MaxUnavailable)
+
+		exists, err = populateObjectIfExists(t, &policyv1.PodDisruptionBudget{}, types.NamespacedName{Namespace: "default", Name: "test-targetallocator"})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("should update pod disruption budget", func(t *testing.T) {
+		minAvailable := intstr.FromString("50%")
+		updated := expectedPDB.DeepCopy()
+		updated.Spec.MaxUnavailable = nil
+		updated.Spec.MinAvailable = &minAvailable
+
+		err := expectedPodDisruptionBudgets(context.Background(), param, []policyv1.PodDisruptionBudget{*updated})
+		assert.NoError(t, err)
+
+		actual := policyv1.PodDisruptionBudget{}
+		exists, err := populateObjectIfExists(t, &actual, types.NamespacedName{Namespace: "default", Name: "test-collector"})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Nil(t, actual.Spec.MaxUnavailable)
+		assert.Equal(t, minAvailable, *actual.Spec.MinAvailable)
+	})
+
+	t.Run("should delete pod disruption budget", func(t *testing.T) {
+		err := deletePodDisruptionBudgets(context.Background(), param, []policyv1.PodDisruptionBudget{expectedPDB})
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &policyv1.PodDisruptionBudget{}, types.NamespacedName{Namespace: "default", Name: "test-collector"})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = populateObjectIfExists(t, &policyv1.PodDisruptionBudget{}, types.NamespacedName{Namespace: "default", Name: "test-targetallocator"})
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestPodDisruptionBudgetsWithoutPolicyAPI(t *testing.T) {
+	mockAutoDetector := &autodetecttest.Mock{
+		HPAVersionFunc: func() (autodetect.AutoscalingVersion, error) {
+			return autodetect.DefaultAutoscalingVersion, nil
+		},
+		PDBVersionFunc: func() (autodetect.PolicyVersion, error) {
+			return autodetect.PolicyVersionUnknown, nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mockAutoDetector))
+	require.NoError(t, cfg.AutoDetect())
+
+	param := params()
+	param.Config = cfg
+	// the reconciliation must not reach out to the cluster
+	param.Client = nil
+
+	err := PodDisruptionBudgets(context.Background(), param)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
+)
+
+func TestDesiredRoleBindings(t *testing.T) {
+	t.Run("should not create any role binding without persistence", func(t *testing.T) {
+		params := params()
+		params.Instance.Spec.TargetAllocator.Enabled = true
+		desired := desiredRoleBindings(params)
+		assert.Len(t, desired, 0)
+	})
+
+	t.Run("should create the targetallocator persistence role binding", func(t *testing.T) {
+		params := params()
+		params.Instance.Spec.TargetAllocator.Enabled = true
+		params.Instance.Spec.TargetAllocator.Persistence.Enabled = true
+		desired := desiredRoleBindings(params)
+		assert.Len(t, desired, 1)
+		assert.Equal(t, targetallocator.PersistenceRoleBinding(params.Instance), desired[0])
+	})
+}
+
+func TestExpectedRoleBindings(t *testing.T) {
+	t.Run("should create and update the targetallocator persistence role binding", func(t *testing.T) {
+		existing := rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-targetallocator-persistence",
+				Namespace: "default",
+			},
+		}
+		createObjectIfNotExists(t, "test-targetallocator-persistence", &existing)
+
+		desired := targetallocator.PersistenceRoleBinding(params().Instance)
+		err := expectedRoleBindings(context.Background(), params(), []rbacv1.RoleBinding{desired})
+		assert.NoError(t, err)
+
+		actual := rbacv1.RoleBinding{}
+		exists, err := populateObjectIfExists(t, &actual, types.NamespacedName{Namespace: "default", Name: "test-targetallocator-persistence"})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, instanceUID, actual.OwnerReferences[0].UID)
+		assert.Equal(t, desired.Subjects, actual.Subjects)
+	})
+}
+
+func TestDeleteRoleBindings(t *testing.T) {
+	t.Run("should delete the managed role binding", func(t *testing.T) {
+		existing := rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-delete-targetallocator-persistence",
+				Namespace: "default",
+				Labels: map[string]string{
+					"app.kubernetes.io/instance":   "default.test",
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				},
+			},
+		}
+		createObjectIfNotExists(t, "test-delete-targetallocator-persistence", &existing)
+
+		err := deleteRoleBindings(context.Background(), params(), []rbacv1.RoleBinding{})
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &rbacv1.RoleBinding{}, types.NamespacedName{Namespace: "default", Name: "test-delete-targetallocator-persistence"})
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -89,6 +89,18 @@ func desiredService(ctx context.Context, params Params) *corev1.Service {
 		return nil
 	}
 
+	// exporters and connectors listening on an endpoint, like the prometheus exporter, are exposed along with the
+	// receivers, unless they conflict with a receiver port
+	exporterPorts, err := adapters.ConfigToExporterPorts(params.Log, config)
+	if err != nil {
+		params.Log.Error(err, "couldn't build the service ports for the exporters")
+	}
+	connectorPorts, err := adapters.ConfigToConnectorPorts(params.Log, config)
+	if err != nil {
+		params.Log.Error(err, "couldn't build the service ports for the connectors")
+	}
+	ports = adapters.AppendUniquePorts(params.Log, ports, append(exporterPorts, connectorPorts...)...)
+
 	if len(params.Instance.Spec.Ports) > 0 {
 		// we should add all the ports from the CR
 		// there are two cases where problems might occur:
@@ -189,14 +201,38 @@ func monitoringService(ctx context.Context, params Params) *corev1.Service {
 		Spec: corev1.ServiceSpec{
 			Selector:  collector.SelectorLabels(params.Instance),
 			ClusterIP: "",
-			Ports: []corev1.ServicePort{{
-				Name: "monitoring",
-				Port: 8888,
-			}},
+			Ports:     append([]corev1.ServicePort{{Name: "monitoring", Port: 8888}}, extensionPorts(params)...),
 		},
 	}
 }
 
+// extensionPorts returns the ports of the extensions listening on an endpoint, like the health_check or zpages
+// extensions. They are exposed by the monitoring service, as they are meant for operating the collector.
+func extensionPorts(params Params) []corev1.ServicePort {
+	config, err := adapters.ConfigFromString(params.Instance.Spec.Config)
+	if err != nil {
+		params.Log.Error(err, "couldn't extract the configuration from the context")
+		return nil
+	}
+
+	ports, err := adapters.ConfigToExtensionPorts(params.Log, config)
+	if err != nil {
+		params.Log.Error(err, "couldn't build the service ports for the extensions")
+		return nil
+	}
+
+	// the monitoring port is already taken
+	result := []corev1.ServicePort{}
+	for _, p := range ports {
+		if p.Name == "monitoring" || p.Port == 8888 {
+			params.Log.Info("extension port conflicts with the monitoring port, skipping", "port.name", p.Name, "port.num", p.Port)
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
 func expectedServices(ctx context.Context, params Params, expected []corev1.Service) error {
 	for _, obj := range expected {
 		desired := obj
@@ -130,6 +130,49 @@ func TestDesiredService(t *testing.T) {
 
 	})
 
+	t.Run("should return service with inferred exporter ports", func(t *testing.T) {
+		param := params()
+		param.Instance.Spec.Ports = nil
+		param.Instance.Spec.Config = `receivers:
+  jaeger:
+    protocols:
+      grpc:
+exporters:
+  prometheus:
+    endpoint: 0.0.0.0:8889
+service:
+  pipelines:
+    metrics:
+      receivers: [jaeger]
+      exporters: [prometheus]`
+
+		actual := desiredService(context.Background(), param)
+
+		assert.NotNil(t, actual)
+		assert.Contains(t, actual.Spec.Ports, v1.ServicePort{Name: "prometheus", Protocol: "TCP", Port: 8889})
+	})
+
+	t.Run("should skip the exporter ports conflicting with receiver ports", func(t *testing.T) {
+		param := params()
+		param.Instance.Spec.Ports = nil
+		param.Instance.Spec.Config = `receivers:
+  examplereceiver:
+    endpoint: 0.0.0.0:8889
+exporters:
+  prometheus:
+    endpoint: 0.0.0.0:8889
+service:
+  pipelines:
+    metrics:
+      receivers: [examplereceiver]
+      exporters: [prometheus]`
+
+		actual := desiredService(context.Background(), param)
+
+		assert.NotNil(t, actual)
+		assert.Equal(t, []v1.ServicePort{{Name: "examplereceiver", Port: 8889}}, actual.Spec.Ports)
+	})
+
 }
 
 func TestExpectedServices(t *testing.T) {
@@ -227,6 +270,37 @@ func TestMonitoringService(t *testing.T) {
 		assert.Equal(t, expected, actual.Spec.Ports)
 
 	})
+
+	t.Run("returned service should expose extension ports", func(t *testing.T) {
+		param := params()
+		param.Instance.Spec.Config = `receivers:
+  otlp:
+    protocols:
+      grpc:
+extensions:
+  health_check:
+exporters:
+  logging:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [logging]`
+		expected := []v1.ServicePort{
+			{
+				Name: "monitoring",
+				Port: 8888,
+			},
+			{
+				Name:     "health-check",
+				Port:     13133,
+				Protocol: v1.ProtocolTCP,
+			},
+		}
+		actual := monitoringService(context.Background(), param)
+		assert.Equal(t, expected, actual.Spec.Ports)
+	})
 }
 
 func service(name string, ports []v1.ServicePort) v1.Service {
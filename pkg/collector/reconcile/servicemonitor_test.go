@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+func TestExpectedServiceMonitors(t *testing.T) {
+	t.Run("should create and update service monitor entry", func(t *testing.T) {
+		ctx := context.Background()
+		param := params()
+
+		err := expectedServiceMonitors(ctx, param, []monitoringv1.ServiceMonitor{collector.ServiceMonitor(param.Config, logger, param.Instance)})
+		assert.NoError(t, err)
+
+		nns := types.NamespacedName{Namespace: "default", Name: naming.ServiceMonitor(param.Instance)}
+		exists, err := populateObjectIfExists(t, &monitoringv1.ServiceMonitor{}, nns)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		// update fields
+		desired := collector.ServiceMonitor(param.Config, logger, param.Instance)
+		desired.Spec.Endpoints[0].Interval = "30s"
+
+		err = expectedServiceMonitors(ctx, param, []monitoringv1.ServiceMonitor{desired})
+		assert.NoError(t, err)
+
+		got := &monitoringv1.ServiceMonitor{}
+		err = param.Client.Get(ctx, nns, got)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "30s", got.Spec.Endpoints[0].Interval)
+	})
+}
+
+func TestDeleteServiceMonitors(t *testing.T) {
+	t.Run("should delete excess service monitor", func(t *testing.T) {
+		// create
+		ctx := context.Background()
+		param := params()
+
+		err := expectedServiceMonitors(ctx, param, []monitoringv1.ServiceMonitor{collector.ServiceMonitor(param.Config, logger, param.Instance)})
+		assert.NoError(t, err)
+
+		nns := types.NamespacedName{Namespace: "default", Name: naming.ServiceMonitor(param.Instance)}
+		exists, err := populateObjectIfExists(t, &monitoringv1.ServiceMonitor{}, nns)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		// delete
+		err = deleteServiceMonitors(ctx, param, []monitoringv1.ServiceMonitor{})
+		assert.NoError(t, err)
+
+		// check
+		exists, err = populateObjectIfExists(t, &monitoringv1.ServiceMonitor{}, nns)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestServiceMonitorsWithoutPrometheusCRs(t *testing.T) {
+	param := params()
+	param.Instance.Spec.Observability.Metrics.EnableMetrics = true
+	param.Instance.Spec.TargetAllocator.Enabled = true
+	// without a client, any call to the API would panic
+	param.Client = nil
+
+	err := ServiceMonitors(context.Background(), param)
+	assert.NoError(t, err)
+}
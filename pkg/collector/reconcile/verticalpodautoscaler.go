@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	vpav1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+
+// VerticalPodAutoscalers reconciles the vertical pod autoscaler(s) required for the instance in the current context.
+func VerticalPodAutoscalers(ctx context.Context, params Params) error {
+	// the VerticalPodAutoscaler CRD isn't installed, there is nothing to create or delete
+	if params.Config.VPAAvailability() != autodetect.VPAAvailable {
+		return nil
+	}
+
+	desired := []vpav1.VerticalPodAutoscaler{}
+	if params.Instance.Spec.Mode != v1alpha1.ModeSidecar && params.Instance.Spec.VerticalAutoscaler != nil {
+		desired = append(desired, collector.VerticalPodAutoscaler(params.Config, params.Log, params.Instance))
+	}
+
+	// first, handle the create/update parts
+	if err := expectedVerticalPodAutoscalers(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected vertical pod autoscalers: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteVerticalPodAutoscalers(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the vertical pod autoscalers to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func expectedVerticalPodAutoscalers(ctx context.Context, params Params, expected []vpav1.VerticalPodAutoscaler) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		existing := &vpav1.VerticalPodAutoscaler{}
+		nns := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
+		err := params.Client.Get(ctx, nns, existing)
+		if err != nil && k8serrors.IsNotFound(err) {
+			if clientErr := params.Client.Create(ctx, &desired); clientErr != nil {
+				return fmt.Errorf("failed to create: %w", clientErr)
+			}
+			params.Log.V(2).Info("created", "vpa.name", desired.Name, "vpa.namespace", desired.Namespace)
+
+			// a fixed memory limit of the memory_limiter doesn't follow the limits recommended by the autoscaler,
+			// warn once when the autoscaler is created rather than on every reconciliation
+			if params.Recorder != nil && collector.MemoryLimiterUsesFixedLimit(params.Log, params.Instance.Spec.Config) {
+				params.Recorder.Event(&params.Instance, corev1.EventTypeWarning, "MemoryLimiter",
+					"The memory_limiter processor sets limit_mib, consider using limit_percentage and spike_limit_percentage along with the verticalAutoscaler")
+			}
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get: %w", err)
+		}
+
+		// it exists already, merge the two if the end result isn't identical to the existing one
+		updated := existing.DeepCopy()
+		if updated.Annotations == nil {
+			updated.Annotations = map[string]string{}
+		}
+		if updated.Labels == nil {
+			updated.Labels = map[string]string{}
+		}
+
+		updated.Spec = desired.Spec
+		updated.ObjectMeta.OwnerReferences = desired.ObjectMeta.OwnerReferences
+
+		for k, v := range desired.ObjectMeta.Annotations {
+			updated.ObjectMeta.Annotations[k] = v
+		}
+		for k, v := range desired.ObjectMeta.Labels {
+			updated.ObjectMeta.Labels[k] = v
+		}
+
+		patch := client.MergeFrom(existing)
+
+		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "vpa.name", desired.Name, "vpa.namespace", desired.Namespace)
+	}
+
+	return nil
+}
+
+func deleteVerticalPodAutoscalers(ctx context.Context, params Params, expected []vpav1.VerticalPodAutoscaler) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+	list := &vpav1.VerticalPodAutoscalerList{}
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.Name == existing.Name && keep.Namespace == existing.Namespace {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "vpa.name", existing.Name, "vpa.namespace", existing.Namespace)
+		}
+	}
+
+	return nil
+}
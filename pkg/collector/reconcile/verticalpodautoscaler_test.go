@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	vpav1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+const memoryLimiterConfig = `processors:
+  memory_limiter:
+    check_interval: 1s
+    limit_mib: 400
+`
+
+func TestExpectedVerticalPodAutoscalers(t *testing.T) {
+	t.Run("should create and update vpa entry", func(t *testing.T) {
+		ctx := context.Background()
+		param := params()
+		param.Instance.Spec.Config = memoryLimiterConfig
+		param.Instance.Spec.VerticalAutoscaler = &v1alpha1.VerticalAutoscalerSpec{UpdateMode: v1alpha1.VerticalAutoscalerUpdateModeInitial}
+		recorder := record.NewFakeRecorder(10)
+		param.Recorder = recorder
+
+		err := expectedVerticalPodAutoscalers(ctx, param, []vpav1.VerticalPodAutoscaler{collector.VerticalPodAutoscaler(param.Config, logger, param.Instance)})
+		assert.NoError(t, err)
+
+		nns := types.NamespacedName{Namespace: "default", Name: "test-collector"}
+		exists, err := populateObjectIfExists(t, &vpav1.VerticalPodAutoscaler{}, nns)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		require.Len(t, recorder.Events, 1, "the memory_limiter should be warned about when the vpa is created")
+		assert.Contains(t, <-recorder.Events, "MemoryLimiter")
+
+		// update fields
+		param.Instance.Spec.VerticalAutoscaler.UpdateMode = v1alpha1.VerticalAutoscalerUpdateModeOff
+
+		err = expectedVerticalPodAutoscalers(ctx, param, []vpav1.VerticalPodAutoscaler{collector.VerticalPodAutoscaler(param.Config, logger, param.Instance)})
+		assert.NoError(t, err)
+
+		got := &vpav1.VerticalPodAutoscaler{}
+		err = param.Client.Get(ctx, nns, got)
+		assert.NoError(t, err)
+		assert.Equal(t, vpav1.UpdateModeOff, *got.Spec.UpdatePolicy.UpdateMode)
+		assert.Empty(t, recorder.Events, "the memory_limiter should only be warned about once")
+	})
+}
+
+func TestDeleteVerticalPodAutoscalers(t *testing.T) {
+	t.Run("should delete excess vpa", func(t *testing.T) {
+		// create
+		ctx := context.Background()
+		param := params()
+		param.Instance.Spec.VerticalAutoscaler = &v1alpha1.VerticalAutoscalerSpec{}
+
+		err := expectedVerticalPodAutoscalers(ctx, param, []vpav1.VerticalPodAutoscaler{collector.VerticalPodAutoscaler(param.Config, logger, param.Instance)})
+		assert.NoError(t, err)
+
+		nns := types.NamespacedName{Namespace: "default", Name: "test-collector"}
+		exists, err := populateObjectIfExists(t, &vpav1.VerticalPodAutoscaler{}, nns)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		// delete
+		err = deleteVerticalPodAutoscalers(ctx, param, []vpav1.VerticalPodAutoscaler{})
+		assert.NoError(t, err)
+
+		// check
+		exists, err = populateObjectIfExists(t, &vpav1.VerticalPodAutoscaler{}, nns)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestVerticalPodAutoscalersWithoutVPA(t *testing.T) {
+	param := params()
+	param.Instance.Spec.VerticalAutoscaler = &v1alpha1.VerticalAutoscalerSpec{}
+	// without a client, any call to the API would panic
+	param.Client = nil
+
+	err := VerticalPodAutoscalers(context.Background(), param)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/go-logr/logr"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// ServiceMonitor builds the service monitor scraping the collector's own metrics through the monitoring service.
+func ServiceMonitor(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) monitoringv1.ServiceMonitor {
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = naming.ServiceMonitor(otelcol)
+
+	selector := SelectorLabels(otelcol)
+	selector["app.kubernetes.io/name"] = naming.MonitoringService(otelcol)
+
+	return monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.ServiceMonitor(otelcol),
+			Namespace: otelcol.Namespace,
+			Labels:    labels,
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Endpoints: []monitoringv1.Endpoint{{
+				Port: "monitoring",
+			}},
+			NamespaceSelector: monitoringv1.NamespaceSelector{
+				MatchNames: []string{otelcol.Namespace},
+			},
+			Selector: metav1.LabelSelector{
+				MatchLabels: selector,
+			},
+		},
+	}
+}
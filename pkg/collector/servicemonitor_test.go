@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestServiceMonitor(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-ns",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Observability: v1alpha1.ObservabilitySpec{
+				Metrics: v1alpha1.MetricsConfigSpec{EnableMetrics: true},
+			},
+		},
+	}
+
+	// test
+	sm := ServiceMonitor(config.New(), logger, otelcol)
+
+	// verify
+	assert.Equal(t, "my-instance-collector", sm.Name)
+	assert.Equal(t, "my-ns", sm.Namespace)
+	assert.Equal(t, "my-instance-collector", sm.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, "my-ns.my-instance", sm.Labels["app.kubernetes.io/instance"])
+	assert.Equal(t, []string{"my-ns"}, sm.Spec.NamespaceSelector.MatchNames)
+	assert.Equal(t, "my-instance-collector-monitoring", sm.Spec.Selector.MatchLabels["app.kubernetes.io/name"])
+	assert.Len(t, sm.Spec.Endpoints, 1)
+	assert.Equal(t, "monitoring", sm.Spec.Endpoints[0].Port)
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"strings"
+
+	"github.com/go-logr/logr"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	vpav1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// VerticalPodAutoscaler builds the vertical pod autoscaler for the workload of the given instance.
+func VerticalPodAutoscaler(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) vpav1.VerticalPodAutoscaler {
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = naming.Collector(otelcol)
+
+	kind := "Deployment"
+	switch otelcol.Spec.Mode {
+	case v1alpha1.ModeDaemonSet:
+		kind = "DaemonSet"
+	case v1alpha1.ModeStatefulSet:
+		kind = "StatefulSet"
+	}
+
+	spec := otelcol.Spec.VerticalAutoscaler
+	vpa := vpav1.VerticalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.VerticalPodAutoscaler(otelcol),
+			Namespace:   otelcol.Namespace,
+			Labels:      labels,
+			Annotations: Annotations(otelcol),
+		},
+		Spec: vpav1.VerticalPodAutoscalerSpec{
+			TargetRef: &autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       naming.Collector(otelcol),
+			},
+			ResourcePolicy: &vpav1.PodResourcePolicy{
+				ContainerPolicies: []vpav1.ContainerResourcePolicy{
+					{
+						ContainerName: naming.Container(),
+						MinAllowed:    spec.MinAllowed,
+						MaxAllowed:    spec.MaxAllowed,
+					},
+				},
+			},
+		},
+	}
+	if spec.UpdateMode != "" {
+		updateMode := vpav1.UpdateMode(spec.UpdateMode)
+		vpa.Spec.UpdatePolicy = &vpav1.PodUpdatePolicy{UpdateMode: &updateMode}
+	}
+	// the horizontal pod autoscaler scales on the CPU usage, changing the CPU requests would work against it
+	if otelcol.Spec.MaxReplicas != nil || (otelcol.Spec.Autoscaler != nil && otelcol.Spec.Autoscaler.MaxReplicas != nil) {
+		controlledResources := []corev1.ResourceName{corev1.ResourceMemory}
+		vpa.Spec.ResourcePolicy.ContainerPolicies[0].ControlledResources = &controlledResources
+	}
+	return vpa
+}
+
+// MemoryLimiterUsesFixedLimit returns whether the memory_limiter processor of the given configuration sets its
+// limit in MiB. Such a limit doesn't follow the memory limits set by a vertical pod autoscaler, unlike limit_percentage.
+func MemoryLimiterUsesFixedLimit(logger logr.Logger, configStr string) bool {
+	cfg, err := adapters.ConfigFromString(configStr)
+	if err != nil {
+		logger.V(2).Info("couldn't parse the configuration", "error", err)
+		return false
+	}
+	processors, ok := cfg["processors"].(map[interface{}]interface{})
+	if !ok {
+		return false
+	}
+	for name, processor := range processors {
+		nameStr, ok := name.(string)
+		if !ok || (nameStr != "memory_limiter" && !strings.HasPrefix(nameStr, "memory_limiter/")) {
+			continue
+		}
+		settings, ok := processor.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		if _, ok := settings["limit_mib"]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	vpav1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestVerticalPodAutoscaler(t *testing.T) {
+	minAllowed := corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")}
+	maxAllowed := corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}
+
+	for _, tt := range []struct {
+		mode         v1alpha1.Mode
+		expectedKind string
+	}{
+		{v1alpha1.ModeDeployment, "Deployment"},
+		{v1alpha1.ModeDaemonSet, "DaemonSet"},
+		{v1alpha1.ModeStatefulSet, "StatefulSet"},
+	} {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-instance",
+					Namespace: "my-ns",
+				},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode: tt.mode,
+					VerticalAutoscaler: &v1alpha1.VerticalAutoscalerSpec{
+						UpdateMode: v1alpha1.VerticalAutoscalerUpdateModeInitial,
+						MinAllowed: minAllowed,
+						MaxAllowed: maxAllowed,
+					},
+				},
+			}
+
+			// test
+			vpa := VerticalPodAutoscaler(config.New(), logger, otelcol)
+
+			// verify
+			assert.Equal(t, "my-instance-collector", vpa.Name)
+			assert.Equal(t, "my-ns", vpa.Namespace)
+			assert.Equal(t, "my-instance-collector", vpa.Labels["app.kubernetes.io/name"])
+			assert.Equal(t, "apps/v1", vpa.Spec.TargetRef.APIVersion)
+			assert.Equal(t, tt.expectedKind, vpa.Spec.TargetRef.Kind)
+			assert.Equal(t, "my-instance-collector", vpa.Spec.TargetRef.Name)
+			assert.Equal(t, vpav1.UpdateModeInitial, *vpa.Spec.UpdatePolicy.UpdateMode)
+			assert.Len(t, vpa.Spec.ResourcePolicy.ContainerPolicies, 1)
+			assert.Equal(t, "otc-container", vpa.Spec.ResourcePolicy.ContainerPolicies[0].ContainerName)
+			assert.Equal(t, minAllowed, vpa.Spec.ResourcePolicy.ContainerPolicies[0].MinAllowed)
+			assert.Equal(t, maxAllowed, vpa.Spec.ResourcePolicy.ContainerPolicies[0].MaxAllowed)
+		})
+	}
+}
+
+func TestVerticalPodAutoscalerWithoutUpdateMode(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			VerticalAutoscaler: &v1alpha1.VerticalAutoscalerSpec{},
+		},
+	}
+
+	// test
+	vpa := VerticalPodAutoscaler(config.New(), logger, otelcol)
+
+	// verify
+	assert.Nil(t, vpa.Spec.UpdatePolicy)
+	assert.Nil(t, vpa.Spec.ResourcePolicy.ContainerPolicies[0].ControlledResources)
+}
+
+func TestVerticalPodAutoscalerWithHorizontalAutoscaler(t *testing.T) {
+	maxReplicas := int32(3)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Autoscaler:         &v1alpha1.AutoscalerSpec{MaxReplicas: &maxReplicas},
+			VerticalAutoscaler: &v1alpha1.VerticalAutoscalerSpec{},
+		},
+	}
+
+	// test
+	vpa := VerticalPodAutoscaler(config.New(), logger, otelcol)
+
+	// verify
+	assert.Equal(t, &[]corev1.ResourceName{corev1.ResourceMemory}, vpa.Spec.ResourcePolicy.ContainerPolicies[0].ControlledResources)
+}
+
+func TestMemoryLimiterUsesFixedLimit(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		config   string
+		expected bool
+	}{
+		{
+			desc: "limit in MiB",
+			config: `processors:
+  memory_limiter:
+    check_interval: 1s
+    limit_mib: 400
+`,
+			expected: true,
+		},
+		{
+			desc: "named limit in MiB",
+			config: `processors:
+  memory_limiter/custom:
+    limit_mib: 400
+`,
+			expected: true,
+		},
+		{
+			desc: "limit in percent",
+			config: `processors:
+  memory_limiter:
+    check_interval: 1s
+    limit_percentage: 80
+    spike_limit_percentage: 25
+`,
+			expected: false,
+		},
+		{
+			desc:     "no memory limiter",
+			config:   "processors:\n  batch:\n",
+			expected: false,
+		},
+		{
+			desc:     "invalid config",
+			config:   "🦄",
+			expected: false,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MemoryLimiterUsesFixedLimit(logger, tt.config))
+		})
+	}
+}
@@ -29,6 +29,17 @@ func TAConfigMap(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
 }
 
+// TAAssignmentsConfigMap returns the name for the config map the TargetAllocator persists its target assignments in.
+func TAAssignmentsConfigMap(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-targetallocator-assignments", 63, otelcol.Name))
+}
+
+// TAPersistenceRole returns the name for the role, and its binding, allowing the TargetAllocator to persist its target
+// assignments.
+func TAPersistenceRole(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-targetallocator-persistence", 63, otelcol.Name))
+}
+
 // ConfigMapVolume returns the name to use for the config map's volume in the pod.
 func ConfigMapVolume() string {
 	return "otc-internal"
@@ -59,6 +70,31 @@ func HorizontalPodAutoscaler(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
 }
 
+// VerticalPodAutoscaler builds the vertical pod autoscaler name based on the instance.
+func VerticalPodAutoscaler(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+}
+
+// PodDisruptionBudget builds the pod disruption budget name based on the instance.
+func PodDisruptionBudget(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+}
+
+// TAPodDisruptionBudget returns the name to use for the TargetAllocator pod disruption budget.
+func TAPodDisruptionBudget(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
+}
+
+// ServiceMonitor builds the service monitor name based on the instance.
+func ServiceMonitor(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+}
+
+// TAServiceMonitor returns the name to use for the TargetAllocator service monitor.
+func TAServiceMonitor(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
+}
+
 // HorizontalPodAutoscaler builds the collector (deployment/daemonset) name based on the instance.
 func OpenTelemetryCollector(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s", 63, otelcol.Name))
@@ -94,11 +130,26 @@ func Ingress(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s-ingress", 63, otelcol.Name))
 }
 
+// IngressTLSSecret builds the name of the secret holding the generated certificate of the ingress.
+func IngressTLSSecret(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-ingress-tls", 63, otelcol.Name))
+}
+
 // Route builds the route name based on the instance.
 func Route(otelcol v1alpha1.OpenTelemetryCollector, prefix string) string {
 	return DNSName(Truncate("%s-%s-route", 63, prefix, otelcol.Name))
 }
 
+// HTTPRoute builds the Gateway API HTTPRoute name based on the instance.
+func HTTPRoute(otelcol v1alpha1.OpenTelemetryCollector, prefix string) string {
+	return DNSName(Truncate("%s-%s-httproute", 63, prefix, otelcol.Name))
+}
+
+// GRPCRoute builds the Gateway API GRPCRoute name based on the instance.
+func GRPCRoute(otelcol v1alpha1.OpenTelemetryCollector, prefix string) string {
+	return DNSName(Truncate("%s-%s-grpcroute", 63, prefix, otelcol.Name))
+}
+
 // TAService returns the name to use for the TargetAllocator service.
 func TAService(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestPodDisruptionBudget(t *testing.T) {
+	// prepare
+	maxUnavailable := intstr.FromString("25%")
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				PodDisruptionBudget: &v1alpha1.PodDisruptionBudgetSpec{
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	pdb := PodDisruptionBudget(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, "my-instance-targetallocator", pdb.Name)
+	assert.Equal(t, "my-instance-targetallocator", pdb.Labels["app.kubernetes.io/name"])
+	assert.Nil(t, pdb.Spec.MinAvailable)
+	assert.Equal(t, &maxUnavailable, pdb.Spec.MaxUnavailable)
+
+	// the budget should select the pods of the deployment
+	assert.Equal(t, Deployment(cfg, logger, otelcol).Spec.Selector.MatchLabels, pdb.Spec.Selector.MatchLabels)
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// PersistenceRole returns the role allowing the TargetAllocator to persist its target assignments in a config map.
+// The config map is created by the TargetAllocator itself, and the create verb can't be restricted to a name.
+func PersistenceRole(otelcol v1alpha1.OpenTelemetryCollector) rbacv1.Role {
+	name := naming.TAPersistenceRole(otelcol)
+	labels := Labels(otelcol)
+	labels["app.kubernetes.io/name"] = name
+
+	return rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   otelcol.Namespace,
+			Labels:      labels,
+			Annotations: otelcol.Annotations,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"create"},
+			},
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				ResourceNames: []string{naming.TAAssignmentsConfigMap(otelcol)},
+				Verbs:         []string{"get", "update"},
+			},
+		},
+	}
+}
+
+// PersistenceRoleBinding returns the binding of the persistence role to the TargetAllocator's service account.
+func PersistenceRoleBinding(otelcol v1alpha1.OpenTelemetryCollector) rbacv1.RoleBinding {
+	name := naming.TAPersistenceRole(otelcol)
+	labels := Labels(otelcol)
+	labels["app.kubernetes.io/name"] = name
+
+	return rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   otelcol.Namespace,
+			Labels:      labels,
+			Annotations: otelcol.Annotations,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      ServiceAccountName(otelcol),
+				Namespace: otelcol.Namespace,
+			},
+		},
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestPersistenceRole(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+	}
+
+	// test
+	role := PersistenceRole(otelcol)
+
+	// verify
+	assert.Equal(t, "my-instance-targetallocator-persistence", role.Name)
+	assert.Equal(t, "observability", role.Namespace)
+	assert.Equal(t, "my-instance-targetallocator-persistence", role.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"configmaps"},
+			Verbs:     []string{"create"},
+		},
+		{
+			APIGroups:     []string{""},
+			Resources:     []string{"configmaps"},
+			ResourceNames: []string{"my-instance-targetallocator-assignments"},
+			Verbs:         []string{"get", "update"},
+		},
+	}, role.Rules)
+}
+
+func TestPersistenceRoleBinding(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				ServiceAccount: "my-special-sa",
+			},
+		},
+	}
+
+	// test
+	binding := PersistenceRoleBinding(otelcol)
+
+	// verify
+	assert.Equal(t, "my-instance-targetallocator-persistence", binding.Name)
+	assert.Equal(t, "observability", binding.Namespace)
+	assert.Equal(t, rbacv1.RoleRef{
+		APIGroup: "rbac.authorization.k8s.io",
+		Kind:     "Role",
+		Name:     "my-instance-targetallocator-persistence",
+	}, binding.RoleRef)
+	assert.Equal(t, []rbacv1.Subject{
+		{
+			Kind:      "ServiceAccount",
+			Name:      "my-special-sa",
+			Namespace: "observability",
+		},
+	}, binding.Subjects)
+}